@@ -35,6 +35,8 @@ func newDestroyCmd() *cobra.Command {
 	// Flags for engine.UpdateOptions.
 	var analyzers []string
 	var diffDisplay bool
+	var eventLogPath string
+	var jsonDisplay bool
 	var parallel int
 	var refresh bool
 	var showConfig bool
@@ -62,6 +64,13 @@ func newDestroyCmd() *cobra.Command {
 			if !interactive {
 				yes = true // auto-approve changes, since we cannot prompt.
 			}
+			if jsonDisplay {
+				// Nothing but events may be written to stdout, so we cannot prompt.
+				if !yes {
+					return errors.New("--yes must be passed in along with --json, since changes cannot be confirmed")
+				}
+				interactive = false
+			}
 
 			opts, err := updateFlagsToOptions(interactive, skipPreview, yes)
 			if err != nil {
@@ -78,6 +87,11 @@ func newDestroyCmd() *cobra.Command {
 				DiffDisplay:          diffDisplay,
 				Debug:                debug,
 			}
+			closeEventLog, err := configureEventLog(&opts.Display, jsonDisplay, eventLogPath)
+			if err != nil {
+				return err
+			}
+			defer closeEventLog()
 
 			s, err := requireStack(stack, false, opts.Display, true /*setCurrent*/)
 			if err != nil {
@@ -131,6 +145,12 @@ func newDestroyCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().StringVar(
+		&eventLogPath, "event-log", "",
+		"Log every engine event to the given file as JSON lines")
+	cmd.PersistentFlags().BoolVar(
+		&jsonDisplay, "json", false,
+		"Emit every engine event to stdout as JSON lines, instead of the usual display; requires --yes")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (<=1 for no parallelism)")
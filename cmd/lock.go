@@ -0,0 +1,63 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// readLock loads the Pulumi.lock file of the project rooted at root, failing if there is none.
+func readLock(root string) (*workspace.Lock, error) {
+	path := workspace.LockPath(root)
+	lock, err := workspace.LoadLock(path)
+	if err != nil {
+		return nil, err
+	} else if lock == nil {
+		return nil, errors.Errorf("--locked was passed, but there is no %s; "+
+			"run `pulumi up` without --locked to create one", path)
+	}
+	return lock, nil
+}
+
+// writeLock pins the plugins recorded in the stack's latest snapshot in the Pulumi.lock file of the project rooted at
+// root, keeping the checksums it already records for other platforms.  The file is left untouched if it already pins
+// exactly those plugins.
+func writeLock(ctx context.Context, s backend.Stack, root string) error {
+	snap, err := s.Snapshot(ctx)
+	if err != nil {
+		return err
+	} else if snap == nil {
+		return nil
+	}
+
+	path := workspace.LockPath(root)
+	existing, err := workspace.LoadLock(path)
+	if err != nil {
+		return err
+	}
+	lock, err := workspace.NewLock(snap.Manifest.Plugins, existing)
+	if err != nil {
+		return err
+	}
+	if lock.Equal(existing) {
+		return nil
+	}
+	return lock.Save(path)
+}
@@ -15,17 +15,23 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
 	"time"
 
 	mobytime "github.com/docker/docker/api/types/time"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/apitype"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/operations"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
 )
 
 // We use RFC 5424 timestamps with millisecond precision for displaying time stamps on log entries. Go does not
@@ -36,8 +42,11 @@ const timeFormat = "2006-01-02T15:04:05.000Z07:00"
 
 func newLogsCmd() *cobra.Command {
 	var stack string
+	var filter string
 	var follow bool
+	var jsonOut bool
 	var since string
+	var until string
 	var resource string
 
 	logsCmd := &cobra.Command{
@@ -54,44 +63,77 @@ func newLogsCmd() *cobra.Command {
 				return err
 			}
 
-			startTime, err := parseSince(since, time.Now())
+			now := time.Now()
+			startTime, err := parseSince(since, now)
 			if err != nil {
 				return errors.Wrapf(err, "failed to parse argument to '--since' as duration or timestamp")
 			}
+			var endTime *time.Time
+			if until != "" {
+				if follow {
+					return errors.New("'--until' cannot be combined with '--follow'")
+				}
+				if endTime, err = parseSince(until, now); err != nil {
+					return errors.Wrapf(err, "failed to parse argument to '--until' as duration or timestamp")
+				}
+			}
 			var resourceFilter *operations.ResourceFilter
 			if resource != "" {
 				var rf = operations.ResourceFilter(resource)
 				resourceFilter = &rf
 			}
+			var messageFilter *string
+			if filter != "" {
+				if _, err = regexp.Compile(filter); err != nil {
+					return errors.Wrapf(err, "failed to parse argument to '--filter' as a regular expression")
+				}
+				messageFilter = &filter
+			}
 
-			fmt.Printf(
-				opts.Color.Colorize(colors.BrightMagenta+"Collecting logs for stack %s since %s.\n\n"+colors.Reset),
-				s.Ref().String(),
-				startTime.Format(timeFormat),
-			)
+			if !jsonOut {
+				fmt.Printf(
+					opts.Color.Colorize(colors.BrightMagenta+"Collecting logs for stack %s since %s.\n\n"+colors.Reset),
+					s.Ref().String(),
+					startTime.Format(timeFormat),
+				)
+			}
 
-			// IDEA: This map will grow forever as new log entries are found.  We may need to do a more approximate
-			// approach here to ensure we don't grow memory unboundedly while following logs.
-			//
 			// Note: Just tracking latest log date is not sufficient - as stale logs may show up which should have been
 			// displayed before previously rendered log entries, but weren't available at the time, so still need to be
 			// rendered now even though they are technically out of order.
-			shown := map[operations.LogEntry]bool{}
+			shown := newLogWindow(logWindowSize)
+
+			// Load the provider plugins that serve logs once for the whole command, rather than once per poll.
+			plugctx, err := plugin.NewContext(cmdutil.Diag(), cmdutil.Diag(), nil, nil, nil, "", nil, nil)
+			if err != nil {
+				return err
+			}
+			defer contract.IgnoreClose(plugctx)
+			ctx := operations.ContextWithProviderPlugins(commandContext(), operations.NewProviderPlugins(plugctx.Host))
+
 			for {
-				logs, err := s.GetLogs(commandContext(), operations.LogQuery{
+				logs, err := s.GetLogs(ctx, operations.LogQuery{
 					StartTime:      startTime,
+					EndTime:        endTime,
 					ResourceFilter: resourceFilter,
+					Filter:         messageFilter,
 				})
 				if err != nil {
 					return errors.Wrapf(err, "failed to get logs")
 				}
 
 				for _, logEntry := range logs {
-					if _, shownAlready := shown[logEntry]; !shownAlready {
-						eventTime := time.Unix(0, logEntry.Timestamp*1000000)
-						fmt.Printf("%30.30s[%30.30s] %v\n", eventTime.Format(timeFormat), logEntry.ID, logEntry.Message)
-						shown[logEntry] = true
+					if !shown.Add(logEntry) {
+						continue
 					}
+					if jsonOut {
+						if err = printLogEntryJSON(logEntry); err != nil {
+							return err
+						}
+						continue
+					}
+					eventTime := time.Unix(0, logEntry.Timestamp*1000000)
+					fmt.Printf("%30.30s[%30.30s] %v\n", eventTime.Format(timeFormat), logEntry.ID, logEntry.Message)
 				}
 
 				if !follow {
@@ -113,9 +155,20 @@ func newLogsCmd() *cobra.Command {
 		&since, "since", "1h",
 		"Only return logs newer than a relative duration ('5s', '2m', '3h') or absolute timestamp.  "+
 			"Defaults to returning the last 1 hour of logs.")
+	logsCmd.PersistentFlags().StringVar(
+		&until, "until", "",
+		"Only return logs older than a relative duration ('5s', '2m', '3h') or absolute timestamp.  "+
+			"Defaults to returning logs up to the present.")
 	logsCmd.PersistentFlags().StringVarP(
 		&resource, "resource", "r", "",
-		"Only return logs for the requested resource ('name', 'type::name' or full URN).  Defaults to returning all logs.")
+		"Only return logs for the requested resource ('name', 'type::name' or full URN).  Defaults to returning all logs.  "+
+			"May contain the wildcards '*' and '?', e.g. 'aws:lambda/*::*'.")
+	logsCmd.PersistentFlags().StringVar(
+		&filter, "filter", "",
+		"Only return logs whose messages match the given regular expression")
+	logsCmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false,
+		"Emit output as JSON, one log entry per line")
 
 	return logsCmd
 }
@@ -135,3 +188,75 @@ func parseSince(since string, reference time.Time) (*time.Time, error) {
 	startTime := time.Unix(startTimeSec, startTimeNs)
 	return &startTime, nil
 }
+
+// printLogEntryJSON prints a single log entry as a line of JSON.
+func printLogEntryJSON(logEntry operations.LogEntry) error {
+	b, err := json.Marshal(apitype.LogEntry{
+		ID:        logEntry.ID,
+		Timestamp: logEntry.Timestamp,
+		Message:   logEntry.Message,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+// logWindowSize is the number of recent entries remembered for each log source in `--follow` mode.
+const logWindowSize = 1000
+
+// logWindow remembers the log entries that have been shown, so that entries returned again by later polls are not
+// shown twice.  Rather than remembering every entry, which would grow without bound while following logs, it
+// remembers the newest entries from each source (i.e. each distinct entry ID), ordered by timestamp.  An entry that
+// is no newer than the newest entry to have been forgotten is assumed to have been shown already; any newer entry,
+// even one that arrives late and out of order, is shown unless it is remembered.
+type logWindow struct {
+	size    int
+	sources map[string]*sourceLogWindow
+}
+
+type sourceLogWindow struct {
+	entries    []operations.LogEntry        // the remembered entries, sorted by timestamp.
+	shown      map[operations.LogEntry]bool // the set of remembered entries.
+	horizon    int64                        // the timestamp of the newest entry to have been forgotten.
+	forgetting bool                         // true once any entry has been forgotten.
+}
+
+func newLogWindow(size int) *logWindow {
+	contract.Assert(size > 0)
+	return &logWindow{size: size, sources: make(map[string]*sourceLogWindow)}
+}
+
+// Add records the given entry, returning true if it has not been shown before.
+func (w *logWindow) Add(entry operations.LogEntry) bool {
+	source, ok := w.sources[entry.ID]
+	if !ok {
+		source = &sourceLogWindow{shown: make(map[operations.LogEntry]bool)}
+		w.sources[entry.ID] = source
+	}
+
+	if source.shown[entry] || source.forgetting && entry.Timestamp <= source.horizon {
+		return false
+	}
+
+	// Insert the entry after any others with the same timestamp, so that a late entry does not push out a newer one.
+	i := sort.Search(len(source.entries), func(i int) bool {
+		return source.entries[i].Timestamp > entry.Timestamp
+	})
+	source.entries = append(source.entries, operations.LogEntry{})
+	copy(source.entries[i+1:], source.entries[i:])
+	source.entries[i] = entry
+	source.shown[entry] = true
+
+	// Forget the oldest entry once the window is full.  Because the entries are kept in timestamp order, everything
+	// still remembered is at least as new as the horizon.
+	if len(source.entries) > w.size {
+		oldest := source.entries[0]
+		source.entries = source.entries[1:]
+		delete(source.shown, oldest)
+		source.horizon = oldest.Timestamp
+		source.forgetting = true
+	}
+	return true
+}
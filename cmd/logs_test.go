@@ -20,6 +20,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/operations"
 )
 
 func TestParseSince(t *testing.T) {
@@ -46,3 +48,36 @@ func TestParseSince(t *testing.T) {
 	f, _ := parseSince("2006-01-02-08:00", time.Now().In(pst))
 	assert.Equal(t, "2006-01-02T00:00:00-08:00", f.In(pst).Format(time.RFC3339))
 }
+
+func TestLogWindow(t *testing.T) {
+	w := newLogWindow(2)
+	a1 := operations.LogEntry{ID: "a", Timestamp: 1, Message: "one"}
+	a2 := operations.LogEntry{ID: "a", Timestamp: 2, Message: "two"}
+	a4 := operations.LogEntry{ID: "a", Timestamp: 4, Message: "four"}
+	b1 := operations.LogEntry{ID: "b", Timestamp: 1, Message: "one"}
+
+	assert.True(t, w.Add(a4))
+	assert.True(t, w.Add(a1))
+	assert.False(t, w.Add(a1))
+	assert.True(t, w.Add(b1))
+
+	// A late entry that is older than the newest entry still fills the window in timestamp order, so it is the
+	// oldest entry, rather than the first to arrive, that is forgotten.
+	assert.True(t, w.Add(a2))
+	assert.Len(t, w.sources["a"].entries, 2)
+	assert.Equal(t, []operations.LogEntry{a2, a4}, w.sources["a"].entries)
+	assert.Equal(t, int64(1), w.sources["a"].horizon)
+
+	// Entries returned again by later polls are not shown twice, whether or not they are still remembered.
+	assert.False(t, w.Add(a1))
+	assert.False(t, w.Add(a2))
+	assert.False(t, w.Add(a4))
+
+	// Late entries are shown as long as they are newer than anything forgotten.
+	assert.True(t, w.Add(operations.LogEntry{ID: "a", Timestamp: 3, Message: "late"}))
+	assert.False(t, w.Add(operations.LogEntry{ID: "a", Timestamp: 1, Message: "too late"}))
+
+	// Other sources are unaffected.
+	assert.Len(t, w.sources["b"].entries, 1)
+	assert.False(t, w.Add(b1))
+}
@@ -0,0 +1,243 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/operations"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newMetricsCmd() *cobra.Command {
+	var stack string
+	var jsonOut bool
+	var period time.Duration
+	var since string
+	var until string
+	var resource string
+
+	metricsCmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "Show metrics for a stack's resources",
+		Long: "Show metrics for a stack's resources.\n" +
+			"\n" +
+			"This command fetches time series of operational metrics, such as invocations, errors and latency, for\n" +
+			"each resource in the stack that reports them, and renders them as a table of sparklines.\n" +
+			"\n" +
+			"AWS Lambda functions report invocations, errors and latency.  Lambda does not publish a CPU metric, so\n" +
+			"no CPU series is shown for them.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(stack, false, opts, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			startTime, err := parseSince(since, now)
+			if err != nil {
+				return errors.Wrapf(err, "failed to parse argument to '--since' as duration or timestamp")
+			}
+			var endTime *time.Time
+			if until != "" {
+				if endTime, err = parseSince(until, now); err != nil {
+					return errors.Wrapf(err, "failed to parse argument to '--until' as duration or timestamp")
+				}
+			}
+			if period < 0 {
+				return errors.New("'--period' must not be negative")
+			}
+			var resourceFilter *operations.ResourceFilter
+			if resource != "" {
+				var rf = operations.ResourceFilter(resource)
+				resourceFilter = &rf
+			}
+
+			series, err := s.GetMetrics(commandContext(), operations.MetricsQuery{
+				StartTime:      startTime,
+				EndTime:        endTime,
+				Period:         period,
+				ResourceFilter: resourceFilter,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "failed to get metrics")
+			}
+
+			if jsonOut {
+				return printMetricsJSON(series)
+			}
+
+			if len(series) == 0 {
+				fmt.Printf("No metrics found for stack %s\n", s.Ref().String())
+				return nil
+			}
+			fmt.Printf(
+				opts.Color.Colorize(colors.BrightMagenta+"Metrics for stack %s since %s.\n\n"+colors.Reset),
+				s.Ref().String(),
+				startTime.Format(timeFormat),
+			)
+			printMetricsTable(series)
+			return nil
+		}),
+	}
+
+	metricsCmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	metricsCmd.PersistentFlags().StringVar(
+		&since, "since", "1h",
+		"Only return data newer than a relative duration ('5s', '2m', '3h') or absolute timestamp.  "+
+			"Defaults to returning the last 1 hour of data.")
+	metricsCmd.PersistentFlags().StringVar(
+		&until, "until", "",
+		"Only return data older than a relative duration ('5s', '2m', '3h') or absolute timestamp.  "+
+			"Defaults to returning data up to the present.")
+	metricsCmd.PersistentFlags().DurationVar(
+		&period, "period", time.Minute,
+		"The interval summarized by each data point, e.g. '1m' or '5m'")
+	metricsCmd.PersistentFlags().StringVarP(
+		&resource, "resource", "r", "",
+		"Only return metrics for the requested resource ('name', 'type::name' or full URN).  "+
+			"May contain the wildcards '*' and '?', e.g. 'aws:lambda/*::*'.")
+	metricsCmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false,
+		"Emit output as JSON")
+
+	return metricsCmd
+}
+
+// metricSeriesJSON is the shape of the --json output of `pulumi metrics`.
+type metricSeriesJSON struct {
+	Resource string                `json:"resource"`
+	Metric   string                `json:"metric"`
+	Unit     string                `json:"unit,omitempty"`
+	Points   []metricDataPointJSON `json:"points"`
+}
+
+type metricDataPointJSON struct {
+	Timestamp string  `json:"timestamp"`
+	Value     float64 `json:"value"`
+}
+
+func printMetricsJSON(series []operations.MetricSeries) error {
+	out := make([]metricSeriesJSON, 0, len(series))
+	for _, s := range series {
+		points := make([]metricDataPointJSON, 0, len(s.Points))
+		for _, p := range s.Points {
+			points = append(points, metricDataPointJSON{
+				Timestamp: time.Unix(0, p.Timestamp*int64(time.Millisecond)).UTC().Format(timeFormat),
+				Value:     p.Value,
+			})
+		}
+		out = append(out, metricSeriesJSON{
+			Resource: s.ID,
+			Metric:   string(s.Name),
+			Unit:     s.Unit,
+			Points:   points,
+		})
+	}
+	return printJSON(out)
+}
+
+func printMetricsTable(series []operations.MetricSeries) {
+	maxresource, maxmetric := len("RESOURCE"), len("METRIC")
+	for _, s := range series {
+		if len(s.ID) > maxresource {
+			maxresource = len(s.ID)
+		}
+		if len(s.Name) > maxmetric {
+			maxmetric = len(s.Name)
+		}
+	}
+
+	graphs := make([]string, len(series))
+	maxgraph := len("GRAPH")
+	for i, s := range series {
+		values := make([]float64, len(s.Points))
+		for j, p := range s.Points {
+			values[j] = p.Value
+		}
+		graphs[i] = sparkline(values)
+		if n := utf8.RuneCountInString(graphs[i]); n > maxgraph {
+			maxgraph = n
+		}
+	}
+
+	format := "%-" + strconv.Itoa(maxresource) + "s  %-" + strconv.Itoa(maxmetric) + "s  %s  %s\n"
+	pad := func(graph string) string {
+		for n := utf8.RuneCountInString(graph); n < maxgraph; n++ {
+			graph += " "
+		}
+		return graph
+	}
+
+	fmt.Printf(format, "RESOURCE", "METRIC", pad("GRAPH"), "LATEST")
+	for i, s := range series {
+		latest := "n/a"
+		if len(s.Points) > 0 {
+			latest = formatMetricValue(s.Points[len(s.Points)-1].Value, s.Unit)
+		}
+		fmt.Printf(format, s.ID, s.Name, pad(graphs[i]), latest)
+	}
+}
+
+// sparkTicks are the characters used to draw sparklines, from lowest to highest.
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders a series of values as a string of block characters, one per value, scaled so that the lowest
+// value is drawn with the shortest block and the highest with the tallest.
+func sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+
+	ticks := make([]rune, len(values))
+	for i, v := range values {
+		tick := 0
+		if hi > lo {
+			tick = int((v - lo) / (hi - lo) * float64(len(sparkTicks)-1))
+		}
+		ticks[i] = sparkTicks[tick]
+	}
+	return string(ticks)
+}
+
+// formatMetricValue renders a metric value, rounded to two decimal places, along with its unit.
+func formatMetricValue(value float64, unit string) string {
+	s := strconv.FormatFloat(math.Floor(value*100+0.5)/100, 'f', -1, 64)
+	if unit != "" {
+		s += " " + unit
+	}
+	return s
+}
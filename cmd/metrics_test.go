@@ -0,0 +1,34 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "", sparkline(nil))
+	assert.Equal(t, "▁▁▁", sparkline([]float64{5, 5, 5}))
+	assert.Equal(t, "▁▂▃▄▅▆▇█", sparkline([]float64{0, 1, 2, 3, 4, 5, 6, 7}))
+	assert.Equal(t, "█▁▆", sparkline([]float64{10, -4, 6}))
+}
+
+func TestFormatMetricValue(t *testing.T) {
+	assert.Equal(t, "3 count", formatMetricValue(3, "count"))
+	assert.Equal(t, "12.35 milliseconds", formatMetricValue(12.3456, "milliseconds"))
+	assert.Equal(t, "0.5", formatMetricValue(0.5, ""))
+}
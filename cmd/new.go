@@ -48,6 +48,7 @@ import (
 
 // nolint: vetshadow, intentionally disabling here for cleaner err declaration/assignment.
 func newNewCmd() *cobra.Command {
+	var answersPath string
 	var configArray []string
 	var description string
 	var dir string
@@ -63,7 +64,17 @@ func newNewCmd() *cobra.Command {
 		Use:        "new [template]",
 		SuggestFor: []string{"init", "create"},
 		Short:      "Create a new Pulumi project",
-		Args:       cmdutil.MaximumNArgs(1),
+		Long: "Create a new Pulumi project from a template.\n" +
+			"\n" +
+			"The template may be the name of a Pulumi template, a Git URL over HTTPS or SSH (such as\n" +
+			"git@github.com:acme/templates/service), a local directory, or a .zip or .tar.gz archive given as a\n" +
+			"path or URL. Private HTTPS repositories use the token in PULUMI_GIT_TOKEN, which is only sent to the\n" +
+			"comma-separated hosts in PULUMI_GIT_TOKEN_HOST, or Git's credential helpers; SSH repositories use the\n" +
+			"key in PULUMI_GIT_SSH_KEY, or the SSH agent.\n" +
+			"\n" +
+			"If PULUMI_TEMPLATE_REGISTRY names a Git URL or directory, its templates are offered and looked up by\n" +
+			"name instead of the public Pulumi templates.",
+		Args: cmdutil.MaximumNArgs(1),
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			interactive := cmdutil.Interactive()
 			if !interactive {
@@ -96,6 +107,18 @@ func newNewCmd() *cobra.Command {
 			}
 			originalCwd := cwd
 
+			templateNameOrURL := ""
+			if len(args) > 0 {
+				templateNameOrURL = args[0]
+			}
+
+			// Resolve local template paths before changing into dir, so they're relative to where we were run.
+			if workspace.IsTemplatePath(templateNameOrURL) {
+				if templateNameOrURL, err = filepath.Abs(templateNameOrURL); err != nil {
+					return errors.Wrap(err, "resolving the template path")
+				}
+			}
+
 			// If dir was specified, ensure it exists and use it as the
 			// current working directory.
 			if dir != "" {
@@ -130,11 +153,6 @@ func newNewCmd() *cobra.Command {
 				}
 			}
 
-			templateNameOrURL := ""
-			if len(args) > 0 {
-				templateNameOrURL = args[0]
-			}
-
 			// Retrieve the template repo.
 			repo, err := workspace.RetrieveTemplates(templateNameOrURL, offline)
 			if err != nil {
@@ -188,7 +206,7 @@ func newNewCmd() *cobra.Command {
 			}
 
 			// Show instructions, if we're going to show at least one prompt.
-			hasAtLeastOnePrompt := (name == "") || (description == "") || (stack == "")
+			hasAtLeastOnePrompt := (name == "") || (description == "") || (stack == "") || len(template.Prompts) > 0
 			if !yes && hasAtLeastOnePrompt {
 				fmt.Println("This command will walk you through creating a new Pulumi project.")
 				fmt.Println()
@@ -215,8 +233,14 @@ func newNewCmd() *cobra.Command {
 				}
 			}
 
+			// Answer the template's own prompts, if it has any.
+			answers, err := promptForTemplateAnswers(template, answersPath, yes, opts.Display)
+			if err != nil {
+				return err
+			}
+
 			// Actually copy the files.
-			if err = template.CopyTemplateFiles(cwd, force, name, description); err != nil {
+			if err = template.CopyTemplateFiles(cwd, force, name, description, answers); err != nil {
 				if os.IsNotExist(err) {
 					return errors.Wrapf(err, "template '%s' not found", templateNameOrURL)
 				}
@@ -309,6 +333,9 @@ func newNewCmd() *cobra.Command {
 		}
 	})
 
+	cmd.PersistentFlags().StringVar(
+		&answersPath, "answers", "",
+		"A YAML or JSON file of answers to the template's prompts; any prompts it does not answer are asked")
 	cmd.PersistentFlags().StringArrayVarP(
 		&configArray, "config", "c", []string{},
 		"Config to save")
@@ -728,6 +755,51 @@ func promptForConfig(
 	return c, nil
 }
 
+// promptForTemplateAnswers answers a template's prompts, using the answers in the file at answersPath (if any) and
+// prompting for the rest.  If yes is true, the remaining prompts take their default answers instead.
+func promptForTemplateAnswers(template workspace.Template, answersPath string, yes bool,
+	opts display.Options) (workspace.TemplateAnswers, error) {
+
+	provided := make(map[string]string)
+	if answersPath != "" {
+		var err error
+		if provided, err = workspace.LoadTemplateAnswers(answersPath); err != nil {
+			return nil, err
+		}
+	}
+
+	return template.ResolveAnswers(provided, func(p workspace.ProjectTemplatePrompt) (string, error) {
+		if yes {
+			if _, err := p.Parse(p.Default); err != nil {
+				return "", errors.Errorf("prompt %s has no default answer; provide one with --answers", p.Name)
+			}
+			return p.Default, nil
+		}
+
+		prompt := p.Name
+		if p.Description != "" {
+			prompt = prompt + ": " + p.Description
+		}
+		switch p.PromptType() {
+		case workspace.PromptTypeEnum:
+			prompt = prompt + " [" + strings.Join(p.Enum, ", ") + "]"
+		case workspace.PromptTypeBoolean:
+			prompt = prompt + " [true, false]"
+		}
+
+		for {
+			value, err := promptForValue(false, prompt, p.Default, false, nil, opts)
+			if err != nil {
+				return "", err
+			}
+			if _, err = p.Parse(value); err == nil {
+				return value, nil
+			}
+			fmt.Printf("Sorry, %v.\n", err)
+		}
+	})
+}
+
 // promptForValue prompts the user for a value with a defaultValue preselected. Hitting enter accepts the
 // default. If yes is true, defaultValue is returned without prompting. isValidFn is an optional parameter;
 // when specified, it will be run to validate that value entered. An invalid value will result in an error
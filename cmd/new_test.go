@@ -0,0 +1,53 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func TestPromptForTemplateAnswersNonInteractive(t *testing.T) {
+	template := workspace.Template{
+		Name: "test",
+		Prompts: []workspace.ProjectTemplatePrompt{
+			{Name: "region", Type: workspace.PromptTypeEnum, Enum: []string{"us-east-1", "us-west-2"}},
+			{Name: "count", Type: workspace.PromptTypeInteger, Default: "2"},
+		},
+	}
+
+	dir, err := ioutil.TempDir("", "pulumi-new")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	answersPath := filepath.Join(dir, "answers.yaml")
+	assert.NoError(t, ioutil.WriteFile(answersPath, []byte("region: us-west-2\n"), 0600))
+
+	// Answers come from the file, and anything it leaves out takes its default.
+	answers, err := promptForTemplateAnswers(template, answersPath, true, display.Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, workspace.TemplateAnswers{"region": "us-west-2", "count": 2}, answers)
+
+	// A prompt without a default must be answered.
+	_, err = promptForTemplateAnswers(template, "", true, display.Options{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "--answers")
+}
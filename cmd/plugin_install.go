@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
@@ -27,14 +28,18 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend/httpstate"
 	"github.com/pulumi/pulumi/pkg/diag"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/workspace"
 )
 
 func newPluginInstallCmd() *cobra.Command {
+	var checksum string
 	var cloudURL string
 	var exact bool
 	var file string
+	var mirrors []string
 	var reinstall bool
+	var skipChecksum bool
 	var verbose bool
 
 	var cmd = &cobra.Command{
@@ -48,8 +53,23 @@ func newPluginInstallCmd() *cobra.Command {
 			"letting Pulumi compute the set of plugins that may be required by the current\n" +
 			"project.  VERSION cannot be a range: it must be a specific number.\n" +
 			"\n" +
-			"If you let Pulumi compute the set to download, it is conservative and may end up\n" +
-			"downloading more plugins than is strictly necessary.",
+			"If the project has a Pulumi.lock file, the exact plugins it pins are installed and\n" +
+			"checked against their locked tarball checksums.  Otherwise, if you let Pulumi compute the set\n" +
+			"to download, it is conservative and may end up downloading more plugins than is\n" +
+			"strictly necessary.\n" +
+			"\n" +
+			"Plugins are downloaded from the Pulumi service unless plugin mirrors are configured,\n" +
+			"either with --mirror, the PULUMI_PLUGIN_MIRRORS environment variable (a comma-separated\n" +
+			"list), or the `pluginMirrors` workspace setting.  A mirror is a base URL or a local\n" +
+			"directory holding `pulumi-<kind>-<name>-v<version>-<os>-<arch>.tar.gz` tarballs, each\n" +
+			"next to a `.sha256` file containing its SHA-256 checksum.  Mirrors are tried in order.\n" +
+			"\n" +
+			"A tarball is checked against a SHA-256 checksum before it is installed whenever one is\n" +
+			"available, and one whose checksum does not match is never installed.  The checksum comes from\n" +
+			"--checksum, from the project's Pulumi.lock, from the mirror, or from a `.sha256` file next to\n" +
+			"a --file tarball.  The Pulumi service does not publish checksums, so a plugin downloaded from\n" +
+			"it with none of these is installed unverified, with a warning.  A plugin pinned by Pulumi.lock\n" +
+			"without a checksum for this platform is not installed, unless --skip-checksum is passed.",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			displayOpts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
@@ -57,6 +77,7 @@ func newPluginInstallCmd() *cobra.Command {
 
 			// Parse the kind, name, and version, if specified.
 			var installs []workspace.PluginInfo
+			var lock *workspace.Lock
 			if len(args) > 0 {
 				if !workspace.IsPluginKind(args[0]) {
 					return errors.Errorf("unrecognized plugin kind: %s", args[0])
@@ -78,12 +99,25 @@ func newPluginInstallCmd() *cobra.Command {
 				if file != "" {
 					return errors.New("--file (-f) is only valid if a specific package is being installed")
 				}
+				if checksum != "" {
+					return errors.New("--checksum is only valid if a specific package is being installed")
+				}
 
-				// If a specific plugin wasn't given, compute the set of plugins the current project needs.
-				plugins, err := getProjectPlugins()
+				// If a specific plugin wasn't given, install exactly the plugins pinned by the project's lock file, or
+				// if there is none, compute the set of plugins the current project needs.
+				_, root, err := readProject()
 				if err != nil {
 					return err
 				}
+				if lock, err = workspace.LoadLock(workspace.LockPath(root)); err != nil {
+					return err
+				}
+				plugins := lock.PluginInfos()
+				if lock != nil {
+					exact = true
+				} else if plugins, err = getProjectPlugins(); err != nil {
+					return err
+				}
 				for _, plugin := range plugins {
 					// Skip language plugins; by definition, we already have one installed.
 					// TODO[pulumi/pulumi#956]: eventually we will want to honor and install these in the usual way.
@@ -93,9 +127,16 @@ func newPluginInstallCmd() *cobra.Command {
 				}
 			}
 
-			// Target the cloud URL for downloads.
+			// Prefer mirrors, if any are configured, over the cloud URL for downloads.
+			if len(mirrors) == 0 {
+				cwd, err := os.Getwd()
+				if err != nil {
+					return err
+				}
+				mirrors = workspace.GetPluginMirrors(cwd)
+			}
 			var releases httpstate.Backend
-			if len(installs) > 0 && file == "" {
+			if len(installs) > 0 && file == "" && len(mirrors) == 0 {
 				r, err := httpstate.New(cmdutil.Diag(), httpstate.ValueOrDefaultURL(cloudURL))
 				if err != nil {
 					return errors.Wrap(err, "creating API client")
@@ -134,8 +175,17 @@ func newPluginInstallCmd() *cobra.Command {
 				// If we got here, actually try to do the download.
 				var source string
 				var tarball io.ReadCloser
+				var expected string
 				var err error
-				if file == "" {
+				if file == "" && len(mirrors) > 0 {
+					if verbose {
+						cmdutil.Diag().Infoerrf(
+							diag.Message("", "%s downloading from mirrors %s"), label, strings.Join(mirrors, ", "))
+					}
+					if tarball, expected, source, err = install.DownloadFromMirrors(mirrors); err != nil {
+						return errors.Wrapf(err, "%s downloading", label)
+					}
+				} else if file == "" {
 					source = releases.CloudURL()
 					if verbose {
 						cmdutil.Diag().Infoerrf(
@@ -150,15 +200,38 @@ func newPluginInstallCmd() *cobra.Command {
 						cmdutil.Diag().Infoerrf(
 							diag.Message("", "%s opening tarball from %s"), label, file)
 					}
+					if expected, err = workspace.ReadTarballChecksum(file); err != nil {
+						return err
+					}
 					if tarball, err = os.Open(file); err != nil {
 						return errors.Wrapf(err, "opening file %s", source)
 					}
 				}
+
+				// Whatever its source, the tarball is verified before anything is extracted from it.  A checksum given
+				// on the command line takes precedence over a locked one, which takes precedence over a published one.
+				locked, isLocked := lock.Find(install.Kind, install.Name, install.Version)
+				if checksum != "" {
+					expected = checksum
+				} else if isLocked && locked.Checksum() != "" {
+					expected = locked.Checksum()
+				}
+				if expected == "" {
+					if isLocked && !skipChecksum {
+						contract.IgnoreClose(tarball)
+						return errors.Errorf("%s is pinned by Pulumi.lock, but no checksum is available to verify "+
+							"the tarball from %s; pass --checksum, or --skip-checksum to install it unverified",
+							label, source)
+					}
+					cmdutil.Diag().Warningf(
+						diag.Message("", "%s installing without verifying a checksum, as %s does not publish one"),
+						label, source)
+				}
 				if verbose {
 					cmdutil.Diag().Infoerrf(
 						diag.Message("", "%s installing tarball ..."), label)
 				}
-				if err = install.Install(tarball); err != nil {
+				if err = install.InstallWithChecksum(tarball, expected); err != nil {
 					return errors.Wrapf(err, "installing %s from %s", label, source)
 				}
 			}
@@ -167,14 +240,20 @@ func newPluginInstallCmd() *cobra.Command {
 		}),
 	}
 
+	cmd.PersistentFlags().StringVar(&checksum,
+		"checksum", "", "The SHA-256 checksum that the plugin's tarball must match")
 	cmd.PersistentFlags().StringVarP(&cloudURL,
 		"cloud-url", "c", "", "A cloud URL to download releases from")
 	cmd.PersistentFlags().BoolVar(&exact,
 		"exact", false, "Force installation of an exact version match (usually >= is accepted)")
 	cmd.PersistentFlags().StringVarP(&file,
 		"file", "f", "", "Install a plugin from a tarball file, instead of downloading it")
+	cmd.PersistentFlags().StringSliceVar(&mirrors,
+		"mirror", nil, "A plugin mirror URL or directory to download from; may be repeated")
 	cmd.PersistentFlags().BoolVar(&reinstall,
 		"reinstall", false, "Reinstall a plugin even if it already exists")
+	cmd.PersistentFlags().BoolVar(&skipChecksum,
+		"skip-checksum", false, "Install locked plugins for which no checksum is available, without verifying them")
 	cmd.PersistentFlags().BoolVar(&verbose,
 		"verbose", false, "Print detailed information about the installation steps")
 
@@ -0,0 +1,228 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func newPluginPruneCmd() *cobra.Command {
+	var days int
+	var dryRun bool
+	var yes bool
+	var cmd = &cobra.Command{
+		Use:   "prune",
+		Args:  cmdutil.NoArgs,
+		Short: "Remove unused plugins from the download cache",
+		Long: "Remove unused plugins from the download cache.\n" +
+			"\n" +
+			"A plugin version is removed if no stack in the current backend has it in its\n" +
+			"latest snapshot, the current project's Pulumi.lock does not pin it, and it has\n" +
+			"not been used within the last --days days.  If a snapshot references a plugin\n" +
+			"without a version, the newest installed version of that plugin is kept.\n" +
+			"\n" +
+			"Pass --dry-run to see what would be removed, and how much space would be freed,\n" +
+			"without removing anything.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+			if days < 0 {
+				return errors.New("--days must not be negative")
+			}
+
+			installed, err := workspace.GetPlugins()
+			if err != nil {
+				return errors.Wrap(err, "loading plugins")
+			}
+
+			b, err := currentBackend(opts)
+			if err != nil {
+				return err
+			}
+			referenced, err := getReferencedPlugins(commandContext(), b)
+			if err != nil {
+				return err
+			}
+
+			cutoff := time.Now().AddDate(0, 0, -days)
+			prunes := prunablePlugins(installed, referenced, cutoff)
+
+			var totalSize, pruneSize uint64
+			for _, plugin := range installed {
+				totalSize += uint64(plugin.Size)
+			}
+			for _, plugin := range prunes {
+				pruneSize += uint64(plugin.Size)
+			}
+
+			if len(prunes) == 0 {
+				fmt.Printf("No plugins to remove; the plugin cache uses %s.\n", humanize.Bytes(totalSize))
+				return nil
+			}
+
+			verb := "This will remove"
+			if dryRun {
+				verb = "Would remove"
+			}
+			fmt.Print(
+				opts.Color.Colorize(
+					fmt.Sprintf("%s%s %d of %d plugins, freeing %s of %s:%s\n",
+						colors.SpecAttention, verb, len(prunes), len(installed),
+						humanize.Bytes(pruneSize), humanize.Bytes(totalSize), colors.Reset)))
+			for _, plugin := range prunes {
+				lastUsed := humanNeverTime
+				if !plugin.LastUsedTime.IsZero() {
+					lastUsed = humanize.Time(plugin.LastUsedTime)
+				}
+				fmt.Printf("    %-10s %-32s %10s  last used %s\n",
+					plugin.Kind, plugin.String(), humanize.Bytes(uint64(plugin.Size)), lastUsed)
+			}
+			if dryRun || !(yes || confirmPrompt("", "yes", opts)) {
+				return nil
+			}
+
+			var result error
+			for _, plugin := range prunes {
+				if err := plugin.Delete(); err != nil {
+					result = multierror.Append(
+						result, errors.Wrapf(err, "failed to delete %s plugin %s", plugin.Kind, plugin))
+				}
+			}
+			return result
+		}),
+	}
+
+	cmd.PersistentFlags().IntVar(
+		&days, "days", 30,
+		"Keep plugins that have been used within this many days")
+	cmd.PersistentFlags().BoolVar(
+		&dryRun, "dry-run", false,
+		"Only report what would be removed and how much space it would free")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Skip confirmation prompts, and proceed with removal anyway")
+
+	return cmd
+}
+
+// getReferencedPlugins returns the plugins recorded in the latest snapshot of every stack in the backend, together
+// with those pinned by the current project's lock file, if there is one.
+func getReferencedPlugins(ctx context.Context, b backend.Backend) ([]workspace.PluginInfo, error) {
+	summaries, err := b.ListStacks(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing stacks")
+	}
+
+	var plugins []workspace.PluginInfo
+	for _, summary := range summaries {
+		s, stackErr := b.GetStack(ctx, summary.Name())
+		if stackErr != nil {
+			return nil, errors.Wrapf(stackErr, "loading stack %s", summary.Name())
+		} else if s == nil {
+			continue
+		}
+		snap, stackErr := s.Snapshot(ctx)
+		if stackErr != nil {
+			return nil, errors.Wrapf(stackErr, "loading snapshot of stack %s", summary.Name())
+		} else if snap != nil {
+			plugins = append(plugins, snap.Manifest.Plugins...)
+		}
+	}
+
+	path, err := workspace.DetectProjectPath()
+	if err != nil {
+		return nil, err
+	} else if path != "" {
+		lock, err := workspace.LoadLock(workspace.LockPath(filepath.Dir(path)))
+		if err != nil {
+			return nil, err
+		}
+		plugins = append(plugins, lock.PluginInfos()...)
+	}
+
+	return plugins, nil
+}
+
+// prunablePlugins returns the installed plugins that are not referenced and were last used (or, if never used,
+// installed) before the cutoff.  A reference without a version protects the newest installed version of the plugin,
+// since that is the one that would be loaded for it.
+func prunablePlugins(installed, referenced []workspace.PluginInfo, cutoff time.Time) []workspace.PluginInfo {
+	kindName := func(plugin workspace.PluginInfo) string {
+		return string(plugin.Kind) + "-" + plugin.Name
+	}
+
+	keep := make(map[string]bool)
+	unversioned := make(map[string]bool)
+	for _, plugin := range referenced {
+		if plugin.Version != nil {
+			keep[plugin.Dir()] = true
+		} else {
+			unversioned[kindName(plugin)] = true
+		}
+	}
+
+	newest := make(map[string]*semver.Version)
+	for _, plugin := range installed {
+		key := kindName(plugin)
+		if unversioned[key] && plugin.Version != nil && (newest[key] == nil || plugin.Version.GT(*newest[key])) {
+			newest[key] = plugin.Version
+		}
+	}
+	for key, version := range newest {
+		keep[key+"-v"+version.String()] = true
+	}
+
+	var prunes []workspace.PluginInfo
+	for _, plugin := range installed {
+		if keep[plugin.Dir()] {
+			continue
+		}
+		lastUsed := plugin.LastUsedTime
+		if plugin.InstallTime.After(lastUsed) {
+			lastUsed = plugin.InstallTime
+		}
+		if lastUsed.Before(cutoff) {
+			prunes = append(prunes, plugin)
+		}
+	}
+
+	sort.Slice(prunes, func(i, j int) bool {
+		pi, pj := prunes[i], prunes[j]
+		if pi.Kind != pj.Kind {
+			return pi.Kind < pj.Kind
+		} else if pi.Name != pj.Name {
+			return pi.Name < pj.Name
+		}
+		return pi.Version != nil && pj.Version != nil && pi.Version.LT(*pj.Version)
+	})
+	return prunes
+}
@@ -0,0 +1,73 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func TestPrunablePlugins(t *testing.T) {
+	now := time.Date(2018, 11, 1, 0, 0, 0, 0, time.UTC)
+	cutoff := now.AddDate(0, 0, -30)
+	old := now.AddDate(0, 0, -90)
+	recent := now.AddDate(0, 0, -1)
+
+	plugin := func(kind workspace.PluginKind, name, version string, installed, used time.Time) workspace.PluginInfo {
+		v := semver.MustParse(version)
+		return workspace.PluginInfo{Kind: kind, Name: name, Version: &v, InstallTime: installed, LastUsedTime: used}
+	}
+	ref := func(kind workspace.PluginKind, name, version string) workspace.PluginInfo {
+		info := workspace.PluginInfo{Kind: kind, Name: name}
+		if version != "" {
+			v := semver.MustParse(version)
+			info.Version = &v
+		}
+		return info
+	}
+
+	installed := []workspace.PluginInfo{
+		plugin(workspace.ResourcePlugin, "aws", "0.15.0", old, old),                // unreferenced and unused: pruned
+		plugin(workspace.ResourcePlugin, "aws", "0.16.0", old, old),                // referenced by a snapshot: kept
+		plugin(workspace.ResourcePlugin, "aws", "0.16.2", old, recent),             // used recently: kept
+		plugin(workspace.ResourcePlugin, "gcp", "0.14.0", old, old),                // older than the newest: pruned
+		plugin(workspace.ResourcePlugin, "gcp", "0.16.0", old, old),                // newest, referenced unversioned: kept
+		plugin(workspace.ResourcePlugin, "azure", "0.16.0", recent, time.Time{}),   // installed recently: kept
+		plugin(workspace.AnalyzerPlugin, "aws", "0.1.0", time.Time{}, time.Time{}), // nothing known: pruned
+	}
+	referenced := []workspace.PluginInfo{
+		ref(workspace.ResourcePlugin, "aws", "0.16.0"),
+		ref(workspace.ResourcePlugin, "gcp", ""),
+		ref(workspace.ResourcePlugin, "kubernetes", "0.17.0"),
+	}
+
+	var pruned []string
+	for _, p := range prunablePlugins(installed, referenced, cutoff) {
+		pruned = append(pruned, p.Dir())
+	}
+	assert.Equal(t, []string{"analyzer-aws-v0.1.0", "resource-aws-v0.15.0", "resource-gcp-v0.14.0"}, pruned)
+
+	// With an earlier cutoff, only the plugin we know nothing about is still pruned.
+	pruned = nil
+	for _, p := range prunablePlugins(installed, referenced, old.Add(-time.Hour)) {
+		pruned = append(pruned, p.Dir())
+	}
+	assert.Equal(t, []string{"analyzer-aws-v0.1.0"}, pruned)
+}
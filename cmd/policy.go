@@ -0,0 +1,241 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func newPolicyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Manage and test policy analyzers",
+		Long: "Manage and test policy analyzers.\n" +
+			"\n" +
+			"Analyzers are plugins that check the resources in a stack against a set of policies\n" +
+			"before any changes are made to them.  Each analyzer sees every resource as it is\n" +
+			"planned, and then the entire proposed stack at once, so that policies may span\n" +
+			"multiple resources.  Policy violations are either advisory, in which case they are\n" +
+			"reported as warnings, or mandatory, in which case they block the update.  Because the\n" +
+			"entire stack is only known once the program has finished, `pulumi up` first runs the\n" +
+			"program as a preview to check it, whether or not --skip-preview is passed.\n" +
+			"\n" +
+			"Analyzers are enabled for a project by listing them under `analyzers` in Pulumi.yaml,\n" +
+			"or for a single update by passing `--analyzer` to `pulumi up` or `pulumi preview`.",
+		Args: cmdutil.NoArgs,
+	}
+
+	cmd.AddCommand(newPolicyLsCmd())
+	cmd.AddCommand(newPolicyTestCmd())
+
+	return cmd
+}
+
+func newPolicyTestCmd() *cobra.Command {
+	var stack string
+
+	cmd := &cobra.Command{
+		Use:   "test [analyzer...]",
+		Short: "Run analyzers against a stack's current resources",
+		Long: "Run analyzers against a stack's current resources.\n" +
+			"\n" +
+			"This command runs the given analyzers -- or, if none are given, those enabled for the\n" +
+			"current project -- against the resources in the stack's most recent checkpoint, and\n" +
+			"reports any policy violations.  No changes are made to the stack.  The command fails\n" +
+			"if any mandatory violations are found.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			proj, root, err := readProject()
+			if err != nil {
+				return err
+			}
+
+			var analyzers []tokens.QName
+			for _, arg := range args {
+				analyzers = append(analyzers, tokens.QName(arg))
+			}
+			if len(analyzers) == 0 && proj.Analyzers != nil {
+				analyzers = *proj.Analyzers
+			}
+			if len(analyzers) == 0 {
+				return errors.New("no analyzers were given, and the current project does not enable any")
+			}
+
+			s, err := requireStack(stack, false, opts, false /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			snap, err := s.Snapshot(commandContext())
+			if err != nil {
+				return err
+			}
+			policy, err := getPolicyConfig(proj, s.Ref().Name())
+			if err != nil {
+				return errors.Wrap(err, "reading policy settings")
+			}
+
+			_, _, ctx, err := engine.ProjectInfoContext(&engine.Projinfo{Proj: proj, Root: root},
+				nil, nil, nil, cmdutil.Diag(), cmdutil.Diag(), nil)
+			if err != nil {
+				return err
+			}
+			defer contract.IgnoreClose(ctx)
+
+			resources := analyzerResourcesFromSnapshot(snap)
+
+			var mandatory, advisory int
+			for _, name := range analyzers {
+				failures, err := runAnalyzer(ctx.Host, name, policy[name], resources)
+				if err != nil {
+					return err
+				}
+				for _, failure := range failures {
+					printPolicyFailure(name, failure)
+					if failure.EnforcementLevel == plugin.Advisory {
+						advisory++
+					} else {
+						mandatory++
+					}
+				}
+			}
+
+			if mandatory+advisory == 0 {
+				fmt.Printf("No policy violations found in %d resources\n", len(resources))
+				return nil
+			}
+			fmt.Printf("\nFound %d mandatory and %d advisory policy violations\n", mandatory, advisory)
+			if mandatory > 0 {
+				return errors.New("mandatory policy violations found")
+			}
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+
+	return cmd
+}
+
+// getPolicyConfig returns the analyzer settings from the `policy` sections of the project file and the given stack's
+// file, with the stack's settings taking precedence.
+func getPolicyConfig(proj *workspace.Project, stackName tokens.QName) (workspace.PolicyConfig, error) {
+	ps, err := workspace.DetectProjectStack(stackName)
+	if err != nil {
+		return nil, err
+	}
+	return proj.Policy.Merge(ps.Policy), nil
+}
+
+// analyzerResourcesFromSnapshot returns the live resources in the given snapshot, in the form that analyzers expect.
+// Resources that are pending deletion are skipped.
+func analyzerResourcesFromSnapshot(snap *deploy.Snapshot) []plugin.AnalyzerResource {
+	if snap == nil {
+		return nil
+	}
+
+	var resources []plugin.AnalyzerResource
+	for _, res := range snap.Resources {
+		if res.Delete {
+			continue
+		}
+		resources = append(resources, plugin.AnalyzerResource{
+			URN:          res.URN,
+			Type:         res.Type,
+			Properties:   res.Inputs,
+			Parent:       res.Parent,
+			Dependencies: res.Dependencies,
+			Provider:     res.Provider,
+			Custom:       res.Custom,
+		})
+	}
+	return resources
+}
+
+// runAnalyzer configures a single analyzer with the given settings, if any, and runs it against each of the given
+// resources and then against all of them at once, returning every failure it reports.  Failures for individual
+// resources are attributed to those resources.
+func runAnalyzer(host plugin.Host, name tokens.QName, settings map[string]interface{},
+	resources []plugin.AnalyzerResource) ([]plugin.AnalyzeFailure, error) {
+
+	analyzer, err := host.Analyzer(name)
+	if err != nil {
+		return nil, err
+	} else if analyzer == nil {
+		return nil, errors.Errorf("analyzer '%v' could not be loaded from your $PATH", name)
+	}
+	if settings != nil {
+		if err = analyzer.Configure(resource.NewPropertyMapFromMap(settings)); err != nil {
+			return nil, errors.Wrapf(err, "configuring analyzer '%v'", name)
+		}
+	}
+
+	var results []plugin.AnalyzeFailure
+	for _, r := range resources {
+		failures, analyzeErr := analyzer.Analyze(r)
+		if analyzeErr != nil {
+			return nil, errors.Wrapf(analyzeErr, "analyzing %s", r.URN)
+		}
+		for _, failure := range failures {
+			if failure.URN == "" {
+				failure.URN = r.URN
+			}
+			results = append(results, failure)
+		}
+	}
+
+	failures, err := analyzer.AnalyzeStack(resources)
+	if err != nil {
+		return nil, errors.Wrap(err, "analyzing stack")
+	}
+	return append(results, failures...), nil
+}
+
+// printPolicyFailure prints a single policy violation reported by the named analyzer.
+func printPolicyFailure(name tokens.QName, failure plugin.AnalyzeFailure) {
+	level := failure.EnforcementLevel
+	if level == "" {
+		level = plugin.Mandatory
+	}
+
+	target := string(failure.URN)
+	if target == "" {
+		target = "(stack)"
+	}
+	fmt.Printf("[%s] %s: %s\n", level, name, target)
+
+	reason := failure.Reason
+	if failure.Property != "" {
+		reason = fmt.Sprintf("%s: %s", failure.Property, reason)
+	}
+	fmt.Printf("    %s\n", reason)
+}
@@ -0,0 +1,114 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func newPolicyLsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ls",
+		Short: "List installed analyzers",
+		Long: "List installed analyzers.\n" +
+			"\n" +
+			"This command lists the analyzer plugins that are installed, along with whether each\n" +
+			"is enabled for the current project.  Analyzers that the project enables but that are\n" +
+			"not installed are listed too, so that they may be installed with `pulumi plugin install`.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			plugins, err := workspace.GetPlugins()
+			if err != nil {
+				return errors.Wrapf(err, "loading plugins")
+			}
+
+			// Find the analyzers enabled by the current project, if there is one.
+			enabled := make(map[string]bool)
+			if proj, _, projErr := readProject(); projErr == nil && proj.Analyzers != nil {
+				for _, a := range *proj.Analyzers {
+					enabled[string(a)] = true
+				}
+			}
+
+			type analyzerRow struct {
+				name, version string
+				installed     bool
+			}
+			var rows []analyzerRow
+			installed := make(map[string]bool)
+			for _, plugin := range plugins {
+				if plugin.Kind != workspace.AnalyzerPlugin {
+					continue
+				}
+				var version string
+				if plugin.Version != nil {
+					version = plugin.Version.String()
+				}
+				rows = append(rows, analyzerRow{name: plugin.Name, version: version, installed: true})
+				installed[plugin.Name] = true
+			}
+			for name := range enabled {
+				if !installed[name] {
+					rows = append(rows, analyzerRow{name: name, version: naString})
+				}
+			}
+			sort.Slice(rows, func(i, j int) bool {
+				if rows[i].name != rows[j].name {
+					return rows[i].name < rows[j].name
+				}
+				return rows[i].version > rows[j].version
+			})
+
+			if len(rows) == 0 {
+				fmt.Printf("No analyzers are installed or enabled for this project\n")
+				return nil
+			}
+
+			// Devote 26 characters to the name width, unless there is a longer name.
+			maxname := 26
+			for _, row := range rows {
+				if len(row.name) > maxname {
+					maxname = len(row.name)
+				}
+			}
+
+			fmt.Printf("%-"+strconv.Itoa(maxname)+"s %-26s %-12s %-12s\n", "NAME", "VERSION", "INSTALLED", "ENABLED")
+			for _, row := range rows {
+				fmt.Printf("%-"+strconv.Itoa(maxname)+"s %-26s %-12s %-12s\n",
+					row.name, row.version, yesNo(row.installed), yesNo(enabled[row.name]))
+			}
+
+			return nil
+		}),
+	}
+
+	return cmd
+}
+
+// yesNo returns "yes" if the given value is true, and "no" otherwise.
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -15,6 +15,9 @@
 package cmd
 
 import (
+	"encoding/json"
+	"os"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
@@ -22,18 +25,24 @@ import (
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/engine"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
 )
 
 func newPreviewCmd() *cobra.Command {
 	var debug bool
 	var expectNop bool
 	var message string
+	var savePlan string
 	var stack string
 
 	// Flags for engine.UpdateOptions.
 	var analyzers []string
 	var diffDisplay bool
+	var eventLogPath string
+	var jsonDisplay bool
+	var locked bool
 	var parallel int
+	var policies []string
 	var showConfig bool
 	var showReplacementSteps bool
 	var showSames bool
@@ -59,7 +68,7 @@ func newPreviewCmd() *cobra.Command {
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := backend.UpdateOptions{
 				Engine: engine.UpdateOptions{
-					Analyzers: analyzers,
+					Analyzers: append(analyzers, policies...),
 					Parallel:  parallel,
 					Debug:     debug,
 				},
@@ -69,11 +78,19 @@ func newPreviewCmd() *cobra.Command {
 					ShowReplacementSteps: showReplacementSteps,
 					ShowSameResources:    showSames,
 					SuppressOutputs:      suppressOutputs,
-					IsInteractive:        cmdutil.Interactive(),
+					IsInteractive:        cmdutil.Interactive() && !jsonDisplay,
 					DiffDisplay:          diffDisplay,
 					Debug:                debug,
 				},
 			}
+			closeEventLog, err := configureEventLog(&opts.Display, jsonDisplay, eventLogPath)
+			if err != nil {
+				return err
+			}
+			defer closeEventLog()
+			if savePlan != "" {
+				opts.Display.PlanRecorder = display.NewPlanRecorder()
+			}
 
 			s, err := requireStack(stack, true, opts.Display, true /*setCurrent*/)
 			if err != nil {
@@ -85,6 +102,15 @@ func newPreviewCmd() *cobra.Command {
 				return err
 			}
 
+			if opts.Engine.Policy, err = getPolicyConfig(proj, s.Ref().Name()); err != nil {
+				return errors.Wrap(err, "reading policy settings")
+			}
+			if locked {
+				if opts.Engine.Lock, err = readLock(root); err != nil {
+					return err
+				}
+			}
+
 			m, err := getUpdateMetadata("", root)
 			if err != nil {
 				return errors.Wrap(err, "gathering environment metadata")
@@ -97,14 +123,18 @@ func newPreviewCmd() *cobra.Command {
 				Opts:   opts,
 				Scopes: cancellationScopes,
 			})
-			switch {
-			case err != nil:
+			if err != nil {
 				return PrintEngineError(err)
-			case expectNop && changes != nil && changes.HasChanges():
+			}
+			if savePlan != "" {
+				if err = writePlan(savePlan, opts.Display.PlanRecorder, proj.Name.String(), s); err != nil {
+					return err
+				}
+			}
+			if expectNop && changes != nil && changes.HasChanges() {
 				return errors.New("error: no changes were expected but changes were proposed")
-			default:
-				return nil
 			}
+			return nil
 		}),
 	}
 
@@ -114,6 +144,9 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&expectNop, "expect-no-changes", false,
 		"Return an error if any changes are proposed by this preview")
+	cmd.PersistentFlags().StringVar(
+		&savePlan, "save-plan", "",
+		"Save a machine-readable description of the previewed steps to the given file as JSON")
 	cmd.PersistentFlags().StringVarP(
 		&stack, "stack", "s", "",
 		"The name of the stack to operate on. Defaults to the current stack")
@@ -129,9 +162,21 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().StringVar(
+		&eventLogPath, "event-log", "",
+		"Log every engine event to the given file as JSON lines")
+	cmd.PersistentFlags().BoolVar(
+		&jsonDisplay, "json", false,
+		"Emit every engine event to stdout as JSON lines, instead of the usual display")
+	cmd.PersistentFlags().BoolVar(
+		&locked, "locked", false,
+		"Only load the exact plugin versions pinned in Pulumi.lock")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (<=1 for no parallelism)")
+	cmd.PersistentFlags().StringSliceVar(
+		&policies, "policy", []string{},
+		"Enable one or more policy analyzers for this update, in addition to those in the project")
 	cmd.PersistentFlags().BoolVar(
 		&showConfig, "show-config", false,
 		"Show configuration keys and variables")
@@ -147,3 +192,23 @@ func newPreviewCmd() *cobra.Command {
 
 	return cmd
 }
+
+// writePlan writes the plan built by the given recorder for the given project and stack to a file as JSON.
+func writePlan(path string, recorder *display.PlanRecorder, project string, s backend.Stack) error {
+	plan := recorder.Plan()
+	plan.Project = project
+	plan.Stack = s.Ref().String()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "could not create plan file")
+	}
+	defer contract.IgnoreClose(f)
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "    ")
+	if err = enc.Encode(plan); err != nil {
+		return errors.Wrap(err, "could not save plan")
+	}
+	return nil
+}
@@ -150,6 +150,7 @@ func NewPulumiCmd() *cobra.Command {
 	cmd.AddCommand(newUpCmd())
 	cmd.AddCommand(newPreviewCmd())
 	cmd.AddCommand(newDestroyCmd())
+	cmd.AddCommand(newWorkspaceCmd())
 	//     - Stack Management Commands:
 	cmd.AddCommand(newStackCmd())
 	cmd.AddCommand(newConfigCmd())
@@ -162,7 +163,9 @@ func NewPulumiCmd() *cobra.Command {
 	cmd.AddCommand(newRefreshCmd())
 	//     - Other Commands:
 	cmd.AddCommand(newLogsCmd())
+	cmd.AddCommand(newMetricsCmd())
 	cmd.AddCommand(newPluginCmd())
+	cmd.AddCommand(newPolicyCmd())
 	cmd.AddCommand(newVersionCmd())
 
 	// Less common, and thus hidden, commands:
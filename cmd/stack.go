@@ -154,13 +154,17 @@ func newStackCmd() *cobra.Command {
 		&showURNs, "show-urns", "u", false, "Display each resource's Pulumi-assigned globally unique URN")
 
 	cmd.AddCommand(newStackExportCmd())
+	cmd.AddCommand(newStackGCCmd())
 	cmd.AddCommand(newStackGraphCmd())
 	cmd.AddCommand(newStackImportCmd())
 	cmd.AddCommand(newStackInitCmd())
+	cmd.AddCommand(newStackLockStatusCmd())
 	cmd.AddCommand(newStackLsCmd())
 	cmd.AddCommand(newStackOutputCmd())
+	cmd.AddCommand(newStackRenameCmd())
 	cmd.AddCommand(newStackRmCmd())
 	cmd.AddCommand(newStackSelectCmd())
+	cmd.AddCommand(newStackTagCmd())
 
 	return cmd
 }
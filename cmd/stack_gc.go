@@ -0,0 +1,318 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/backend/state"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func newStackGCCmd() *cobra.Command {
+	var dryRun bool
+	var yes bool
+
+	var cmd = &cobra.Command{
+		Use:   "gc",
+		Args:  cmdutil.NoArgs,
+		Short: "Destroy and remove expired stacks",
+		Long: "Destroy and remove expired stacks\n" +
+			"\n" +
+			"This command finds every stack in the backend whose time to live, set with\n" +
+			"`pulumi stack init --ttl`, has passed. Each is destroyed, removed, and its configuration\n" +
+			"file deleted. If a stack's configuration file is missing, as in a fresh checkout, the\n" +
+			"configuration of its most recent deployment is used to destroy it.\n" +
+			"\n" +
+			"Stacks of the current project are destroyed using the project. Stacks of other projects\n" +
+			"are destroyed using the project name and runtime recorded by their most recent deployment\n" +
+			"and their tags. A stack whose configuration has secrets encrypted with a passphrase can only\n" +
+			"be destroyed from its own project's directory, where its configuration file holds the salt.\n" +
+			"\n" +
+			"Stacks that fail to be destroyed are left in place, and are retried the next time this\n" +
+			"command runs.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			interactive := cmdutil.Interactive()
+			opts := display.Options{
+				Color:         cmdutil.GetGlobalColorization(),
+				IsInteractive: interactive,
+			}
+
+			b, err := currentBackend(opts)
+			if err != nil {
+				return err
+			}
+
+			// The current project, if there is one, is used to destroy its own stacks.
+			var proj *workspace.Project
+			var root string
+			if path, pathErr := workspace.DetectProjectPath(); pathErr == nil && path != "" {
+				if proj, root, err = readProject(); err != nil {
+					return err
+				}
+			}
+
+			summaries, err := b.ListStacks(commandContext(), nil)
+			if err != nil {
+				return err
+			}
+			expired := expiredStacks(summaries, time.Now())
+			if len(expired) == 0 {
+				fmt.Println("No expired stacks")
+				return nil
+			}
+
+			maxname := len("NAME")
+			for _, s := range expired {
+				if len(s.Ref.String()) > maxname {
+					maxname = len(s.Ref.String())
+				}
+			}
+			fmt.Printf("%-"+strconv.Itoa(maxname)+"s %s\n", "NAME", "EXPIRED")
+			for _, s := range expired {
+				fmt.Printf("%-"+strconv.Itoa(maxname)+"s %s\n", s.Ref, s.Expires.Local().Format(time.RFC3339))
+			}
+			if dryRun {
+				return nil
+			}
+
+			if !yes {
+				if !interactive {
+					return errors.New("--yes must be passed in to destroy expired stacks in non-interactive mode")
+				}
+				prompt := fmt.Sprintf("This will permanently destroy %d expired stack(s) and all of their resources!",
+					len(expired))
+				if !confirmPrompt(prompt, "yes", opts) {
+					return errors.New("confirmation declined")
+				}
+			}
+
+			current, err := state.CurrentStack(commandContext(), b)
+			if err != nil {
+				return err
+			}
+
+			failed := 0
+			for _, s := range expired {
+				fmt.Printf("\nDestroying stack '%s'\n", s.Ref)
+				if err := gcStack(b, s, proj, root, opts); err != nil {
+					cmdutil.Diag().Errorf(diag.Message("", "failed to remove stack '%s': %v"), s.Ref, err)
+					failed++
+					continue
+				}
+				if current != nil && current.Ref().String() == s.Ref.String() {
+					contract.IgnoreError(state.SetCurrentStack(""))
+				}
+				fmt.Printf("Stack '%s' has been removed\n", s.Ref)
+			}
+
+			if failed > 0 {
+				return errors.Errorf("%d of %d expired stacks could not be removed", failed, len(expired))
+			}
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVar(
+		&dryRun, "dry-run", false,
+		"List the expired stacks without destroying them")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Skip confirmation prompts, and proceed with destroying and removing the expired stacks")
+
+	return cmd
+}
+
+// expiredStack is a stack whose time to live has passed.
+type expiredStack struct {
+	Ref     backend.StackReference
+	Expires time.Time
+	Tags    map[apitype.StackTagName]string
+}
+
+// expiredStacks returns the stacks that expired before now, in the order they expired. Stacks without an expiry, or
+// with one that can't be parsed, never expire.
+func expiredStacks(summaries []backend.StackSummary, now time.Time) []expiredStack {
+	var expired []expiredStack
+	for _, summary := range summaries {
+		tags := summary.Tags()
+		value, has := tags[apitype.StackExpiresTag]
+		if !has {
+			continue
+		}
+		expires, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			continue
+		}
+		if expires.Before(now) {
+			expired = append(expired, expiredStack{Ref: summary.Name(), Expires: expires, Tags: tags})
+		}
+	}
+
+	sort.SliceStable(expired, func(i, j int) bool {
+		return expired[i].Expires.Before(expired[j].Expires)
+	})
+	return expired
+}
+
+// gcStack destroys the resources of the given stack and then removes it and its configuration. The stack is destroyed
+// using the given project if it belongs to it, or if its project is unknown because it was never deployed; otherwise,
+// its project is recovered from its last deployment, and it is destroyed from a temporary directory holding just that
+// project.
+func gcStack(b backend.Backend, expired expiredStack, proj *workspace.Project, root string,
+	displayOpts display.Options) error {
+
+	ref := expired.Ref
+	s, err := b.GetStack(commandContext(), ref)
+	if err != nil {
+		return err
+	} else if s == nil {
+		return errors.Errorf("stack '%s' not found", ref)
+	}
+
+	name, runtime, err := getStackProject(s, expired.Tags)
+	if err != nil {
+		return err
+	}
+	if proj == nil || name != "" && name != proj.Name {
+		if name == "" {
+			return errors.Errorf("could not determine the project of stack '%s' from its last deployment", ref)
+		} else if runtime == "" {
+			return errors.Errorf("could not determine the runtime of project '%s' from its last deployment", name)
+		}
+		stackProj := &workspace.Project{Name: name, RuntimeInfo: workspace.NewProjectRuntimeInfo(runtime, nil)}
+
+		temp, tempErr := ioutil.TempDir("", "pulumi-gc-")
+		if tempErr != nil {
+			return tempErr
+		}
+		defer func() { contract.IgnoreError(os.RemoveAll(temp)) }()
+		if err = stackProj.Save(filepath.Join(temp, "Pulumi.yaml")); err != nil {
+			return err
+		}
+
+		cwd, cwdErr := os.Getwd()
+		if cwdErr != nil {
+			return cwdErr
+		}
+		if err = os.Chdir(temp); err != nil {
+			return errors.Wrap(err, "changing the working directory")
+		}
+		defer func() { contract.IgnoreError(os.Chdir(cwd)) }()
+		proj, root = stackProj, temp
+	}
+
+	// Destroying needs the stack's configuration; recover it from the last deployment if it isn't here.
+	configPath, err := workspace.DetectProjectStackPath(ref.Name())
+	if err != nil {
+		return err
+	}
+	if _, err = os.Stat(configPath); os.IsNotExist(err) {
+		c, cfgErr := backend.GetLatestConfiguration(commandContext(), s)
+		if cfgErr != nil && cfgErr != backend.ErrNoPreviousDeployment {
+			return errors.Wrap(cfgErr, "getting the configuration of the last deployment")
+		}
+		if c != nil {
+			// Secrets encrypted with a passphrase can only be decrypted with the salt from the stack's original
+			// configuration file, which is not part of the deployment.
+			if c.HasSecureValue() && filestate.IsLocalBackendURL(b.URL()) {
+				return errors.Errorf("the configuration of stack '%s' has secrets, but its configuration file, "+
+					"which holds their encryption salt, is missing; run this command from the directory of project "+
+					"'%s' with %s present", ref, proj.Name, filepath.Base(configPath))
+			}
+			ps := &workspace.ProjectStack{Config: c}
+			if err = ps.Save(configPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	m, err := getUpdateMetadata("Destroying expired stack", root)
+	if err != nil {
+		return errors.Wrap(err, "gathering environment metadata")
+	}
+
+	displayOpts.IsInteractive = false
+	_, err = s.Destroy(commandContext(), backend.UpdateOperation{
+		Proj: proj,
+		Root: root,
+		M:    m,
+		Opts: backend.UpdateOptions{
+			AutoApprove: true,
+			SkipPreview: true,
+			Display:     displayOpts,
+			Engine:      engine.UpdateOptions{Parallel: defaultParallel},
+		},
+		Scopes: cancellationScopes,
+	})
+	if err == context.Canceled {
+		return errors.New("destroy cancelled")
+	} else if err != nil {
+		return err
+	}
+
+	if _, err = s.Remove(commandContext(), false /*force*/); err != nil {
+		return err
+	}
+
+	// Blow away stack specific settings if they exist.
+	if err = os.Remove(configPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// getStackProject returns the name and runtime of the project that the given stack was last deployed from, if known.
+// The name is that of the project of the resources in its last deployment, or failing that its project tag. The runtime
+// comes from its runtime tag, or failing that the language plugin recorded by its last deployment.
+func getStackProject(s backend.Stack, tags map[apitype.StackTagName]string) (tokens.PackageName, string, error) {
+	snap, err := s.Snapshot(commandContext())
+	if err != nil {
+		return "", "", errors.Wrap(err, "getting the last deployment")
+	}
+
+	name := tokens.PackageName(tags[apitype.ProjectNameTag])
+	runtime := tags[apitype.ProjectRuntimeTag]
+	if snap != nil {
+		if len(snap.Resources) > 0 {
+			name = snap.Resources[0].URN.Project()
+		}
+		for _, plugin := range snap.Manifest.Plugins {
+			if runtime == "" && plugin.Kind == workspace.LanguagePlugin {
+				runtime = plugin.Name
+			}
+		}
+	}
+	return name, runtime, nil
+}
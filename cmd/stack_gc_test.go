@@ -0,0 +1,123 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+type testStackReference string
+
+func (r testStackReference) String() string     { return string(r) }
+func (r testStackReference) Name() tokens.QName { return tokens.QName(r) }
+
+type testStackSummary struct {
+	name string
+	tags map[apitype.StackTagName]string
+}
+
+func (s testStackSummary) Name() backend.StackReference            { return testStackReference(s.name) }
+func (s testStackSummary) LastUpdate() *time.Time                  { return nil }
+func (s testStackSummary) LastUpdateKind() *apitype.UpdateKind     { return nil }
+func (s testStackSummary) LastUpdateResult() *apitype.UpdateResult { return nil }
+func (s testStackSummary) UpdateInProgress() bool                  { return false }
+func (s testStackSummary) ResourceCount() *int                     { return nil }
+func (s testStackSummary) Tags() map[apitype.StackTagName]string   { return s.tags }
+
+func TestExpiredStacks(t *testing.T) {
+	now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	summary := func(name, project, expires string) backend.StackSummary {
+		tags := map[apitype.StackTagName]string{apitype.ProjectNameTag: project}
+		if expires != "" {
+			tags[apitype.StackExpiresTag] = expires
+		}
+		return testStackSummary{name: name, tags: tags}
+	}
+
+	expired := expiredStacks([]backend.StackSummary{
+		summary("pr-2", "app", "2019-06-01T11:00:00Z"),
+		summary("pr-1", "app", "2019-05-31T12:00:00Z"),
+		summary("pr-3", "app", "2019-06-01T13:00:00Z"), // not yet expired
+		summary("prod", "app", ""),                     // never expires
+		summary("bad", "app", "tomorrow"),              // unparseable, never expires
+		summary("other", "other", "2019-05-01T00:00:00Z"),
+		summary("pr-4", "app", "2019-06-01T13:30:00+02:00"),
+	}, now)
+
+	// Expired stacks of every project are collected.
+	var names []string
+	for _, s := range expired {
+		names = append(names, s.Ref.String())
+	}
+	assert.Equal(t, []string{"other", "pr-1", "pr-2", "pr-4"}, names)
+	assert.Equal(t, "other", expired[0].Tags[apitype.ProjectNameTag])
+	assert.Equal(t, time.Date(2019, 5, 31, 12, 0, 0, 0, time.UTC), expired[1].Expires.UTC())
+}
+
+// testSnapshotStack is a stack whose only behavior is to return its snapshot.
+type testSnapshotStack struct {
+	backend.Stack
+	snap *deploy.Snapshot
+}
+
+func (s testSnapshotStack) Ref() backend.StackReference { return testStackReference("pr-1") }
+func (s testSnapshotStack) Snapshot(ctx context.Context) (*deploy.Snapshot, error) {
+	return s.snap, nil
+}
+
+func TestGetStackProject(t *testing.T) {
+	tags := map[apitype.StackTagName]string{apitype.ProjectNameTag: "tagged", apitype.ProjectRuntimeTag: "python"}
+
+	// The project of the deployed resources takes precedence over the project tag, and the runtime tag over the
+	// recorded language plugin.
+	snap := &deploy.Snapshot{
+		Manifest: deploy.Manifest{Plugins: []workspace.PluginInfo{
+			{Name: "aws", Kind: workspace.ResourcePlugin},
+			{Name: "nodejs", Kind: workspace.LanguagePlugin},
+		}},
+		Resources: []*resource.State{{URN: resource.DefaultRootStackURN("pr-1", "deployed")}},
+	}
+	name, runtime, err := getStackProject(testSnapshotStack{snap: snap}, tags)
+	assert.NoError(t, err)
+	assert.Equal(t, tokens.PackageName("deployed"), name)
+	assert.Equal(t, "python", runtime)
+
+	name, runtime, err = getStackProject(testSnapshotStack{snap: snap}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, tokens.PackageName("deployed"), name)
+	assert.Equal(t, "nodejs", runtime)
+
+	// A stack that was never deployed only has its tags.
+	name, runtime, err = getStackProject(testSnapshotStack{}, tags)
+	assert.NoError(t, err)
+	assert.Equal(t, tokens.PackageName("tagged"), name)
+	assert.Equal(t, "python", runtime)
+
+	name, runtime, err = getStackProject(testSnapshotStack{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, tokens.PackageName(""), name)
+	assert.Equal(t, "", runtime)
+}
@@ -15,15 +15,25 @@
 package cmd
 
 import (
+	"io"
 	"os"
+	"sort"
+	"strings"
 
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/graph"
 	"github.com/pulumi/pulumi/pkg/graph/dotconv"
+	"github.com/pulumi/pulumi/pkg/graph/graphmlconv"
+	"github.com/pulumi/pulumi/pkg/graph/jsonconv"
+	"github.com/pulumi/pulumi/pkg/graph/mermaidconv"
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
-	"github.com/spf13/cobra"
 )
 
 // Whether or not we should ignore parent edges when building up our graph.
@@ -38,8 +48,37 @@ var dependencyEdgeColor string
 // The color of parent edges in the graph. Defaults to #AA6639, an orange.
 var parentEdgeColor string
 
+// Whether or not we should ignore edges from resources to the providers that manage them.
+var ignoreProviderEdges bool
+
+// The color of provider edges in the graph. Defaults to #4B5A9E, a slate blue.
+var providerEdgeColor string
+
+// graphPrinters are the formats in which a graph can be written, keyed by the name passed to `--format`.
+var graphPrinters = map[string]func(graph.Graph, io.Writer) error{
+	"dot":     dotconv.Print,
+	"graphml": graphmlconv.Print,
+	"json":    jsonconv.Print,
+	"mermaid": mermaidconv.Print,
+}
+
+// stepOpColors are the colors given to resources by `--highlight-changes`, keyed by the operation a preview would
+// perform on them.  Resources that would be left unchanged are not colored.
+var stepOpColors = map[deploy.StepOp]string{
+	deploy.OpCreate:  "#2E8B57", // green
+	deploy.OpUpdate:  "#C9A227", // yellow
+	deploy.OpReplace: "#A0409E", // magenta
+	deploy.OpDelete:  "#C0392B", // red
+	deploy.OpRead:    "#2B7BB9", // blue
+}
+
 func newStackGraphCmd() *cobra.Command {
 	var stackName string
+	var format string
+	var filterTypes []string
+	var rootURN string
+	var depth int
+	var highlightChanges bool
 
 	cmd := &cobra.Command{
 		Use:   "graph",
@@ -48,8 +87,14 @@ func newStackGraphCmd() *cobra.Command {
 		Long: "Export a stack's dependency graph to a file.\n" +
 			"\n" +
 			"This command can be used to view the dependency graph that a Pulumi program\n" +
-			"admitted when it was ran. This graph is output in the DOT format. This command operates\n" +
-			"on your stack's most recent deployment.",
+			"admitted when it was ran. This graph is output in the DOT format by default; use\n" +
+			"`--format` to choose Mermaid, GraphML or JSON instead. This command operates on your\n" +
+			"stack's most recent deployment.\n" +
+			"\n" +
+			"Use `--root` to graph only the resources connected to a given resource, optionally\n" +
+			"limited to `--depth` edges away, and `--filter-type` to graph only resources of the\n" +
+			"given types. `--highlight-changes` runs a preview and colors each resource by the\n" +
+			"operation the preview would perform on it.",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
@@ -59,18 +104,39 @@ func newStackGraphCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			printGraph, ok := graphPrinters[format]
+			if !ok {
+				return errors.Errorf("unrecognized graph format '%s'; expected one of %s",
+					format, strings.Join(graphFormats(), ", "))
+			}
+
 			snap, err := s.Snapshot(commandContext())
 			if err != nil {
 				return err
 			}
+			if snap == nil {
+				return errors.Errorf("stack '%s' has no resources", s.Ref())
+			}
+
+			resources, err := selectGraphResources(snap.Resources, resource.URN(rootURN), depth, filterTypes)
+			if err != nil {
+				return err
+			}
+
+			var ops map[resource.URN]deploy.StepOp
+			if highlightChanges {
+				if ops, err = previewStepOps(s); err != nil {
+					return err
+				}
+			}
 
-			dg := makeDependencyGraph(snap)
+			dg := makeDependencyGraph(resources, ops)
 			file, err := os.Create(args[0])
 			if err != nil {
 				return err
 			}
 
-			if err := dotconv.Print(dg, file); err != nil {
+			if err := printGraph(dg, file); err != nil {
 				_ = file.Close()
 				return err
 			}
@@ -90,12 +156,166 @@ func newStackGraphCmd() *cobra.Command {
 		"Sets the color of dependency edges in the graph")
 	cmd.PersistentFlags().StringVar(&parentEdgeColor, "parent-edge-color", "#AA6639",
 		"Sets the color of parent edges in the graph")
+	cmd.PersistentFlags().BoolVar(&ignoreProviderEdges, "ignore-provider-edges", false,
+		"Ignores edges from resources to the providers that manage them")
+	cmd.PersistentFlags().StringVar(&providerEdgeColor, "provider-edge-color", "#4B5A9E",
+		"Sets the color of provider edges in the graph")
+	cmd.PersistentFlags().StringVar(&format, "format", "dot",
+		"The format in which to write the graph: "+strings.Join(graphFormats(), ", "))
+	cmd.PersistentFlags().StringSliceVar(&filterTypes, "filter-type", nil,
+		"Only include resources of the given type(s) in the graph")
+	cmd.PersistentFlags().StringVar(&rootURN, "root", "",
+		"Only include the resource with the given URN and the resources connected to it")
+	cmd.PersistentFlags().IntVar(&depth, "depth", -1,
+		"With --root, the maximum number of edges between the root and an included resource; -1 for no limit")
+	cmd.PersistentFlags().BoolVar(&highlightChanges, "highlight-changes", false,
+		"Run a preview and color each resource by the operation it would perform")
 	return cmd
 }
 
+// graphFormats returns the names of the supported graph formats, in sorted order.
+func graphFormats() []string {
+	formats := make([]string, 0, len(graphPrinters))
+	for f := range graphPrinters {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// selectGraphResources returns the resources to include in a graph, in their original order.  If root is non-empty,
+// only the root and the resources connected to it by a dependency, parent or provider relationship, in either
+// direction, are included; if depth is non-negative, only those at most depth such relationships away are.  If types
+// is non-empty, only resources of those types are included.
+func selectGraphResources(resources []*resource.State, root resource.URN, depth int,
+	types []string) ([]*resource.State, error) {
+
+	selected := make(map[resource.URN]bool)
+	if root == "" {
+		for _, res := range resources {
+			selected[res.URN] = true
+		}
+	} else {
+		// Build an undirected adjacency list of all relationships, then search outward from the root.
+		neighbors := make(map[resource.URN][]resource.URN)
+		link := func(a, b resource.URN) {
+			neighbors[a] = append(neighbors[a], b)
+			neighbors[b] = append(neighbors[b], a)
+		}
+		found := false
+		for _, res := range resources {
+			if res.URN == root {
+				found = true
+			}
+			for _, dep := range res.Dependencies {
+				link(res.URN, dep)
+			}
+			if res.Parent != "" {
+				link(res.URN, res.Parent)
+			}
+			if provURN, ok := providerURN(res); ok {
+				link(res.URN, provURN)
+			}
+		}
+		if !found {
+			return nil, errors.Errorf("no resource with URN '%s' found in the stack", root)
+		}
+
+		selected[root] = true
+		frontier := []resource.URN{root}
+		for d := 0; len(frontier) > 0 && (depth < 0 || d < depth); d++ {
+			var next []resource.URN
+			for _, urn := range frontier {
+				for _, n := range neighbors[urn] {
+					if !selected[n] {
+						selected[n] = true
+						next = append(next, n)
+					}
+				}
+			}
+			frontier = next
+		}
+	}
+
+	if len(types) > 0 {
+		allowed := make(map[string]bool, len(types))
+		for _, t := range types {
+			allowed[t] = true
+		}
+		for _, res := range resources {
+			if !allowed[string(res.Type)] {
+				delete(selected, res.URN)
+			}
+		}
+	}
+
+	var result []*resource.State
+	for _, res := range resources {
+		if selected[res.URN] {
+			result = append(result, res)
+		}
+	}
+	return result, nil
+}
+
+// providerURN returns the URN of the provider that manages the given resource, if it has one.
+func providerURN(res *resource.State) (resource.URN, bool) {
+	if res.Provider == "" {
+		return "", false
+	}
+	ref, err := providers.ParseReference(res.Provider)
+	if err != nil {
+		return "", false
+	}
+	return ref.URN(), true
+}
+
+// previewStepOps runs a preview of the current project against the given stack, without displaying it, and returns
+// the logical operation the preview would perform on each resource.
+func previewStepOps(s backend.Stack) (map[resource.URN]deploy.StepOp, error) {
+	proj, root, err := readProject()
+	if err != nil {
+		return nil, err
+	}
+	m, err := getUpdateMetadata("", root)
+	if err != nil {
+		return nil, errors.Wrap(err, "gathering environment metadata")
+	}
+
+	recorder := display.NewPlanRecorder()
+	opts := backend.UpdateOptions{
+		Display: display.Options{
+			Color:        cmdutil.GetGlobalColorization(),
+			JSONDisplay:  true,
+			PlanRecorder: recorder,
+		},
+	}
+	if opts.Engine.Policy, err = getPolicyConfig(proj, s.Ref().Name()); err != nil {
+		return nil, errors.Wrap(err, "reading policy settings")
+	}
+
+	if _, err = s.Preview(commandContext(), backend.UpdateOperation{
+		Proj:   proj,
+		Root:   root,
+		M:      m,
+		Opts:   opts,
+		Scopes: cancellationScopes,
+	}); err != nil {
+		return nil, PrintEngineError(err)
+	}
+
+	ops := make(map[resource.URN]deploy.StepOp)
+	for _, step := range recorder.Plan().Steps {
+		if step.Logical {
+			ops[resource.URN(step.URN)] = deploy.StepOp(step.Op)
+		}
+	}
+	return ops, nil
+}
+
 // All of the types and code within this file are to provide implementations of the interfaces
-// in the `graph` package, so that we can use the `dotconv` package to output our graph in the
-// DOT format.
+// in the `graph` package, so that we can use the `dotconv` package (and its siblings) to output
+// our graph in the DOT format (or others).
 //
 // `dependencyEdge` implements graph.Edge, `dependencyVertex` implements graph.Vertex, and
 // `dependencyGraph` implements `graph.Graph`.
@@ -109,9 +329,8 @@ func (edge *dependencyEdge) Data() interface{} {
 	return nil
 }
 
-// In this simple case, edges have no label.
 func (edge *dependencyEdge) Label() string {
-	return ""
+	return "dependency"
 }
 
 func (edge *dependencyEdge) To() graph.Vertex {
@@ -138,9 +357,8 @@ func (edge *parentEdge) Data() interface{} {
 	return nil
 }
 
-// In this simple case, edges have no label.
 func (edge *parentEdge) Label() string {
-	return ""
+	return "parent"
 }
 
 func (edge *parentEdge) To() graph.Vertex {
@@ -155,12 +373,40 @@ func (edge *parentEdge) Color() string {
 	return parentEdgeColor
 }
 
+// providerEdges connect resources to the provider instances that manage them. An edge
+// exists from node A to node B if node B is the provider of node A.
+type providerEdge struct {
+	to   *dependencyVertex
+	from *dependencyVertex
+}
+
+func (edge *providerEdge) Data() interface{} {
+	return nil
+}
+
+func (edge *providerEdge) Label() string {
+	return "provider"
+}
+
+func (edge *providerEdge) To() graph.Vertex {
+	return edge.to
+}
+
+func (edge *providerEdge) From() graph.Vertex {
+	return edge.from
+}
+
+func (edge *providerEdge) Color() string {
+	return providerEdgeColor
+}
+
 // A dependencyVertex contains a reference to the graph to which it belongs
 // and to the resource state that it represents. Incoming and outgoing edges
 // are calculated on-demand using the combination of the graph and the state.
 type dependencyVertex struct {
 	graph         *dependencyGraph
 	resource      *resource.State
+	op            deploy.StepOp // the operation a preview would perform on this resource, if known.
 	incomingEdges []graph.Edge
 	outgoingEdges []graph.Edge
 }
@@ -173,6 +419,11 @@ func (vertex *dependencyVertex) Label() string {
 	return string(vertex.resource.URN)
 }
 
+// Vertices are colored by the operation a preview would perform on them, if one was run.
+func (vertex *dependencyVertex) Color() string {
+	return stepOpColors[vertex.op]
+}
+
 func (vertex *dependencyVertex) Ins() []graph.Edge {
 	return vertex.incomingEdges
 }
@@ -188,13 +439,14 @@ func (vertex *dependencyVertex) Outs() []graph.Edge {
 // the graph. It is constructed directly from a snapshot.
 type dependencyGraph struct {
 	vertices map[resource.URN]*dependencyVertex
+	order    []*dependencyVertex // the vertices, in the order of the resources they were made from.
 }
 
 // Roots are edges that point to the root set of our graph. In our case,
 // for simplicity, we define the root set of our dependency graph to be everything.
 func (dg *dependencyGraph) Roots() []graph.Edge {
 	rootEdges := []graph.Edge{}
-	for _, vertex := range dg.vertices {
+	for _, vertex := range dg.order {
 		edge := &dependencyEdge{
 			to:   vertex,
 			from: nil,
@@ -206,28 +458,35 @@ func (dg *dependencyGraph) Roots() []graph.Edge {
 	return rootEdges
 }
 
-// Makes a dependency graph from a deployment snapshot, allocating a vertex
-// for every resource in the graph.
-func makeDependencyGraph(snapshot *deploy.Snapshot) *dependencyGraph {
+// Makes a dependency graph from a list of resources, allocating a vertex for
+// every resource. Relationships with resources outside of the list are omitted.
+// If ops is non-nil, each vertex records the operation a preview would perform
+// on its resource.
+func makeDependencyGraph(resources []*resource.State, ops map[resource.URN]deploy.StepOp) *dependencyGraph {
 	dg := &dependencyGraph{
 		vertices: make(map[resource.URN]*dependencyVertex),
 	}
 
-	for _, resource := range snapshot.Resources {
+	for _, resource := range resources {
 		vertex := &dependencyVertex{
 			graph:    dg,
 			resource: resource,
+			op:       ops[resource.URN],
 		}
 
 		dg.vertices[resource.URN] = vertex
+		dg.order = append(dg.order, vertex)
 	}
 
-	for _, vertex := range dg.vertices {
+	for _, vertex := range dg.order {
 		if !ignoreDependencyEdges {
 			// Incoming edges are directly stored within the checkpoint file; they represent
 			// resources on which this vertex immediately depends upon.
 			for _, dep := range vertex.resource.Dependencies {
-				vertexWeDependOn := vertex.graph.vertices[dep]
+				vertexWeDependOn, ok := vertex.graph.vertices[dep]
+				if !ok {
+					continue
+				}
 				edge := &dependencyEdge{to: vertex, from: vertexWeDependOn}
 				vertex.incomingEdges = append(vertex.incomingEdges, edge)
 				vertexWeDependOn.outgoingEdges = append(vertexWeDependOn.outgoingEdges, edge)
@@ -239,11 +498,24 @@ func makeDependencyGraph(snapshot *deploy.Snapshot) *dependencyGraph {
 		// edges.
 		if !ignoreParentEdges {
 			if parent := vertex.resource.Parent; parent != resource.URN("") {
-				parentVertex := dg.vertices[parent]
-				vertex.outgoingEdges = append(vertex.outgoingEdges, &parentEdge{
-					to:   parentVertex,
-					from: vertex,
-				})
+				if parentVertex, ok := dg.vertices[parent]; ok {
+					vertex.outgoingEdges = append(vertex.outgoingEdges, &parentEdge{
+						to:   parentVertex,
+						from: vertex,
+					})
+				}
+			}
+		}
+
+		// likewise, each resource is connected to the provider instance that manages it.
+		if !ignoreProviderEdges {
+			if provURN, ok := providerURN(vertex.resource); ok {
+				if providerVertex, ok := dg.vertices[provURN]; ok {
+					vertex.outgoingEdges = append(vertex.outgoingEdges, &providerEdge{
+						to:   providerVertex,
+						from: vertex,
+					})
+				}
 			}
 		}
 	}
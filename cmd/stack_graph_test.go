@@ -0,0 +1,143 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/graph/graphmlconv"
+	"github.com/pulumi/pulumi/pkg/graph/jsonconv"
+	"github.com/pulumi/pulumi/pkg/graph/mermaidconv"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func graphTestResources(t *testing.T) []*resource.State {
+	newURN := func(typ tokens.Type, name string) resource.URN {
+		return resource.NewURN("test", "test", "", typ, tokens.QName(name))
+	}
+	newState := func(urn resource.URN, parent resource.URN, deps []resource.URN, provider string) *resource.State {
+		return resource.NewState(urn.Type(), urn, true, false, "", resource.PropertyMap{}, resource.PropertyMap{},
+			parent, false, false, deps, nil, provider)
+	}
+
+	provURN := newURN(providers.MakeProviderType("pkgA"), "prov")
+	provRef, err := providers.NewReference(provURN, "prov-id")
+	assert.NoError(t, err)
+
+	componentURN := newURN("pkgA:m:component", "component")
+	bucketURN := newURN("pkgA:m:bucket", "bucket")
+	functionURN := newURN("pkgA:m:function", "function")
+	otherURN := newURN("pkgA:m:bucket", "other")
+
+	return []*resource.State{
+		newState(provURN, "", nil, ""),
+		newState(componentURN, "", nil, ""),
+		newState(bucketURN, componentURN, nil, provRef.String()),
+		newState(functionURN, componentURN, []resource.URN{bucketURN}, provRef.String()),
+		newState(otherURN, "", nil, ""),
+	}
+}
+
+func graphResourceNames(resources []*resource.State) []string {
+	var names []string
+	for _, res := range resources {
+		names = append(names, string(res.URN.Name()))
+	}
+	return names
+}
+
+func TestSelectGraphResources(t *testing.T) {
+	resources := graphTestResources(t)
+	function := resources[3].URN
+
+	all, err := selectGraphResources(resources, "", -1, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"prov", "component", "bucket", "function", "other"}, graphResourceNames(all))
+
+	// The function is one edge away from its parent, its dependency and its provider.
+	near, err := selectGraphResources(resources, function, 1, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"prov", "component", "bucket", "function"}, graphResourceNames(near))
+
+	self, err := selectGraphResources(resources, function, 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"function"}, graphResourceNames(self))
+
+	buckets, err := selectGraphResources(resources, "", -1, []string{"pkgA:m:bucket"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bucket", "other"}, graphResourceNames(buckets))
+
+	_, err = selectGraphResources(resources, "urn:pulumi:test::test::pkgA:m:bucket::missing", -1, nil)
+	assert.Error(t, err)
+}
+
+func TestDependencyGraphFormats(t *testing.T) {
+	oldColors := []string{dependencyEdgeColor, parentEdgeColor, providerEdgeColor}
+	dependencyEdgeColor, parentEdgeColor, providerEdgeColor = "#111111", "#222222", "#333333"
+	defer func() {
+		dependencyEdgeColor, parentEdgeColor, providerEdgeColor = oldColors[0], oldColors[1], oldColors[2]
+	}()
+
+	resources := graphTestResources(t)
+	// Graph the component and its children only; edges to the provider, which is left out, are dropped.
+	dg := makeDependencyGraph(resources[1:4], map[resource.URN]deploy.StepOp{
+		resources[2].URN: deploy.OpUpdate,
+		resources[3].URN: deploy.OpSame,
+	})
+
+	g := jsonconv.Convert(dg)
+	assert.Equal(t, []jsonconv.Node{
+		{ID: "Resource0", Label: string(resources[1].URN)},
+		{ID: "Resource1", Label: string(resources[2].URN), Color: stepOpColors[deploy.OpUpdate]},
+		{ID: "Resource2", Label: string(resources[3].URN)},
+	}, g.Nodes)
+	assert.Equal(t, []jsonconv.Edge{
+		{From: "Resource1", To: "Resource0", Label: "parent", Color: parentEdgeColor},
+		{From: "Resource1", To: "Resource2", Label: "dependency", Color: dependencyEdgeColor},
+		{From: "Resource2", To: "Resource0", Label: "parent", Color: parentEdgeColor},
+	}, g.Edges)
+
+	var buf bytes.Buffer
+	assert.NoError(t, jsonconv.Print(dg, &buf))
+	var decoded jsonconv.Graph
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, g, decoded)
+
+	buf.Reset()
+	assert.NoError(t, mermaidconv.Print(dg, &buf))
+	assert.Contains(t, buf.String(), "graph TD\n")
+	assert.Contains(t, buf.String(), "    Resource1[\""+string(resources[2].URN)+"\"]\n")
+	assert.Contains(t, buf.String(), "    style Resource1 stroke:"+stepOpColors[deploy.OpUpdate])
+	assert.Contains(t, buf.String(), "    Resource1 -->|dependency| Resource2\n")
+	assert.Contains(t, buf.String(), "    linkStyle 2 stroke:"+parentEdgeColor+"\n")
+
+	buf.Reset()
+	assert.NoError(t, graphmlconv.Print(dg, &buf))
+	assert.Contains(t, buf.String(), `<edge source="Resource2" target="Resource0">`)
+	assert.Contains(t, buf.String(), `<data key="label">parent</data>`)
+
+	// The full graph includes provider edges.
+	g = jsonconv.Convert(makeDependencyGraph(resources, nil))
+	assert.Contains(t, g.Edges, jsonconv.Edge{
+		From: "Resource3", To: "Resource0", Label: "provider", Color: providerEdgeColor,
+	})
+}
@@ -15,16 +15,29 @@
 package cmd
 
 import (
+	"regexp"
+	"strings"
+	"time"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/backend/httpstate"
+	"github.com/pulumi/pulumi/pkg/backend/state"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/util/ciutil"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/workspace"
 )
 
 func newStackInitCmd() *cobra.Command {
 	var ppc string
+	var copyConfigFrom string
+	var ttl time.Duration
 	cmd := &cobra.Command{
 		Use:   "init [<organization-name>/]<stack-name>",
 		Args:  cmdutil.MaximumNArgs(1),
@@ -35,7 +48,13 @@ func newStackInitCmd() *cobra.Command {
 			"but afterwards it can become the target of a deployment using the `update` command.\n" +
 			"\n" +
 			"To create a stack in an organization, prefix the stack name with the organization name\n" +
-			"and a slash (e.g. 'my-organization/my-great-stack')",
+			"and a slash (e.g. 'my-organization/my-great-stack')\n" +
+			"\n" +
+			"For short-lived stacks, such as one per pull request, `--copy-config-from` copies the\n" +
+			"configuration of an existing stack, re-encrypting its secrets, and `--ttl` records when\n" +
+			"the stack expires so that `pulumi stack gc` can destroy and remove it later. When run\n" +
+			"in CI for a pull request without a stack name, the stack is named after the pull\n" +
+			"request's number and branch (e.g. 'pr-123-fix-login').",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
@@ -53,6 +72,10 @@ func newStackInitCmd() *cobra.Command {
 				}
 			}
 
+			if ttl < 0 {
+				return errors.New("--ttl must not be negative")
+			}
+
 			var stackName string
 			if len(args) > 0 {
 				stackName = args[0]
@@ -62,6 +85,8 @@ func newStackInitCmd() *cobra.Command {
 					return nameErr
 				}
 				stackName = name
+			} else {
+				stackName = reviewStackName(ciutil.DetectVars())
 			}
 
 			if stackName == "" {
@@ -73,11 +98,123 @@ func newStackInitCmd() *cobra.Command {
 				return err
 			}
 
-			_, err = createStack(b, stackRef, createOpts, true /*setCurrent*/)
-			return err
+			// Make sure the stack to copy from exists before creating anything.
+			var source backend.Stack
+			if copyConfigFrom != "" {
+				sourceRef, sourceErr := b.ParseStackReference(copyConfigFrom)
+				if sourceErr != nil {
+					return sourceErr
+				}
+				if source, err = b.GetStack(commandContext(), sourceRef); err != nil {
+					return err
+				} else if source == nil {
+					return errors.Errorf("stack '%s' not found", copyConfigFrom)
+				}
+			}
+
+			s, err := createStack(b, stackRef, createOpts, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+			if ttl > 0 {
+				if err = setStackTTL(s, ttl, time.Now()); err != nil {
+					// A stack without its expiry would never be collected, so don't leave it behind.
+					if _, rmErr := s.Remove(commandContext(), false /*force*/); rmErr != nil {
+						return errors.Wrapf(err, "recording the stack's time to live (and removing the stack "+
+							"'%s' failed: %v)", s.Ref(), rmErr)
+					}
+					contract.IgnoreError(state.SetCurrentStack(""))
+					return errors.Wrap(err, "recording the stack's time to live; the stack was not created")
+				}
+			}
+
+			if source != nil {
+				if err = copyStackConfig(source, s); err != nil {
+					return errors.Wrapf(err, "copying configuration from stack '%s'", source.Ref())
+				}
+			}
+			return nil
 		}),
 	}
 	cmd.PersistentFlags().StringVarP(
 		&ppc, "ppc", "p", "", "An optional Pulumi Private Cloud (PPC) name to initialize this stack in")
+	cmd.PersistentFlags().StringVar(
+		&copyConfigFrom, "copy-config-from", "",
+		"The name of a stack of this project whose configuration, including secrets, is copied to the new stack")
+	cmd.PersistentFlags().DurationVar(
+		&ttl, "ttl", 0,
+		"How long the stack should live (e.g. 24h); after this, `pulumi stack gc` destroys and removes it")
 	return cmd
 }
+
+// reviewStackNameRegex matches the runs of characters that aren't allowed in stack names.
+var reviewStackNameRegex = regexp.MustCompile(`[^a-z0-9_.]+`)
+
+// reviewStackName returns the name of the stack to use for reviewing the pull request the CI system is building,
+// or an empty string if it isn't building a pull request.
+func reviewStackName(vars ciutil.Vars) string {
+	if vars.PRNumber == "" {
+		return ""
+	}
+
+	name := "pr-" + vars.PRNumber
+	if branch := reviewStackNameRegex.ReplaceAllString(strings.ToLower(vars.BranchName), "-"); branch != "" {
+		name += "-" + strings.Trim(branch, "-")
+	}
+
+	const maxStackName = 100
+	if len(name) > maxStackName {
+		name = name[:maxStackName]
+	}
+	return strings.TrimRight(name, "-")
+}
+
+// setStackTTL records in the stack's tags that it expires ttl after now.
+func setStackTTL(s backend.Stack, ttl time.Duration, now time.Time) error {
+	tags := copyStackTags(s.Tags())
+	tags[apitype.StackTTLTag] = ttl.String()
+	tags[apitype.StackExpiresTag] = now.Add(ttl).UTC().Format(time.RFC3339)
+	return backend.UpdateStackTags(commandContext(), s, tags)
+}
+
+// copyStackConfig copies the configuration of one stack to another, re-encrypting any secrets for the destination
+// stack. Existing configuration of the destination stack with the same keys is replaced.
+func copyStackConfig(from backend.Stack, to backend.Stack) error {
+	src, err := workspace.DetectProjectStack(from.Ref().Name())
+	if err != nil {
+		return err
+	}
+
+	var decrypter, encrypter config.Crypter
+	if src.Config.HasSecureValue() {
+		if decrypter, err = backend.GetStackCrypter(from); err != nil {
+			return err
+		}
+		// Getting the crypter may save new encryption state for the destination stack, so do so before loading it.
+		if encrypter, err = backend.GetStackCrypter(to); err != nil {
+			return err
+		}
+	}
+
+	dest, err := workspace.DetectProjectStack(to.Ref().Name())
+	if err != nil {
+		return err
+	}
+
+	for key, value := range src.Config {
+		if value.Secure() {
+			plaintext, err := value.Value(decrypter)
+			if err != nil {
+				return errors.Wrapf(err, "decrypting %s", key)
+			}
+			ciphertext, err := encrypter.EncryptValue(plaintext)
+			if err != nil {
+				return errors.Wrapf(err, "encrypting %s", key)
+			}
+			value = config.NewSecureValue(ciphertext)
+		}
+		dest.Config[key] = value
+	}
+
+	return workspace.SaveProjectStack(to.Ref().Name(), dest)
+}
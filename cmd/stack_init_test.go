@@ -0,0 +1,39 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/util/ciutil"
+)
+
+func TestReviewStackName(t *testing.T) {
+	assert.Equal(t, "", reviewStackName(ciutil.Vars{BranchName: "master"}))
+	assert.Equal(t, "pr-12", reviewStackName(ciutil.Vars{PRNumber: "12"}))
+	assert.Equal(t, "pr-12-feature-fix-login", reviewStackName(ciutil.Vars{
+		PRNumber:   "12",
+		BranchName: "Feature/Fix Login!",
+	}))
+	assert.Equal(t, "pr-7-v1.2_hotfix", reviewStackName(ciutil.Vars{PRNumber: "7", BranchName: "v1.2_hotfix"}))
+
+	long := reviewStackName(ciutil.Vars{PRNumber: "99", BranchName: strings.Repeat("a-", 100)})
+	assert.True(t, len(long) <= 100)
+	assert.NoError(t, backend.ValidateStackProperties(long, nil))
+}
@@ -0,0 +1,130 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newStackLockStatusCmd() *cobra.Command {
+	var stack string
+	var forceUnlock bool
+	var jsonOut bool
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "lock-status",
+		Args:  cmdutil.NoArgs,
+		Short: "Show which update, if any, holds a stack's lock",
+		Long: "Show which update, if any, holds a stack's lock.\n" +
+			"\n" +
+			"While an update is running it holds a lock on its stack, which it keeps alive by\n" +
+			"periodically renewing a lease.  This command shows the ID and kind of the update\n" +
+			"holding the lock, who requested it, when it started and when its lease was last\n" +
+			"renewed.\n" +
+			"\n" +
+			"Passing --force-unlock breaks the lock, so that another update may proceed.  Note\n" +
+			"that this operation is _very dangerous_: if the update holding the lock is still\n" +
+			"running, the stack may be left in an inconsistent state.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(stack, false, opts, true /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+
+			status, err := backend.GetStackLockStatus(commandContext(), s)
+			if err != nil {
+				return err
+			}
+
+			if jsonOut {
+				if err = printJSON(status); err != nil {
+					return err
+				}
+			} else {
+				printStackLockStatus(string(s.Ref().Name()), status)
+			}
+
+			if !forceUnlock || !status.Locked {
+				return nil
+			}
+
+			// Ensure the user really wants to do this.
+			stackName := string(s.Ref().Name())
+			prompt := fmt.Sprintf("This will forcibly unlock '%s', even if update %s is still running!",
+				stackName, status.UpdateID)
+			if !yes && !confirmPrompt(prompt, stackName, opts) {
+				return errors.New("confirmation declined")
+			}
+
+			if err = backend.ForceUnlockStack(commandContext(), s); err != nil {
+				return err
+			}
+
+			msg := fmt.Sprintf("%sThe lock on '%s' has been broken!%s", colors.SpecAttention, stackName, colors.Reset)
+			fmt.Println(opts.Color.Colorize(msg))
+
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().BoolVar(
+		&forceUnlock, "force-unlock", false, "Break the stack's lock, regardless of which update holds it")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false, "Emit the lock status as JSON")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false, "Skip confirmation prompts, and proceed with unlocking anyway")
+
+	return cmd
+}
+
+func printStackLockStatus(stackName string, status apitype.StackLockStatus) {
+	if !status.Locked {
+		fmt.Printf("Stack '%s' is not locked\n", stackName)
+		return
+	}
+
+	formatTime := func(unix int64) string {
+		if unix == 0 {
+			return "n/a"
+		}
+		t := time.Unix(unix, 0)
+		return fmt.Sprintf("%s (%s)", t.Format(time.RFC1123), humanize.Time(t))
+	}
+
+	fmt.Printf("Stack '%s' is locked by update %s\n", stackName, status.UpdateID)
+	fmt.Printf("    %-16s %s\n", "Kind:", status.Kind)
+	fmt.Printf("    %-16s %s\n", "Requested by:", status.RequestedBy)
+	fmt.Printf("    %-16s %s\n", "Started:", formatTime(status.StartTime))
+	fmt.Printf("    %-16s %s\n", "Lease renewed:", formatTime(status.LastLeaseRenewal))
+	fmt.Printf("    %-16s %s\n", "Lease expires:", formatTime(status.LeaseExpiration))
+}
@@ -18,11 +18,15 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/backend/httpstate"
 	"github.com/pulumi/pulumi/pkg/backend/state"
@@ -33,6 +37,8 @@ import (
 
 func newStackLsCmd() *cobra.Command {
 	var allStacks bool
+	var tagFilter []string
+	var jsonOut bool
 	cmd := &cobra.Command{
 		Use:   "ls",
 		Short: "List all known stacks",
@@ -73,11 +79,25 @@ func newStackLsCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			// Drop any stacks that do not match the requested tags.
+			if len(tagFilter) > 0 {
+				filtered := stackSummaries[:0]
+				for _, summary := range stackSummaries {
+					if stackTagsMatch(summary.Tags(), tagFilter) {
+						filtered = append(filtered, summary)
+					}
+				}
+				stackSummaries = filtered
+			}
 			// Sort by stack name.
 			sort.Slice(stackSummaries, func(i, j int) bool {
 				return stackSummaries[i].Name().String() < stackSummaries[j].Name().String()
 			})
 
+			if jsonOut {
+				return printJSON(makeStackSummariesJSON(b, current, stackSummaries))
+			}
+
 			_, showURLColumn := b.(httpstate.Backend)
 
 			// Devote 48 characters to the name width, unless there is a longer name.
@@ -124,13 +144,9 @@ func newStackLsCmd() *cobra.Command {
 				// Render the columns.
 				values := []interface{}{name, lastUpdate, resourceCount}
 				if showURLColumn {
-					var url string
-					if httpBackend, ok := b.(httpstate.Backend); ok {
-						if nameSuffix, err := httpBackend.StackConsoleURL(summary.Name()); err != nil {
-							url = none
-						} else {
-							url = fmt.Sprintf("%s/%s", httpBackend.CloudURL(), nameSuffix)
-						}
+					url := stackConsoleURL(b, summary)
+					if url == "" {
+						url = none
 					}
 					values = append(values, url)
 				}
@@ -143,6 +159,84 @@ func newStackLsCmd() *cobra.Command {
 	}
 	cmd.PersistentFlags().BoolVarP(
 		&allStacks, "all", "a", false, "List all stacks instead of just stacks for the current project")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false, "Emit the list of stacks and their details as JSON")
+	cmd.PersistentFlags().StringArrayVar(
+		&tagFilter, "tag", nil,
+		"Only list stacks with the given tag, as `name=value` or just `name` to match any value; may be repeated")
 
 	return cmd
 }
+
+// stackConsoleURL returns the URL at which the given stack may be viewed in the Pulumi Console, or the empty string if
+// there is no such URL.
+func stackConsoleURL(b backend.Backend, summary backend.StackSummary) string {
+	httpBackend, ok := b.(httpstate.Backend)
+	if !ok {
+		return ""
+	}
+	nameSuffix, err := httpBackend.StackConsoleURL(summary.Name())
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", httpBackend.CloudURL(), nameSuffix)
+}
+
+// stackSummaryJSON is the shape of each stack emitted by `pulumi stack ls --json`.
+type stackSummaryJSON struct {
+	Name             string                          `json:"name"`
+	Current          bool                            `json:"current"`
+	URL              string                          `json:"url,omitempty"`
+	LastUpdate       string                          `json:"lastUpdate,omitempty"`
+	LastUpdateKind   apitype.UpdateKind              `json:"lastUpdateKind,omitempty"`
+	LastUpdateResult apitype.UpdateResult            `json:"lastUpdateResult,omitempty"`
+	UpdateInProgress bool                            `json:"updateInProgress"`
+	ResourceCount    *int                            `json:"resourceCount,omitempty"`
+	Tags             map[apitype.StackTagName]string `json:"tags,omitempty"`
+}
+
+// makeStackSummariesJSON converts the given stack summaries into the form emitted by `pulumi stack ls --json`.
+func makeStackSummariesJSON(b backend.Backend, current string, summaries []backend.StackSummary) []stackSummaryJSON {
+	stacks := make([]stackSummaryJSON, 0, len(summaries))
+	for _, summary := range summaries {
+		entry := stackSummaryJSON{
+			Name:             summary.Name().String(),
+			Current:          summary.Name().String() == current,
+			URL:              stackConsoleURL(b, summary),
+			UpdateInProgress: summary.UpdateInProgress(),
+			ResourceCount:    summary.ResourceCount(),
+			Tags:             summary.Tags(),
+		}
+		if entry.URL == "" {
+			entry.URL = b.URL()
+		}
+		if lastUpdate := summary.LastUpdate(); lastUpdate != nil {
+			entry.LastUpdate = lastUpdate.UTC().Format(time.RFC3339)
+		}
+		if kind := summary.LastUpdateKind(); kind != nil {
+			entry.LastUpdateKind = *kind
+		}
+		if result := summary.LastUpdateResult(); result != nil {
+			entry.LastUpdateResult = *result
+		}
+		stacks = append(stacks, entry)
+	}
+	return stacks
+}
+
+// stackTagsMatch returns true if the given tags satisfy every filter. Each filter is either `name=value`, which
+// requires an exact match, or `name`, which only requires that the tag be present.
+func stackTagsMatch(tags map[apitype.StackTagName]string, filters []string) bool {
+	for _, filter := range filters {
+		name, value := filter, ""
+		hasValue := false
+		if idx := strings.Index(filter, "="); idx >= 0 {
+			name, value, hasValue = filter[:idx], filter[idx+1:], true
+		}
+		actual, has := tags[name]
+		if !has || (hasValue && actual != value) {
+			return false
+		}
+	}
+	return true
+}
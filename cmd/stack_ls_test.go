@@ -0,0 +1,113 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/filestate"
+)
+
+func TestStackTagsMatch(t *testing.T) {
+	tags := map[apitype.StackTagName]string{
+		"owner":                "alice",
+		"empty":                "",
+		apitype.ProjectNameTag: "app",
+	}
+
+	assert.True(t, stackTagsMatch(tags, nil))
+	assert.True(t, stackTagsMatch(tags, []string{"owner=alice"}))
+	assert.True(t, stackTagsMatch(tags, []string{"owner"}))
+	assert.True(t, stackTagsMatch(tags, []string{"owner=alice", "pulumi:project=app"}))
+	assert.True(t, stackTagsMatch(tags, []string{"empty="}))
+	assert.True(t, stackTagsMatch(tags, []string{"empty"}))
+
+	assert.False(t, stackTagsMatch(tags, []string{"owner=bob"}))
+	assert.False(t, stackTagsMatch(tags, []string{"owner="}))
+	assert.False(t, stackTagsMatch(tags, []string{"team"}))
+	assert.False(t, stackTagsMatch(tags, []string{"owner=alice", "team"}))
+	assert.False(t, stackTagsMatch(nil, []string{"owner"}))
+
+	// Only the first '=' separates the name from the value.
+	assert.True(t, stackTagsMatch(map[apitype.StackTagName]string{"expr": "a=b"}, []string{"expr=a=b"}))
+}
+
+type testUpdatedStackSummary struct {
+	testStackSummary
+	lastUpdate    time.Time
+	kind          apitype.UpdateKind
+	result        apitype.UpdateResult
+	inProgress    bool
+	resourceCount int
+}
+
+func (s testUpdatedStackSummary) LastUpdate() *time.Time                  { return &s.lastUpdate }
+func (s testUpdatedStackSummary) LastUpdateKind() *apitype.UpdateKind     { return &s.kind }
+func (s testUpdatedStackSummary) LastUpdateResult() *apitype.UpdateResult { return &s.result }
+func (s testUpdatedStackSummary) UpdateInProgress() bool                  { return s.inProgress }
+func (s testUpdatedStackSummary) ResourceCount() *int                     { return &s.resourceCount }
+
+func TestStackSummariesJSON(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stack-ls-test")
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, os.RemoveAll(dir)) }()
+	b, err := filestate.New(nil, "file://"+dir)
+	assert.NoError(t, err)
+
+	summaries := []backend.StackSummary{
+		testUpdatedStackSummary{
+			testStackSummary: testStackSummary{
+				name: "dev",
+				tags: map[apitype.StackTagName]string{apitype.ProjectNameTag: "app"},
+			},
+			lastUpdate:    time.Date(2019, 6, 1, 12, 0, 0, 0, time.FixedZone("PDT", -7*60*60)),
+			kind:          apitype.UpdateUpdate,
+			result:        apitype.SucceededResult,
+			inProgress:    true,
+			resourceCount: 3,
+		},
+		testStackSummary{name: "new"},
+	}
+
+	out, err := json.Marshal(makeStackSummariesJSON(b, "dev", summaries))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[
+		{
+			"name": "dev",
+			"current": true,
+			"url": "file://`+dir+`",
+			"lastUpdate": "2019-06-01T19:00:00Z",
+			"lastUpdateKind": "update",
+			"lastUpdateResult": "succeeded",
+			"updateInProgress": true,
+			"resourceCount": 3,
+			"tags": {"pulumi:project": "app"}
+		},
+		{
+			"name": "new",
+			"current": false,
+			"url": "file://`+dir+`",
+			"updateInProgress": false
+		}
+	]`, string(out))
+}
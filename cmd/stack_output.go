@@ -16,18 +16,42 @@ package cmd
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/backend/filestate"
+	"github.com/pulumi/pulumi/pkg/encoding"
+	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/resource/stack"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 )
 
+// The formats understood by `pulumi stack output --format`.
+const (
+	outputFormatDefault = ""
+	outputFormatJSON    = "json"
+	outputFormatYAML    = "yaml"
+	outputFormatDotenv  = "dotenv"
+	outputFormatShell   = "shell"
+)
+
+// secretOutputValue is displayed in place of secret outputs when --show-secrets is not passed.
+const secretOutputValue = "[secret]"
+
 func newStackOutputCmd() *cobra.Command {
 	var jsonOut bool
+	var format string
+	var separator string
+	var showSecrets bool
 	var stackName string
+	var watchStack string
 
 	cmd := &cobra.Command{
 		Use:   "output [property-name]",
@@ -36,14 +60,46 @@ func newStackOutputCmd() *cobra.Command {
 		Long: "Show a stack's output properties.\n" +
 			"\n" +
 			"By default, this command lists all output properties exported from a stack.\n" +
-			"If a specific property-name is supplied, just that property's value is shown.",
+			"If a specific property-name is supplied, just that property's value is shown.\n" +
+			"\n" +
+			"Use --format to emit the outputs as `json`, `yaml`, `dotenv` or `shell` (a series of\n" +
+			"`export NAME=value` statements suitable for `eval`).  The dotenv and shell formats\n" +
+			"flatten nested objects and arrays, joining each level of names with --separator.\n" +
+			"\n" +
+			"Outputs are not tracked as secret, so if the stack's most recent deployment used any\n" +
+			"secure configuration, every output is blinded unless --show-secrets is passed.  Passing\n" +
+			"--show-secrets requires that the stack's secure configuration can be decrypted.\n" +
+			"\n" +
+			"Use --watch-stack to read the outputs of any stack in the current backend, without\n" +
+			"needing to be in its project directory and without selecting it.",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
 			}
 
-			// Fetch the current stack and its output properties.
-			s, err := requireStack(stackName, false, opts, true /*setCurrent*/)
+			if jsonOut {
+				if format != outputFormatDefault && format != outputFormatJSON {
+					return errors.New("--json and --format cannot both be specified")
+				}
+				format = outputFormatJSON
+			}
+			switch format {
+			case outputFormatDefault, outputFormatJSON, outputFormatYAML, outputFormatDotenv, outputFormatShell:
+			default:
+				return errors.Errorf("unknown output format '%s'; expected one of json, yaml, dotenv or shell", format)
+			}
+
+			// Fetch the stack and its output properties.
+			var s backend.Stack
+			var err error
+			if watchStack != "" {
+				if stackName != "" {
+					return errors.New("--stack and --watch-stack cannot both be specified")
+				}
+				s, err = requireStackReference(watchStack, opts)
+			} else {
+				s, err = requireStack(stackName, false, opts, true /*setCurrent*/)
+			}
 			if err != nil {
 				return err
 			}
@@ -54,39 +110,264 @@ func newStackOutputCmd() *cobra.Command {
 
 			res, outputs := stack.GetRootStackResource(snap)
 			if res == nil || outputs == nil {
-				return errors.New("current stack has no output properties")
+				return errors.Errorf("stack '%s' has no output properties", s.Ref())
+			}
+
+			// Any output may have been derived from a secret, so if the stack has secrets, either they must all be
+			// blinded or the caller must prove that they can decrypt the secrets.
+			cfg, err := getSecureConfig(s)
+			if err != nil {
+				return errors.Wrap(err, "determining whether the stack has secrets")
+			}
+			if cfg != nil {
+				if !showSecrets {
+					outputs = blindOutputs(outputs)
+				} else if watchStack != "" && filestate.IsLocalBackendURL(s.Backend().URL()) {
+					// The local backend finds a stack's passphrase salt in its project directory, which the
+					// current directory need not be when watching another stack.
+					return errors.New("--show-secrets cannot be used with --watch-stack for a stack in a local " +
+						"backend that has secrets; run `pulumi stack output` from the stack's project directory")
+				} else if err = checkStackSecretsAccess(s, cfg); err != nil {
+					return errors.Wrap(err, "--show-secrets requires access to the stack's secrets")
+				}
 			}
 
 			// If there is an argument, just print that property.  Else, print them all (similar to `pulumi stack`).
 			if len(args) > 0 {
 				name := args[0]
 				v, has := outputs[name]
-				if has {
-					if jsonOut {
-						if err := printJSON(v); err != nil {
-							return err
-						}
-					} else {
-						fmt.Printf("%v\n", stringifyOutput(v))
-					}
-				} else {
-					return errors.Errorf("current stack does not have output property '%v'", name)
+				if !has {
+					return errors.Errorf("stack '%s' does not have output property '%v'", s.Ref(), name)
 				}
-			} else if jsonOut {
-				if err := printJSON(outputs); err != nil {
-					return err
+
+				switch format {
+				case outputFormatDefault:
+					fmt.Printf("%v\n", stringifyOutput(v))
+					return nil
+				case outputFormatJSON, outputFormatYAML:
+					return printOutputs(v, format, separator)
+				default:
+					return printOutputs(map[string]interface{}{name: v}, format, separator)
 				}
-			} else {
+			}
+
+			if format == outputFormatDefault {
 				printStackOutputs(outputs)
+				return nil
 			}
-			return nil
+			return printOutputs(outputs, format, separator)
 		}),
 	}
 
 	cmd.PersistentFlags().BoolVarP(
-		&jsonOut, "json", "j", false, "Emit outputs as JSON")
+		&jsonOut, "json", "j", false, "Emit outputs as JSON; equivalent to --format json")
+	cmd.PersistentFlags().StringVarP(
+		&format, "format", "f", "", "Emit outputs in the given format: json, yaml, dotenv or shell")
+	cmd.PersistentFlags().StringVar(
+		&separator, "separator", "_", "The separator used to join nested names in the dotenv and shell formats")
+	cmd.PersistentFlags().BoolVar(
+		&showSecrets, "show-secrets", false, "Show secret outputs instead of blinding them")
 	cmd.PersistentFlags().StringVarP(
 		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().StringVar(
+		&watchStack, "watch-stack", "",
+		"Read the outputs of the given stack, which need not belong to the current project, without selecting it")
 
 	return cmd
 }
+
+// requireStackReference looks up the stack with the given reference in the current backend.  Unlike requireStack, it
+// never prompts, never selects the stack and does not require a project.
+func requireStackReference(ref string, opts display.Options) (backend.Stack, error) {
+	b, err := currentBackend(opts)
+	if err != nil {
+		return nil, err
+	}
+	stackRef, err := b.ParseStackReference(ref)
+	if err != nil {
+		return nil, err
+	}
+	s, err := b.GetStack(commandContext(), stackRef)
+	if err != nil {
+		return nil, err
+	} else if s == nil {
+		return nil, errors.Errorf("no stack named '%s' found", ref)
+	}
+	return s, nil
+}
+
+// getSecureConfig returns the configuration that was used for the stack's most recent deployment, but only if it
+// contains secure values.  Nothing is decrypted, so this never needs the stack's crypter.
+func getSecureConfig(s backend.Stack) (config.Map, error) {
+	cfg, err := backend.GetLatestConfiguration(commandContext(), s)
+	if err == backend.ErrNoPreviousDeployment {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if !cfg.HasSecureValue() {
+		return nil, nil
+	}
+	return cfg, nil
+}
+
+// checkStackSecretsAccess ensures that the caller is able to decrypt the secure values in the given configuration of
+// the stack.
+func checkStackSecretsAccess(s backend.Stack, cfg config.Map) error {
+	crypter, err := backend.GetStackCrypter(s)
+	if err != nil {
+		return err
+	}
+	return decryptSecureConfig(cfg, crypter)
+}
+
+// decryptSecureConfig returns an error if any of the secure values in the given configuration cannot be decrypted.
+func decryptSecureConfig(cfg config.Map, decrypter config.Decrypter) error {
+	for k, v := range cfg {
+		if !v.Secure() {
+			continue
+		}
+		if _, err := v.Value(decrypter); err != nil {
+			return errors.Wrapf(err, "decrypting configuration value %s", k)
+		}
+	}
+	return nil
+}
+
+// blindOutputs returns a copy of the given outputs in which every value has been replaced.
+func blindOutputs(outputs map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(outputs))
+	for k := range outputs {
+		result[k] = secretOutputValue
+	}
+	return result
+}
+
+// printOutputs prints the given output value in one of the structured output formats.
+func printOutputs(v interface{}, format string, separator string) error {
+	switch format {
+	case outputFormatJSON:
+		return printJSON(v)
+	case outputFormatYAML:
+		b, err := encoding.YAML.Marshal(v)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(b))
+		return nil
+	}
+
+	vars, err := flattenOutputs(v, separator)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if format == outputFormatShell {
+			fmt.Printf("export %s=%s\n", name, shellQuote(vars[name]))
+		} else {
+			fmt.Printf("%s=%s\n", name, dotenvQuote(vars[name]))
+		}
+	}
+	return nil
+}
+
+// flattenOutputs flattens the given output value into a set of environment variables.  The names of nested object
+// properties and array indices are appended to those of their parents using the given separator, and any characters
+// not permitted in variable names are replaced with underscores.  It is an error for two values to flatten to the
+// same variable name, e.g. a top-level "a_b" and the property "b" of a top-level object "a".
+func flattenOutputs(v interface{}, separator string) (map[string]string, error) {
+	vars := make(map[string]string)
+	sources := make(map[string]string)
+
+	var flatten func(path, prefix string, v interface{}) error
+	flatten = func(path, prefix string, v interface{}) error {
+		join := func(name string) string {
+			if prefix == "" {
+				return name
+			}
+			return prefix + separator + name
+		}
+
+		switch v := v.(type) {
+		case map[string]interface{}:
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				if err := flatten(outputPropertyPath(path, k), join(k), v[k]); err != nil {
+					return err
+				}
+			}
+		case []interface{}:
+			for i, e := range v {
+				index := strconv.Itoa(i)
+				if err := flatten(path+"["+index+"]", join(index), e); err != nil {
+					return err
+				}
+			}
+		default:
+			name := envVarName(prefix)
+			if source, has := sources[name]; has {
+				return errors.Errorf("outputs '%s' and '%s' both flatten to the variable name %s", source, path, name)
+			}
+			sources[name] = path
+			if v == nil {
+				vars[name] = ""
+			} else {
+				vars[name] = stringifyOutput(v)
+			}
+		}
+		return nil
+	}
+	if err := flatten("", "", v); err != nil {
+		return nil, err
+	}
+
+	return vars, nil
+}
+
+// outputPropertyPath appends the given property name to the path of an output value, for use in error messages.
+func outputPropertyPath(path, name string) string {
+	if !outputPropertyNameRegex.MatchString(name) {
+		return path + "[" + strconv.Quote(name) + "]"
+	} else if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+var outputPropertyNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// envVarName replaces any characters that may not appear in an environment variable name with underscores.
+func envVarName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+// shellQuote quotes a value for use in a POSIX shell.  Single quotes prevent all expansion, so the only character
+// that needs special treatment is the single quote itself.
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// dotenvQuote quotes a value for use in a dotenv file.
+func dotenvQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`)
+	return `"` + r.Replace(s) + `"`
+}
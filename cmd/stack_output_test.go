@@ -18,6 +18,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource/config"
 )
 
 func TestStringifyOutput(t *testing.T) {
@@ -36,3 +38,91 @@ func TestStringifyOutput(t *testing.T) {
 	assert.Equal(t, "[\"hello\",\"goodbye\"]", stringifyOutput(arr))
 	assert.Equal(t, "{\"bar\":{\"baz\":true},\"foo\":42}", stringifyOutput(obj))
 }
+
+func TestFlattenOutputs(t *testing.T) {
+	outputs := map[string]interface{}{
+		"bucketName": "my-bucket",
+		"port":       float64(8080),
+		"endpoint": map[string]interface{}{
+			"host": "example.com",
+			"tls":  true,
+		},
+		"zones":   []interface{}{"us-west-2a", "us-west-2b"},
+		"missing": nil,
+		"odd-key": "value",
+	}
+
+	vars, err := flattenOutputs(outputs, "_")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"bucketName":    "my-bucket",
+		"port":          "8080",
+		"endpoint_host": "example.com",
+		"endpoint_tls":  "true",
+		"zones_0":       "us-west-2a",
+		"zones_1":       "us-west-2b",
+		"missing":       "",
+		"odd_key":       "value",
+	}, vars)
+
+	vars, err = flattenOutputs(map[string]interface{}{
+		"endpoint": map[string]interface{}{"host": "example.com"},
+	}, "__")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"endpoint__host": "example.com",
+	}, vars)
+}
+
+func TestOutputQuoting(t *testing.T) {
+	assert.Equal(t, `'plain'`, shellQuote("plain"))
+	assert.Equal(t, `'it'\''s $HOME'`, shellQuote("it's $HOME"))
+	assert.Equal(t, `"plain"`, dotenvQuote("plain"))
+	assert.Equal(t, `"say \"hi\"\nC:\\"`, dotenvQuote("say \"hi\"\nC:\\"))
+}
+
+func TestFlattenOutputsCollision(t *testing.T) {
+	_, err := flattenOutputs(map[string]interface{}{
+		"a.b": "top",
+		"a":   map[string]interface{}{"b": "nested"},
+	}, "_")
+	assert.EqualError(t, err, "outputs 'a.b' and '[\"a.b\"]' both flatten to the variable name a_b")
+
+	_, err = flattenOutputs(map[string]interface{}{
+		"a_b": "top",
+		"a":   map[string]interface{}{"b": "nested"},
+		"c":   []interface{}{"x"},
+		"c_0": "y",
+	}, "_")
+	assert.EqualError(t, err, "outputs 'a.b' and 'a_b' both flatten to the variable name a_b")
+}
+
+func TestDecryptSecureConfig(t *testing.T) {
+	crypter := config.NewSymmetricCrypter(make([]byte, 32))
+	ciphertext, err := crypter.EncryptValue("hunter2")
+	assert.NoError(t, err)
+
+	cfg := config.Map{
+		config.MustMakeKey("app", "dbPassword"): config.NewSecureValue(ciphertext),
+		config.MustMakeKey("app", "region"):     config.NewValue("us-west-2"),
+	}
+	assert.NoError(t, decryptSecureConfig(cfg, crypter))
+
+	wrong := config.NewSymmetricCrypterFromPassphrase("wrong", []byte("saltsalt"))
+	assert.Error(t, decryptSecureConfig(cfg, wrong))
+}
+
+func TestBlindOutputs(t *testing.T) {
+	outputs := map[string]interface{}{
+		"dbEndpoint": "db.example.com",
+		"connection": map[string]interface{}{"user": "admin"},
+		"port":       float64(5432),
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"dbEndpoint": secretOutputValue,
+		"connection": secretOutputValue,
+		"port":       secretOutputValue,
+	}, blindOutputs(outputs))
+	assert.Equal(t, "db.example.com", outputs["dbEndpoint"])
+}
@@ -0,0 +1,104 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/display"
+	"github.com/pulumi/pulumi/pkg/backend/state"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func newStackRenameCmd() *cobra.Command {
+	var stackName string
+
+	cmd := &cobra.Command{
+		Use:   "rename <new-stack-name>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Rename an existing stack",
+		Long: "Rename an existing stack.\n" +
+			"\n" +
+			"Because a stack's name is part of the URN of every resource it manages, renaming\n" +
+			"a stack rewrites the URN of each of its resources, along with any dependency, parent\n" +
+			"and provider references between them. The stack's update history is preserved.\n" +
+			"\n" +
+			"The stack's configuration file is renamed to match, and the renamed stack becomes\n" +
+			"the current stack.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(stackName, false, opts, false /*setCurrent*/)
+			if err != nil {
+				return err
+			}
+
+			// The stack's settings file, if there is one, is moved so that its configuration follows it.  Make sure
+			// that won't clobber another stack's settings before renaming anything.
+			oldName, newName := s.Ref().Name(), tokens.QName(args[0])
+			oldPath, err := workspace.DetectProjectStackPath(oldName)
+			if err != nil {
+				return err
+			}
+			newPath, err := workspace.DetectProjectStackPath(newName)
+			if err != nil {
+				return err
+			}
+			if newName != oldName {
+				if _, err = os.Stat(newPath); err == nil {
+					return errors.Errorf("could not rename stack: configuration file %s already exists", newPath)
+				}
+			}
+
+			newRef, err := s.Rename(commandContext(), newName)
+			if err != nil {
+				if _, ok := err.(*backend.StackAlreadyExistsError); ok {
+					return err
+				}
+				return errors.Wrapf(err, "could not rename stack")
+			}
+
+			if err = os.Rename(oldPath, newPath); err != nil && !os.IsNotExist(err) {
+				// Put the stack back under its old name, so that it stays with its configuration.
+				if _, undoErr := s.Backend().RenameStack(commandContext(), newRef, oldName); undoErr != nil {
+					return errors.Wrapf(err, "renaming configuration file %s (and renaming the stack back to '%s' "+
+						"failed: %v)", oldPath, oldName, undoErr)
+				}
+				return errors.Wrapf(err, "renaming configuration file %s; the stack was not renamed", oldPath)
+			}
+
+			if err = state.SetCurrentStack(newRef.String()); err != nil {
+				return err
+			}
+
+			fmt.Printf("Renamed %s to %s\n", s.Ref(), newRef)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+
+	return cmd
+}
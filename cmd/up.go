@@ -16,6 +16,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"io/ioutil"
 	"os"
 
@@ -25,9 +26,11 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/apitype"
 	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/display"
 	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/resource/stack"
@@ -50,7 +53,12 @@ func newUpCmd() *cobra.Command {
 	// Flags for engine.UpdateOptions.
 	var analyzers []string
 	var diffDisplay bool
+	var eventLogPath string
+	var jsonDisplay bool
+	var locked bool
 	var parallel int
+	var policies []string
+	var planPath string
 	var refresh bool
 	var showConfig bool
 	var showReplacementSteps bool
@@ -88,11 +96,30 @@ func newUpCmd() *cobra.Command {
 			return errors.Wrap(err, "gathering environment metadata")
 		}
 
+		policy, err := getPolicyConfig(proj, s.Ref().Name())
+		if err != nil {
+			return errors.Wrap(err, "reading policy settings")
+		}
+
 		opts.Engine = engine.UpdateOptions{
-			Analyzers: analyzers,
+			Analyzers: append(analyzers, policies...),
 			Parallel:  parallel,
 			Debug:     debug,
 			Refresh:   refresh,
+			Policy:    policy,
+		}
+
+		if planPath != "" {
+			approved, planErr := readApprovedPlan(planPath, s)
+			if planErr != nil {
+				return planErr
+			}
+			opts.Engine.ApprovedPlan = approved
+		}
+		if locked {
+			if opts.Engine.Lock, err = readLock(root); err != nil {
+				return err
+			}
 		}
 
 		changes, err := s.Update(commandContext(), backend.UpdateOperation{
@@ -109,13 +136,22 @@ func newUpCmd() *cobra.Command {
 			return PrintEngineError(err)
 		case expectNop && changes != nil && changes.HasChanges():
 			return errors.New("error: no changes were expected but changes occurred")
-		default:
-			return nil
 		}
+
+		// Pin the plugins this update used, unless we were told to stick to the existing pins.
+		if !locked {
+			if err = writeLock(commandContext(), s, root); err != nil {
+				return errors.Wrapf(err, "writing %s", workspace.LockFile)
+			}
+		}
+		return nil
 	}
 
 	// up implementation used when the source of the Pulumi program is a URL.
 	upURL := func(url string, opts backend.UpdateOptions) error {
+		if locked {
+			return errors.New("--locked cannot be used when deploying a template from a URL")
+		}
 		if !workspace.IsTemplateURL(url) {
 			return errors.Errorf("%s is not a valid URL", url)
 		}
@@ -185,8 +221,14 @@ func newUpCmd() *cobra.Command {
 			}
 		}
 
+		// Answer the template's own prompts, if it has any.
+		answers, err := promptForTemplateAnswers(template, "", yes, opts.Display)
+		if err != nil {
+			return err
+		}
+
 		// Copy the template files from the repo to the temporary "virtual workspace" directory.
-		if err = template.CopyTemplateFiles(temp, true, name, description); err != nil {
+		if err = template.CopyTemplateFiles(temp, true, name, description, answers); err != nil {
 			return err
 		}
 
@@ -224,11 +266,17 @@ func newUpCmd() *cobra.Command {
 			return errors.Wrap(err, "gathering environment metadata")
 		}
 
+		policy, err := getPolicyConfig(proj, s.Ref().Name())
+		if err != nil {
+			return errors.Wrap(err, "reading policy settings")
+		}
+
 		opts.Engine = engine.UpdateOptions{
-			Analyzers: analyzers,
+			Analyzers: append(analyzers, policies...),
 			Parallel:  parallel,
 			Debug:     debug,
 			Refresh:   refresh,
+			Policy:    policy,
 		}
 
 		// TODO for the URL case:
@@ -270,13 +318,24 @@ func newUpCmd() *cobra.Command {
 			"afterwards so that the stack may be updated incrementally again later on.\n" +
 			"\n" +
 			"The program to run is loaded from the project in the current directory by default. Use the `-C` or\n" +
-			"`--cwd` flag to use a different directory.",
+			"`--cwd` flag to use a different directory.\n" +
+			"\n" +
+			"After a successful update, the exact versions and checksums of the plugins it used are recorded in the\n" +
+			"project's Pulumi.lock file. Check this file in and pass `--locked` to make sure everyone deploys with\n" +
+			"the same plugins.",
 		Args: cmdutil.MaximumNArgs(1),
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			interactive := cmdutil.Interactive()
 			if !interactive {
 				yes = true // auto-approve changes, since we cannot prompt.
 			}
+			if jsonDisplay {
+				// Nothing but events may be written to stdout, so we cannot prompt.
+				if !yes {
+					return errors.New("--yes must be passed in along with --json, since changes cannot be confirmed")
+				}
+				interactive = false
+			}
 
 			opts, err := updateFlagsToOptions(interactive, skipPreview, yes)
 			if err != nil {
@@ -293,8 +352,16 @@ func newUpCmd() *cobra.Command {
 				DiffDisplay:          diffDisplay,
 				Debug:                debug,
 			}
+			closeEventLog, err := configureEventLog(&opts.Display, jsonDisplay, eventLogPath)
+			if err != nil {
+				return err
+			}
+			defer closeEventLog()
 
 			if len(args) > 0 {
+				if planPath != "" {
+					return errors.New("--plan may not be used when updating from a URL")
+				}
 				return upURL(args[0], opts)
 			}
 
@@ -326,9 +393,25 @@ func newUpCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().StringVar(
+		&eventLogPath, "event-log", "",
+		"Log every engine event to the given file as JSON lines")
+	cmd.PersistentFlags().BoolVar(
+		&jsonDisplay, "json", false,
+		"Emit every engine event to stdout as JSON lines, instead of the usual display; requires --yes")
+	cmd.PersistentFlags().BoolVar(
+		&locked, "locked", false,
+		"Only load the exact plugin versions pinned in Pulumi.lock, and leave the lock file unchanged")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", defaultParallel,
 		"Allow P resource operations to run in parallel at once (<=1 for no parallelism)")
+	cmd.PersistentFlags().StringSliceVar(
+		&policies, "policy", []string{},
+		"Enable one or more policy analyzers for this update, in addition to those in the project")
+	cmd.PersistentFlags().StringVar(
+		&planPath, "plan", "",
+		"Only perform the changes in a plan previously saved with `pulumi preview --save-plan`, failing if the "+
+			"update would deviate from it")
 	cmd.PersistentFlags().BoolVarP(
 		&refresh, "refresh", "r", false,
 		"Refresh the state of the stack's resources before this update")
@@ -354,6 +437,36 @@ func newUpCmd() *cobra.Command {
 	return cmd
 }
 
+// readApprovedPlan loads a plan saved by `pulumi preview --save-plan` and ensures that it was made for the given stack.
+func readApprovedPlan(path string, s backend.Stack) (*deploy.ApprovedPlan, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read plan")
+	}
+
+	var plan apitype.UpdatePlan
+	if err = json.Unmarshal(b, &plan); err != nil {
+		return nil, errors.Wrapf(err, "could not parse plan %s", path)
+	}
+	if plan.Version > apitype.UpdatePlanSchemaVersion {
+		return nil, errors.Errorf("plan %s has version %d, but this version of the CLI only supports versions up to %d",
+			path, plan.Version, apitype.UpdatePlanSchemaVersion)
+	}
+	if stackName := s.Ref().String(); plan.Stack != stackName {
+		return nil, errors.Errorf("plan %s was saved for stack %s, not %s", path, plan.Stack, stackName)
+	}
+
+	approved := deploy.NewApprovedPlan()
+	for _, step := range plan.Steps {
+		var changedKeys []resource.PropertyKey
+		for _, k := range step.ChangedKeys {
+			changedKeys = append(changedKeys, resource.PropertyKey(k))
+		}
+		approved.Approve(resource.URN(step.URN), deploy.StepOp(step.Op), changedKeys)
+	}
+	return approved, nil
+}
+
 // handleConfig handles prompting for config values (as needed) and saving config.
 func handleConfig(
 	s backend.Stack,
@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -554,6 +555,31 @@ func printJSON(v interface{}) error {
 	return nil
 }
 
+// configureEventLog sets up the given display options to emit engine events as JSON lines: on stdout, in place of the
+// usual display, if jsonDisplay is true, and to the file at eventLogPath, if one is given.  The returned function closes
+// the event log file, and must be called once the operation has completed.
+func configureEventLog(opts *display.Options, jsonDisplay bool, eventLogPath string) (func(), error) {
+	var writers []io.Writer
+	closer := func() {}
+	if jsonDisplay {
+		writers = append(writers, os.Stdout)
+	}
+	if eventLogPath != "" {
+		f, err := os.Create(eventLogPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "creating event log")
+		}
+		writers = append(writers, f)
+		closer = func() { contract.IgnoreClose(f) }
+	}
+
+	opts.JSONDisplay = jsonDisplay
+	if len(writers) > 0 {
+		opts.EventLog = display.NewEventLog(io.MultiWriter(writers...))
+	}
+	return closer, nil
+}
+
 // updateFlagsToOptions ensures that the given update flags represent a valid combination.  If so, an UpdateOptions
 // is returned with a nil-error; otherwise, the non-nil error contains information about why the combination is invalid.
 func updateFlagsToOptions(interactive, skipPreview, yes bool) (backend.UpdateOptions, error) {
@@ -0,0 +1,347 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+const (
+	workspaceSucceeded = "succeeded"
+	workspaceFailed    = "failed"
+	workspaceSkipped   = "skipped"
+)
+
+func newWorkspaceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "workspace",
+		Short: "Deploy the projects of a multi-project repository together",
+		Long: "Deploy the projects of a multi-project repository together.\n" +
+			"\n" +
+			"A workspace is described by a PulumiWorkspace.yaml file, found in the current directory or one of its\n" +
+			"parents, that lists each project's directory and the projects whose stack outputs it depends on:\n" +
+			"\n" +
+			"    projects:\n" +
+			"      - path: network\n" +
+			"      - path: database\n" +
+			"        dependsOn: [network]\n" +
+			"      - path: app\n" +
+			"        stack: production\n" +
+			"        dependsOn: [network, database]\n" +
+			"\n" +
+			"Dependencies are given by project name. Each project's currently selected stack is used unless the\n" +
+			"manifest names one. Projects run in dependency order, and projects that don't depend on each other\n" +
+			"run concurrently. If a project fails, the projects that depend on it are skipped.",
+		Args: cmdutil.NoArgs,
+	}
+
+	cmd.AddCommand(newWorkspaceOperationCmd("up", "Create or update the stacks of all projects in the workspace"))
+	cmd.AddCommand(newWorkspaceOperationCmd("preview", "Preview changes to the stacks of all projects in the workspace"))
+	cmd.AddCommand(newWorkspaceOperationCmd("destroy", "Destroy the stacks of all projects in the workspace"))
+
+	return cmd
+}
+
+// newWorkspaceOperationCmd returns the command that runs `pulumi <op>` for every project in the workspace.
+func newWorkspaceOperationCmd(op string, short string) *cobra.Command {
+	var concurrency int
+	var diffDisplay bool
+	var skipPreview bool
+	var yes bool
+
+	reverse := op == "destroy"
+	order := "Projects run in dependency order.\n"
+	if reverse {
+		order = "Projects run in reverse dependency order, so each is destroyed before the projects it depends on.\n"
+	}
+
+	cmd := &cobra.Command{
+		Use:   op,
+		Short: short,
+		Long: short + ".\n" +
+			"\n" +
+			"This command runs `pulumi " + op + "` for each project listed in the workspace manifest.\n" +
+			order +
+			"Output from each project is prefixed with its name, and a summary of every project is printed at the\n" +
+			"end.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			var opArgs []string
+			if op != "preview" {
+				if !yes {
+					return errors.Errorf("--yes must be passed in to %s the stacks of a workspace; "+
+						"run `pulumi workspace preview` to see the changes first", op)
+				}
+				opArgs = append(opArgs, "--yes")
+				if skipPreview {
+					opArgs = append(opArgs, "--skip-preview")
+				}
+			}
+			if diffDisplay {
+				opArgs = append(opArgs, "--diff")
+			}
+
+			manifest, err := workspace.DetectWorkspaceManifest()
+			if err != nil {
+				return err
+			}
+
+			run, err := newWorkspaceProjectRunner(op, opArgs, manifest, os.Stdout)
+			if err != nil {
+				return err
+			}
+
+			results, err := runWorkspaceProjects(manifest, reverse, concurrency, run)
+			if err != nil {
+				return err
+			}
+
+			return printWorkspaceSummary(os.Stdout, results)
+		}),
+	}
+
+	cmd.PersistentFlags().IntVar(
+		&concurrency, "concurrency", 0,
+		"The maximum number of projects to run at once; 0 runs every project as soon as its dependencies are done")
+	cmd.PersistentFlags().BoolVar(
+		&diffDisplay, "diff", false,
+		"Display operation as a rich diff showing the overall change")
+	if op != "preview" {
+		cmd.PersistentFlags().BoolVar(
+			&skipPreview, "skip-preview", false,
+			"Do not perform a preview before performing the "+op)
+		cmd.PersistentFlags().BoolVarP(
+			&yes, "yes", "y", false,
+			"Automatically approve and perform the "+op+" of every project")
+	}
+
+	return cmd
+}
+
+// workspaceResult records the outcome of running an operation for a single project in a workspace.
+type workspaceResult struct {
+	Project  *workspace.WorkspaceProject
+	Status   string        // one of workspaceSucceeded, workspaceFailed, or workspaceSkipped.
+	Reason   string        // why the project failed or was skipped.
+	Duration time.Duration // how long the operation took.
+}
+
+// runWorkspaceProjects calls run for every project in the manifest, each as soon as the projects it depends on have
+// succeeded (or, if reverse is set, the projects that depend on it), with at most concurrency projects running at
+// once. Projects whose dependencies fail are skipped. The results are returned in the order the projects started.
+func runWorkspaceProjects(manifest *workspace.WorkspaceManifest, reverse bool, concurrency int,
+	run func(p *workspace.WorkspaceProject) error) ([]*workspaceResult, error) {
+
+	order, err := manifest.Sort()
+	if err != nil {
+		return nil, err
+	}
+	if reverse {
+		for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+			order[i], order[j] = order[j], order[i]
+		}
+	}
+
+	results := make(map[string]*workspaceResult, len(order))
+	done := make(map[string]chan struct{}, len(order))
+	for _, p := range order {
+		results[p.Name] = &workspaceResult{Project: p}
+		done[p.Name] = make(chan struct{})
+	}
+
+	var slots chan struct{}
+	if concurrency > 0 {
+		slots = make(chan struct{}, concurrency)
+	}
+
+	var wg sync.WaitGroup
+	for _, p := range order {
+		waitFor := p.DependsOn
+		if reverse {
+			waitFor = manifest.Dependents(p.Name)
+		}
+
+		wg.Add(1)
+		go func(p *workspace.WorkspaceProject, waitFor []string) {
+			defer wg.Done()
+			defer close(done[p.Name])
+
+			result := results[p.Name]
+			for _, name := range waitFor {
+				<-done[name]
+				if dep := results[name]; dep.Status != workspaceSucceeded && result.Status == "" {
+					result.Status, result.Reason = workspaceSkipped, fmt.Sprintf("%s %s", name, dep.Status)
+				}
+			}
+			if result.Status == workspaceSkipped {
+				return
+			}
+
+			if slots != nil {
+				slots <- struct{}{}
+				defer func() { <-slots }()
+			}
+
+			start := time.Now()
+			err := run(p)
+			result.Duration = time.Since(start)
+			if err != nil {
+				result.Status, result.Reason = workspaceFailed, err.Error()
+			} else {
+				result.Status = workspaceSucceeded
+			}
+		}(p, waitFor)
+	}
+	wg.Wait()
+
+	sorted := make([]*workspaceResult, len(order))
+	for i, p := range order {
+		sorted[i] = results[p.Name]
+	}
+	return sorted, nil
+}
+
+// newWorkspaceProjectRunner returns a function that runs `pulumi <op>` for a workspace project in a child process,
+// writing its output to out with each line prefixed by the project's name.
+func newWorkspaceProjectRunner(op string, opArgs []string, manifest *workspace.WorkspaceManifest,
+	out io.Writer) (func(p *workspace.WorkspaceProject) error, error) {
+
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, errors.Wrap(err, "locating the pulumi executable")
+	}
+
+	width := 0
+	for _, p := range manifest.Projects {
+		if len(p.Name) > width {
+			width = len(p.Name)
+		}
+	}
+
+	var lock sync.Mutex
+	return func(p *workspace.WorkspaceProject) error {
+		args := []string{op, "--cwd", p.Dir, "--non-interactive", "--color", string(cmdutil.GetGlobalColorization())}
+		if p.Stack != "" {
+			args = append(args, "--stack", p.Stack)
+		}
+		args = append(args, opArgs...)
+
+		w := &prefixedLineWriter{
+			out:    out,
+			lock:   &lock,
+			prefix: fmt.Sprintf("%-"+strconv.Itoa(width)+"s | ", p.Name),
+		}
+		defer w.Flush()
+
+		child := exec.Command(exe, args...) // nolint: gas, re-running ourselves with arguments we built
+		child.Stdout, child.Stderr = w, w
+		if err := child.Run(); err != nil {
+			return errors.Wrapf(err, "pulumi %s", op)
+		}
+		return nil
+	}, nil
+}
+
+// prefixedLineWriter writes complete lines to out, each preceded by prefix. Writes from writers sharing a lock never
+// interleave within a line.
+type prefixedLineWriter struct {
+	out     io.Writer
+	lock    *sync.Mutex
+	prefix  string
+	partial []byte
+}
+
+func (w *prefixedLineWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		if err := w.writeLine(w.partial[:i+1]); err != nil {
+			return 0, err
+		}
+		w.partial = w.partial[i+1:]
+	}
+}
+
+// Flush writes any incomplete final line.
+func (w *prefixedLineWriter) Flush() {
+	if len(w.partial) > 0 {
+		contract.IgnoreError(w.writeLine(append(w.partial, '\n')))
+		w.partial = nil
+	}
+}
+
+func (w *prefixedLineWriter) writeLine(line []byte) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	_, err := fmt.Fprintf(w.out, "%s%s", w.prefix, line)
+	return err
+}
+
+// printWorkspaceSummary prints the outcome of every project, returning an error if any didn't succeed.
+func printWorkspaceSummary(out io.Writer, results []*workspaceResult) error {
+	maxname, maxstack := len("PROJECT"), len("STACK")
+	for _, r := range results {
+		if len(r.Project.Name) > maxname {
+			maxname = len(r.Project.Name)
+		}
+		if len(workspaceStackName(r.Project)) > maxstack {
+			maxstack = len(workspaceStackName(r.Project))
+		}
+	}
+
+	format := "%-" + strconv.Itoa(maxname) + "s %-" + strconv.Itoa(maxstack) + "s %-9s %-10s %s\n"
+	fmt.Fprintf(out, "\nWorkspace summary:\n")
+	fmt.Fprintf(out, format, "PROJECT", "STACK", "RESULT", "DURATION", "REASON")
+	unsuccessful := 0
+	for _, r := range results {
+		duration := ""
+		if r.Status != workspaceSkipped {
+			duration = r.Duration.Round(time.Second).String()
+		}
+		fmt.Fprintf(out, format, r.Project.Name, workspaceStackName(r.Project), r.Status, duration, r.Reason)
+		if r.Status != workspaceSucceeded {
+			unsuccessful++
+		}
+	}
+
+	if unsuccessful > 0 {
+		return errors.Errorf("%d of %d projects did not succeed", unsuccessful, len(results))
+	}
+	return nil
+}
+
+func workspaceStackName(p *workspace.WorkspaceProject) string {
+	if p.Stack == "" {
+		return "(selected)"
+	}
+	return p.Stack
+}
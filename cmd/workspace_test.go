@@ -0,0 +1,166 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// newTestWorkspaceManifest returns a workspace in which c depends on a and b, d depends on c, and e depends on
+// nothing.
+func newTestWorkspaceManifest() *workspace.WorkspaceManifest {
+	return &workspace.WorkspaceManifest{
+		Projects: []*workspace.WorkspaceProject{
+			{Name: "a"},
+			{Name: "b"},
+			{Name: "c", DependsOn: []string{"a", "b"}},
+			{Name: "d", DependsOn: []string{"c"}},
+			{Name: "e"},
+		},
+	}
+}
+
+// recordRuns returns a run function that records when each project started and finished.
+func recordRuns(fail map[string]bool) (func(p *workspace.WorkspaceProject) error, func() []string) {
+	var lock sync.Mutex
+	var events []string
+	run := func(p *workspace.WorkspaceProject) error {
+		lock.Lock()
+		events = append(events, "start "+p.Name)
+		lock.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		lock.Lock()
+		events = append(events, "end "+p.Name)
+		lock.Unlock()
+		if fail[p.Name] {
+			return errors.New("boom")
+		}
+		return nil
+	}
+	return run, func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string(nil), events...)
+	}
+}
+
+func indexOf(events []string, event string) int {
+	for i, e := range events {
+		if e == event {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestRunWorkspaceProjects(t *testing.T) {
+	m := newTestWorkspaceManifest()
+	run, events := recordRuns(nil)
+
+	results, err := runWorkspaceProjects(m, false /*reverse*/, 0, run)
+	assert.NoError(t, err)
+	assert.Len(t, results, 5)
+	for _, r := range results {
+		assert.Equal(t, workspaceSucceeded, r.Status, r.Project.Name)
+	}
+
+	e := events()
+	assert.True(t, indexOf(e, "end a") < indexOf(e, "start c"))
+	assert.True(t, indexOf(e, "end b") < indexOf(e, "start c"))
+	assert.True(t, indexOf(e, "end c") < indexOf(e, "start d"))
+	// Independent projects overlap.
+	assert.True(t, indexOf(e, "start b") < indexOf(e, "end a"))
+	assert.True(t, indexOf(e, "start e") < indexOf(e, "end a"))
+}
+
+func TestRunWorkspaceProjectsReverse(t *testing.T) {
+	m := newTestWorkspaceManifest()
+	run, events := recordRuns(nil)
+
+	_, err := runWorkspaceProjects(m, true /*reverse*/, 0, run)
+	assert.NoError(t, err)
+
+	e := events()
+	assert.True(t, indexOf(e, "end d") < indexOf(e, "start c"))
+	assert.True(t, indexOf(e, "end c") < indexOf(e, "start a"))
+	assert.True(t, indexOf(e, "end c") < indexOf(e, "start b"))
+}
+
+func TestRunWorkspaceProjectsConcurrency(t *testing.T) {
+	m := newTestWorkspaceManifest()
+	run, events := recordRuns(nil)
+
+	_, err := runWorkspaceProjects(m, false /*reverse*/, 1, run)
+	assert.NoError(t, err)
+
+	// With a single slot, every project finishes before the next starts.
+	e := events()
+	assert.Len(t, e, 10)
+	for i := 0; i < len(e); i += 2 {
+		assert.Equal(t, "start", e[i][:5])
+		assert.Equal(t, "end "+e[i][6:], e[i+1])
+	}
+}
+
+func TestRunWorkspaceProjectsFailure(t *testing.T) {
+	m := newTestWorkspaceManifest()
+	run, events := recordRuns(map[string]bool{"b": true})
+
+	results, err := runWorkspaceProjects(m, false /*reverse*/, 0, run)
+	assert.NoError(t, err)
+
+	status := make(map[string]*workspaceResult)
+	for _, r := range results {
+		status[r.Project.Name] = r
+	}
+	assert.Equal(t, workspaceSucceeded, status["a"].Status)
+	assert.Equal(t, workspaceFailed, status["b"].Status)
+	assert.Equal(t, workspaceSkipped, status["c"].Status)
+	assert.Equal(t, "b failed", status["c"].Reason)
+	assert.Equal(t, workspaceSkipped, status["d"].Status)
+	assert.Equal(t, "c skipped", status["d"].Reason)
+	assert.Equal(t, workspaceSucceeded, status["e"].Status)
+	assert.Equal(t, -1, indexOf(events(), "start c"))
+
+	var out bytes.Buffer
+	err = printWorkspaceSummary(&out, results)
+	assert.EqualError(t, err, "3 of 5 projects did not succeed")
+	assert.Contains(t, out.String(), "b       (selected) failed")
+}
+
+func TestPrefixedLineWriter(t *testing.T) {
+	var out bytes.Buffer
+	var lock sync.Mutex
+	w := &prefixedLineWriter{out: &out, lock: &lock, prefix: "net | "}
+
+	_, err := w.Write([]byte("one\ntw"))
+	assert.NoError(t, err)
+	_, err = w.Write([]byte("o\nthree"))
+	assert.NoError(t, err)
+	assert.Equal(t, "net | one\nnet | two\n", out.String())
+
+	w.Flush()
+	assert.Equal(t, "net | one\nnet | two\nnet | three\n", out.String())
+}
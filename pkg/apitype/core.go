@@ -243,6 +243,12 @@ const (
 	// GitHubRepositoryNameTag is a tag that represents the name of a repository on GitHub that this stack
 	// may be associated with (inferred by the CLI based on git remote info).
 	GitHubRepositoryNameTag StackTagName = "gitHub:repo"
+	// StackTTLTag is a tag that records how long an ephemeral stack, such as one created to review a pull request,
+	// is meant to live (e.g. "24h0m0s").
+	StackTTLTag StackTagName = "pulumi:ttl"
+	// StackExpiresTag is a tag that records when an ephemeral stack expires, in RFC 3339 format. Expired stacks are
+	// destroyed and removed by `pulumi stack gc`.
+	StackExpiresTag StackTagName = "pulumi:expires"
 )
 
 // Stack describes a Stack running on a Pulumi Cloud.
@@ -0,0 +1,180 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apitype
+
+// EngineEventSchemaVersion is the version of the EngineEvent schema.  It is incremented whenever a change is made to
+// the schema that is not backwards compatible, e.g. removing or renaming a field or changing its meaning.  Adding new
+// fields or event types does not change the version, so consumers must ignore anything they do not understand.
+const EngineEventSchemaVersion = 1
+
+// EngineEvent is a single event generated by the engine during an update, preview, refresh or destroy.  A stream of
+// these is emitted by `pulumi up --json` and friends, one per line.  Exactly one of the event-specific fields will be
+// set, according to the kind of event.
+//
+// Property values and messages are sanitized before being written: the values of any secret configuration are
+// replaced with "[secret]", and the contents of non-code assets are stripped.
+type EngineEvent struct {
+	// Version is the version of the EngineEvent schema used to encode the event.
+	Version int `json:"version"`
+	// Sequence is a unique, monotonically increasing number for each event in the stream.
+	Sequence int `json:"sequence"`
+	// Timestamp is a Unix timestamp, in milliseconds, of when the event was emitted.
+	Timestamp int64 `json:"timestamp"`
+
+	CancelEvent      *CancelEvent       `json:"cancelEvent,omitempty"`
+	StdoutEvent      *StdoutEngineEvent `json:"stdoutEvent,omitempty"`
+	DiagnosticEvent  *DiagnosticEvent   `json:"diagnosticEvent,omitempty"`
+	PreludeEvent     *PreludeEvent      `json:"preludeEvent,omitempty"`
+	SummaryEvent     *SummaryEvent      `json:"summaryEvent,omitempty"`
+	ResourcePreEvent *ResourcePreEvent  `json:"resourcePreEvent,omitempty"`
+	ResOutputsEvent  *ResOutputsEvent   `json:"resOutputsEvent,omitempty"`
+	ResOpFailedEvent *ResOpFailedEvent  `json:"resOpFailedEvent,omitempty"`
+
+	PolicyViolationEvent *PolicyViolationEvent `json:"policyViolationEvent,omitempty"`
+}
+
+// CancelEvent is emitted when the operation has finished, whether or not it was canceled.  It is always the last event
+// in a stream.
+type CancelEvent struct{}
+
+// StdoutEngineEvent is emitted whenever the engine writes a generic message to stdout.
+type StdoutEngineEvent struct {
+	Message string `json:"message"`
+}
+
+// DiagnosticEvent is emitted whenever a diagnostic message is reported by the engine, a program or a plugin.
+type DiagnosticEvent struct {
+	// URN is the resource the diagnostic is associated with, if any.
+	URN string `json:"urn,omitempty"`
+	// Prefix is the prefix, such as "error: ", that is rendered before the message.
+	Prefix string `json:"prefix,omitempty"`
+	// Message is the text of the diagnostic, without any colorization.
+	Message string `json:"message"`
+	// Severity is one of "debug", "info", "info#err", "warning" or "error".
+	Severity string `json:"severity"`
+	// StreamID identifies the stream of messages this one belongs to, if any.
+	StreamID int32 `json:"streamID,omitempty"`
+	// Ephemeral is true if the message is transient and need not be retained.
+	Ephemeral bool `json:"ephemeral,omitempty"`
+}
+
+// PreludeEvent is emitted at the start of an operation.
+type PreludeEvent struct {
+	// IsPreview is true if the operation is a preview.
+	IsPreview bool `json:"isPreview"`
+	// Config is the stack's configuration.  The values of secrets are blinded.
+	Config map[string]string `json:"config"`
+}
+
+// SummaryEvent is emitted at the end of an operation, with a summary of the changes made.
+type SummaryEvent struct {
+	// IsPreview is true if the operation is a preview.
+	IsPreview bool `json:"isPreview"`
+	// MaybeCorrupt is true if one or more resources may be corrupt.
+	MaybeCorrupt bool `json:"maybeCorrupt"`
+	// DurationSeconds is the number of seconds the operation took to run (zero for previews).
+	DurationSeconds int `json:"durationSeconds"`
+	// ResourceChanges counts the number of resources affected by each kind of operation, e.g. "create" or "same".
+	ResourceChanges map[string]int `json:"resourceChanges"`
+}
+
+// StepEventMetadata describes a step performed by the engine on a resource.
+type StepEventMetadata struct {
+	// Op is the operation being performed, e.g. "create", "update", "delete" or "same".
+	Op string `json:"op"`
+	// URN is the resource's URN.
+	URN string `json:"urn"`
+	// Type is the resource's type.
+	Type string `json:"type"`
+
+	// Old is the state of the resource before the step, if any.
+	Old *StepEventStateMetadata `json:"old,omitempty"`
+	// New is the state of the resource after the step, if any.
+	New *StepEventStateMetadata `json:"new,omitempty"`
+
+	// Keys are the property keys that caused a replacement, for create-replacement and replace steps.
+	Keys []string `json:"keys,omitempty"`
+	// Logical is true if the step represents a logical operation in the program.
+	Logical bool `json:"logical,omitempty"`
+	// Provider is a reference to the provider that performed the step.
+	Provider string `json:"provider,omitempty"`
+}
+
+// StepEventStateMetadata is the state of a resource before or after a step.
+type StepEventStateMetadata struct {
+	Type   string `json:"type"`
+	URN    string `json:"urn"`
+	Custom bool   `json:"custom,omitempty"`
+	// Delete is true if the resource is pending deletion due to a replacement.
+	Delete bool `json:"delete,omitempty"`
+	// ID is the resource's ID, as assigned by its provider; it is empty if the resource has not been created.
+	ID     string `json:"id,omitempty"`
+	Parent string `json:"parent,omitempty"`
+	// Protect is true if the resource may not be deleted.
+	Protect bool `json:"protect,omitempty"`
+	// Inputs are the resource's input properties, as specified by the program.
+	Inputs map[string]interface{} `json:"inputs"`
+	// Outputs are the resource's output properties, as returned by its provider.
+	Outputs map[string]interface{} `json:"outputs"`
+	// Provider is a reference to the resource's provider.
+	Provider string `json:"provider,omitempty"`
+	// InitErrors is the set of errors encountered while initializing the resource.
+	InitErrors []string `json:"initErrors,omitempty"`
+	// Dependencies are the URNs of the resources this resource depends on.
+	Dependencies []string `json:"dependencies,omitempty"`
+}
+
+// ResourcePreEvent is emitted before a step is performed on a resource.
+type ResourcePreEvent struct {
+	Metadata StepEventMetadata `json:"metadata"`
+	// Planning is true if the step is only being planned, as in a preview.
+	Planning bool `json:"planning,omitempty"`
+}
+
+// ResOutputsEvent is emitted after a step has been performed on a resource and its outputs are known.
+type ResOutputsEvent struct {
+	Metadata StepEventMetadata `json:"metadata"`
+	// Planning is true if the step is only being planned, as in a preview.
+	Planning bool `json:"planning,omitempty"`
+	// DurationMilliseconds is the number of milliseconds taken to perform the step (zero for previews).
+	DurationMilliseconds int64 `json:"durationMilliseconds,omitempty"`
+}
+
+// ResOpFailedEvent is emitted when a step on a resource fails.
+type ResOpFailedEvent struct {
+	Metadata StepEventMetadata `json:"metadata"`
+	// Status is 0 if the step failed without changing the resource, 1 if it partially failed and 2 if its effect is
+	// unknown.
+	Status int `json:"status"`
+	// Steps is the number of steps that had been performed when the failure occurred.
+	Steps int `json:"steps"`
+	// DurationMilliseconds is the number of milliseconds spent performing the step before it failed.
+	DurationMilliseconds int64 `json:"durationMilliseconds,omitempty"`
+}
+
+// PolicyViolationEvent is emitted whenever an analyzer reports that a resource, or the stack as a whole, violates one
+// of its policies.
+type PolicyViolationEvent struct {
+	// ResourceURN is the resource that violated the policy, or empty if the violation applies to the whole stack.
+	ResourceURN string `json:"resourceUrn,omitempty"`
+	// Analyzer is the name of the analyzer that reported the violation.
+	Analyzer string `json:"analyzer"`
+	// Property is the property that violated the policy, if any.
+	Property string `json:"property,omitempty"`
+	// Message describes the violation.
+	Message string `json:"message"`
+	// EnforcementLevel is either "mandatory", if the violation blocks the update, or "advisory".
+	EnforcementLevel string `json:"enforcementLevel"`
+}
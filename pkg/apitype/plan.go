@@ -0,0 +1,62 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apitype
+
+// UpdatePlanSchemaVersion is the version of the UpdatePlan schema.  It is incremented whenever a change is made to the
+// schema that is not backwards compatible.  Adding new fields does not change the version.
+const UpdatePlanSchemaVersion = 1
+
+// UpdatePlan is a machine-readable description of the steps a preview determined must be taken to update a stack.  It
+// is written by `pulumi preview --save-plan`.
+type UpdatePlan struct {
+	// Version is the version of the UpdatePlan schema used to encode the plan.
+	Version int `json:"version"`
+	// Project is the name of the project the stack belongs to.
+	Project string `json:"project"`
+	// Stack is the name of the stack the plan applies to.
+	Stack string `json:"stack"`
+	// Steps are the steps the update would perform, in the order the preview produced them.
+	Steps []PlanStep `json:"steps"`
+	// ResourceChanges counts the number of resources affected by each kind of operation, e.g. "create" or "same".
+	ResourceChanges map[string]int `json:"resourceChanges"`
+}
+
+// PlanStep is a single step in an UpdatePlan.
+type PlanStep struct {
+	// Op is the operation to be performed, e.g. "create", "update", "replace", "delete" or "same".
+	Op string `json:"op"`
+	// URN is the URN of the resource the step applies to.
+	URN string `json:"urn"`
+	// Type is the type of the resource the step applies to.
+	Type string `json:"type"`
+	// Provider is a reference to the provider that will perform the step.
+	Provider string `json:"provider,omitempty"`
+	// Logical is true if the step represents an operation in the program, rather than one of the several physical
+	// steps that make up a replacement.
+	Logical bool `json:"logical"`
+
+	// OldInputs are the resource's input properties before the step, if it already exists.  Secrets are blinded.
+	OldInputs map[string]interface{} `json:"oldInputs,omitempty"`
+	// NewInputs are the resource's input properties after the step, unless it is a deletion.  Secrets are blinded
+	// and values that will not be known until the update runs are recorded as "[unknown]".
+	NewInputs map[string]interface{} `json:"newInputs,omitempty"`
+	// ChangedKeys are the names of the input properties that were added, removed or changed.  It is only set for
+	// steps that have both old and new inputs.
+	ChangedKeys []string `json:"changedKeys,omitempty"`
+	// ReplaceKeys are the names of the input properties whose changes require the resource to be replaced.
+	ReplaceKeys []string `json:"replaceKeys,omitempty"`
+	// HasUnknowns is true if any of the new inputs will not be known until the update runs.
+	HasUnknowns bool `json:"hasUnknowns"`
+}
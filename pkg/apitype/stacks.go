@@ -26,8 +26,20 @@ type StackSummary struct {
 	// LastUpdate is a Unix timestamp of the stack's last update, as applicable.
 	LastUpdate *int64 `json:"lastUpdate,omitempty"`
 
+	// LastUpdateKind is the kind of the stack's last update, as applicable.
+	LastUpdateKind *UpdateKind `json:"lastUpdateKind,omitempty"`
+
+	// LastUpdateResult is the result of the stack's last update, as applicable.
+	LastUpdateResult *UpdateResult `json:"lastUpdateResult,omitempty"`
+
+	// ActiveUpdate is the ID of the update currently running against the stack, if any.
+	ActiveUpdate string `json:"activeUpdate,omitempty"`
+
 	// ResourceCount is the number of resources associated with this stack, as applicable.
 	ResourceCount *int `json:"resourceCount,omitempty"`
+
+	// Tags are the stack's tags, as applicable.
+	Tags map[StackTagName]string `json:"tags,omitempty"`
 }
 
 // ListStacksResponse returns a set of stack summaries. This call is designed to be inexpensive.
@@ -57,6 +69,18 @@ type CreateStackResponseByName struct {
 	CloudName string `json:"cloudName"`
 }
 
+// UpdateStackTagsRequest defines the request body for replacing a stack's tags.
+type UpdateStackTagsRequest struct {
+	// Tags is the complete set of tags the stack should have.
+	Tags map[StackTagName]string `json:"tags"`
+}
+
+// StackRenameRequest defines the request body for renaming a stack.
+type StackRenameRequest struct {
+	// NewName is the stack's new name.
+	NewName string `json:"newName"`
+}
+
 // GetStackResponse describes the data returned by the `/GET /stack/{stackID}` endpoint of the PPC API. If the
 // `deployment` query parameter is set to `true`, `Deployment` will be set and `Resources will be empty.
 type GetStackResponse struct {
@@ -270,6 +270,25 @@ type RenewUpdateLeaseResponse struct {
 	Token string `json:"token"`
 }
 
+// StackLockStatus describes the update, if any, that currently holds the lock on a stack.  It is returned by the
+// `GET /stacks/{orgName}/{stackName}/lock` endpoint of the service API.
+type StackLockStatus struct {
+	// Locked is true if an update currently holds the stack's lock.  The remaining fields are only set if so.
+	Locked bool `json:"locked"`
+	// UpdateID is the ID of the update holding the lock.
+	UpdateID string `json:"updateID,omitempty"`
+	// Kind is the kind of the update holding the lock.
+	Kind UpdateKind `json:"kind,omitempty"`
+	// RequestedBy is the name of the user that started the update.
+	RequestedBy string `json:"requestedBy,omitempty"`
+	// StartTime is a Unix timestamp of when the update started.
+	StartTime int64 `json:"startTime,omitempty"`
+	// LastLeaseRenewal is a Unix timestamp of when the update's lease was last renewed.
+	LastLeaseRenewal int64 `json:"lastLeaseRenewal,omitempty"`
+	// LeaseExpiration is a Unix timestamp of when the update's lease will expire if it is not renewed.
+	LeaseExpiration int64 `json:"leaseExpiration,omitempty"`
+}
+
 const (
 	// UpdateStatusSucceeded indicates that an update completed successfully.
 	UpdateStatusSucceeded UpdateStatus = "succeeded"
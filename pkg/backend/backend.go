@@ -65,8 +65,16 @@ type StackSummary interface {
 
 	// LastUpdate returns when the stack was last updated, as applicable.
 	LastUpdate() *time.Time
+	// LastUpdateKind returns the kind of the stack's last update, as applicable.
+	LastUpdateKind() *apitype.UpdateKind
+	// LastUpdateResult returns the result of the stack's last update, as applicable.
+	LastUpdateResult() *apitype.UpdateResult
+	// UpdateInProgress returns true if an update to the stack is currently running.
+	UpdateInProgress() bool
 	// ResourceCount returns the stack's resource count, as applicable.
 	ResourceCount() *int
+	// Tags returns the stack's tags, as applicable.
+	Tags() map[apitype.StackTagName]string
 }
 
 // Backend is an interface that represents actions the engine will interact with to manage stacks of cloud resources.
@@ -89,6 +97,15 @@ type Backend interface {
 	// still contains resources.  Otherwise, if the stack contains resources, a non-nil error is returned, and the
 	// first boolean return value will be set to true.
 	RemoveStack(ctx context.Context, stackRef StackReference, force bool) (bool, error)
+	// RenameStack renames the given stack to the new name and returns a reference to the renamed stack.  Because the
+	// stack name is part of every resource's URN, this rewrites the URNs of all of the stack's resources as well.
+	RenameStack(ctx context.Context, stackRef StackReference, newName tokens.QName) (StackReference, error)
+	// UpdateStackTags replaces the tags of the given stack with the given set.
+	UpdateStackTags(ctx context.Context, stackRef StackReference, tags map[apitype.StackTagName]string) error
+	// GetStackLockStatus returns information about the update, if any, that currently holds the given stack's lock.
+	GetStackLockStatus(ctx context.Context, stackRef StackReference) (apitype.StackLockStatus, error)
+	// ForceUnlockStack breaks the given stack's lock, regardless of which update holds it.
+	ForceUnlockStack(ctx context.Context, stackRef StackReference) error
 	// ListStacks returns a list of stack summaries for all known stacks in the target backend.
 	ListStacks(ctx context.Context, projectFilter *tokens.PackageName) ([]StackSummary, error)
 
@@ -109,6 +126,9 @@ type Backend interface {
 	GetHistory(ctx context.Context, stackRef StackReference) ([]UpdateInfo, error)
 	// GetLogs fetches a list of log entries for the given stack, with optional filtering/querying.
 	GetLogs(ctx context.Context, stackRef StackReference, query operations.LogQuery) ([]operations.LogEntry, error)
+	// GetMetrics fetches metric time series for the resources in the given stack, with optional filtering/querying.
+	GetMetrics(ctx context.Context, stackRef StackReference,
+		query operations.MetricsQuery) ([]operations.MetricSeries, error)
 	// Get the configuration from the most recent deployment of the stack.
 	GetLatestConfiguration(ctx context.Context, stackRef StackReference) (config.Map, error)
 
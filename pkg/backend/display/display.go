@@ -31,9 +31,11 @@ import (
 	"github.com/pulumi/pulumi/pkg/engine"
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
 )
 
 // ShowEvents reads events from the `events` channel until it is closed, displaying each event as
@@ -41,13 +43,44 @@ import (
 // channel so the caller can await all the events being written.
 func ShowEvents(op string, action apitype.UpdateKind, stack tokens.QName, proj tokens.PackageName,
 	events <-chan engine.Event, done chan<- bool, opts Options) {
-	if opts.DiffDisplay {
+	if opts.EventLog != nil {
+		events = teeEvents(events, func(e engine.Event) {
+			if err := opts.EventLog.Write(e); err != nil {
+				logging.V(7).Infof("failed to write event to the event log: %v", err)
+			}
+		})
+	}
+	if opts.PlanRecorder != nil {
+		events = teeEvents(events, opts.PlanRecorder.Record)
+	}
+
+	if opts.JSONDisplay {
+		drainEvents(events, done)
+	} else if opts.DiffDisplay {
 		ShowDiffEvents(op, action, events, done, opts)
 	} else {
 		ShowProgressEvents(op, action, stack, proj, events, done, opts)
 	}
 }
 
+// teeEvents returns a channel that receives every event sent on the given channel, after the event has been passed to
+// the given function.  The returned channel is closed once a cancel event has been forwarded or the input channel is
+// closed.
+func teeEvents(events <-chan engine.Event, f func(e engine.Event)) <-chan engine.Event {
+	out := make(chan engine.Event)
+	go func() {
+		defer close(out)
+		for e := range events {
+			f(e)
+			out <- e
+			if e.Type == engine.CancelEvent {
+				return
+			}
+		}
+	}()
+	return out
+}
+
 type nopSpinner struct {
 }
 
@@ -80,6 +113,8 @@ func ShowDiffEvents(op string, action apitype.UpdateKind,
 	}()
 
 	seen := make(map[resource.URN]engine.StepEventMetadata)
+	var violations []engine.PolicyViolationEventPayload
+	timings := &stepTimings{}
 
 	for {
 		select {
@@ -96,11 +131,30 @@ func ShowDiffEvents(op string, action apitype.UpdateKind,
 				}
 			}
 
+			// Policy violations are collected and reported together, just before the summary.
+			switch event.Type {
+			case engine.PolicyViolationEvent:
+				violations = append(violations, event.Payload.(engine.PolicyViolationEventPayload))
+			case engine.SummaryEvent, engine.CancelEvent:
+				if len(violations) > 0 {
+					fprintIgnoreError(out, renderPolicyViolations(violations, opts))
+					violations = nil
+				}
+			}
+
 			msg := RenderDiffEvent(action, event, seen, opts)
 			if msg != "" && out != nil {
 				fprintIgnoreError(out, msg)
 			}
 
+			// Once an update has finished, report which of its steps took the longest.
+			timings.record(event)
+			if event.Type == engine.SummaryEvent && !event.Payload.(engine.SummaryEventPayload).IsPreview {
+				if report := renderTimingReport(timings, opts); report != "" {
+					fprintIgnoreError(out, "\n"+report)
+				}
+			}
+
 			if event.Type == engine.CancelEvent {
 				return
 			}
@@ -136,6 +190,11 @@ func RenderDiffEvent(action apitype.UpdateKind, event engine.Event,
 	case engine.DiagEvent:
 		return renderDiffDiagEvent(event.Payload.(engine.DiagEventPayload), opts)
 
+		// Policy violations are grouped into a single report at the end of the operation, so there is nothing to
+		// render for each individual violation.
+	case engine.PolicyViolationEvent:
+		return ""
+
 	default:
 		contract.Failf("unknown event type '%s'", event.Type)
 		return ""
@@ -202,6 +261,44 @@ func renderSummaryEvent(action apitype.UpdateKind, event engine.SummaryEventPayl
 	return out.String()
 }
 
+// renderPolicyViolations renders a report of the given policy violations, grouped by the analyzer that reported them.
+func renderPolicyViolations(violations []engine.PolicyViolationEventPayload, opts Options) string {
+	byAnalyzer := make(map[tokens.QName][]engine.PolicyViolationEventPayload)
+	var analyzers []string
+	for _, v := range violations {
+		if _, has := byAnalyzer[v.Analyzer]; !has {
+			analyzers = append(analyzers, string(v.Analyzer))
+		}
+		byAnalyzer[v.Analyzer] = append(byAnalyzer[v.Analyzer], v)
+	}
+	sort.Strings(analyzers)
+
+	out := &bytes.Buffer{}
+	fprintIgnoreError(out, opts.Color.Colorize(
+		fmt.Sprintf("%sPolicy Violations:%s\n", colors.SpecHeadline, colors.Reset)))
+	for _, a := range analyzers {
+		fprintIgnoreError(out, opts.Color.Colorize(fmt.Sprintf("  %s%s:%s\n", colors.BrightBlue, a, colors.Reset)))
+		for _, v := range byAnalyzer[tokens.QName(a)] {
+			color := colors.SpecError
+			if v.EnforcementLevel == plugin.Advisory {
+				color = colors.SpecWarning
+			}
+
+			subject := "(stack)"
+			if v.ResourceURN != "" {
+				subject = fmt.Sprintf("%s (%s)", v.ResourceURN.Type(), v.ResourceURN.Name())
+			}
+			if v.Property != "" {
+				subject = fmt.Sprintf("%s, property %s", subject, v.Property)
+			}
+
+			fprintIgnoreError(out, opts.Color.Colorize(fmt.Sprintf("    %s[%s]%s %s: %s\n",
+				color, v.EnforcementLevel, colors.Reset, subject, v.Message)))
+		}
+	}
+	return out.String()
+}
+
 func renderPreludeEvent(event engine.PreludeEventPayload, opts Options) string {
 	// Only if we have been instructed to show configuration values will we print anything during the prelude.
 	if !opts.ShowConfig {
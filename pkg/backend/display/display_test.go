@@ -0,0 +1,44 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+)
+
+func TestRenderPolicyViolations(t *testing.T) {
+	urn := resource.URN("urn:pulumi:dev::proj::aws:s3/bucket:Bucket::logs")
+	violations := []engine.PolicyViolationEventPayload{
+		{ResourceURN: urn, Analyzer: "tags", Property: "tags", Message: "missing owner",
+			EnforcementLevel: plugin.Mandatory},
+		{Analyzer: "cost", Message: "too many buckets", EnforcementLevel: plugin.Advisory},
+		{ResourceURN: urn, Analyzer: "tags", Message: "name too short", EnforcementLevel: plugin.Advisory},
+	}
+
+	expected := "Policy Violations:\n" +
+		"  cost:\n" +
+		"    [advisory] (stack): too many buckets\n" +
+		"  tags:\n" +
+		"    [mandatory] aws:s3/bucket:Bucket (logs), property tags: missing owner\n" +
+		"    [advisory] aws:s3/bucket:Bucket (logs): name too short\n"
+	assert.Equal(t, expected, renderPolicyViolations(violations, Options{Color: colors.Never}))
+}
@@ -0,0 +1,242 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+)
+
+// unknownPropertyValue is written in place of property values that are not yet known, e.g. during a preview.
+const unknownPropertyValue = "[unknown]"
+
+// EventLog writes engine events to an underlying writer as a stream of JSON lines, one apitype.EngineEvent per line.
+// Sequence numbers are shared by all of the operations that write to the same log, so that a preview and the update
+// that follows it form a single stream.
+type EventLog struct {
+	mu       sync.Mutex
+	w        io.Writer
+	sequence int
+}
+
+// NewEventLog creates a new event log that writes to the given writer.
+func NewEventLog(w io.Writer) *EventLog {
+	return &EventLog{w: w}
+}
+
+// Write serializes the given engine event and writes it to the log.
+func (l *EventLog) Write(e engine.Event) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	apiEvent := ConvertEngineEvent(e)
+	apiEvent.Sequence = l.sequence
+	apiEvent.Timestamp = time.Now().UnixNano() / int64(time.Millisecond)
+	l.sequence++
+
+	b, err := json.Marshal(apiEvent)
+	if err != nil {
+		return err
+	}
+	_, err = l.w.Write(append(b, '\n'))
+	return err
+}
+
+// drainEvents reads events from the `events` channel until the operation completes, without displaying them.  Once all
+// events have been read, it signals the `done` channel.  This is used when events are only being written as JSON.
+func drainEvents(events <-chan engine.Event, done chan<- bool) {
+	defer func() {
+		done <- true
+	}()
+
+	for e := range events {
+		if e.Type == engine.CancelEvent {
+			return
+		}
+	}
+}
+
+// ConvertEngineEvent converts an engine event into its JSON representation.  The Sequence and Timestamp fields of the
+// result are left unset.  Any secrets in messages or property values are replaced with "[secret]".
+func ConvertEngineEvent(e engine.Event) apitype.EngineEvent {
+	apiEvent := apitype.EngineEvent{Version: apitype.EngineEventSchemaVersion}
+
+	switch e.Type {
+	case engine.CancelEvent:
+		apiEvent.CancelEvent = &apitype.CancelEvent{}
+
+	case engine.StdoutColorEvent:
+		p := e.Payload.(engine.StdoutEventPayload)
+		apiEvent.StdoutEvent = &apitype.StdoutEngineEvent{
+			Message: sanitizeMessage(p.Message),
+		}
+
+	case engine.DiagEvent:
+		p := e.Payload.(engine.DiagEventPayload)
+		apiEvent.DiagnosticEvent = &apitype.DiagnosticEvent{
+			URN:       string(p.URN),
+			Prefix:    sanitizeMessage(p.Prefix),
+			Message:   sanitizeMessage(p.Message),
+			Severity:  string(p.Severity),
+			StreamID:  p.StreamID,
+			Ephemeral: p.Ephemeral,
+		}
+
+	case engine.PreludeEvent:
+		p := e.Payload.(engine.PreludeEventPayload)
+		// Secret configuration values have already been blinded by the engine.
+		cfg := make(map[string]string, len(p.Config))
+		for k, v := range p.Config {
+			cfg[k] = v
+		}
+		apiEvent.PreludeEvent = &apitype.PreludeEvent{
+			IsPreview: p.IsPreview,
+			Config:    cfg,
+		}
+
+	case engine.SummaryEvent:
+		p := e.Payload.(engine.SummaryEventPayload)
+		changes := make(map[string]int, len(p.ResourceChanges))
+		for op, count := range p.ResourceChanges {
+			changes[string(op)] = count
+		}
+		apiEvent.SummaryEvent = &apitype.SummaryEvent{
+			IsPreview:       p.IsPreview,
+			MaybeCorrupt:    p.MaybeCorrupt,
+			DurationSeconds: int(p.Duration.Seconds()),
+			ResourceChanges: changes,
+		}
+
+	case engine.ResourcePreEvent:
+		p := e.Payload.(engine.ResourcePreEventPayload)
+		apiEvent.ResourcePreEvent = &apitype.ResourcePreEvent{
+			Metadata: convertStepEventMetadata(p.Metadata),
+			Planning: p.Planning,
+		}
+
+	case engine.ResourceOutputsEvent:
+		p := e.Payload.(engine.ResourceOutputsEventPayload)
+		apiEvent.ResOutputsEvent = &apitype.ResOutputsEvent{
+			Metadata:             convertStepEventMetadata(p.Metadata),
+			Planning:             p.Planning,
+			DurationMilliseconds: durationMilliseconds(p.Duration),
+		}
+
+	case engine.ResourceOperationFailed:
+		p := e.Payload.(engine.ResourceOperationFailedPayload)
+		apiEvent.ResOpFailedEvent = &apitype.ResOpFailedEvent{
+			Metadata:             convertStepEventMetadata(p.Metadata),
+			Status:               int(p.Status),
+			Steps:                p.Steps,
+			DurationMilliseconds: durationMilliseconds(p.Duration),
+		}
+
+	case engine.PolicyViolationEvent:
+		p := e.Payload.(engine.PolicyViolationEventPayload)
+		apiEvent.PolicyViolationEvent = &apitype.PolicyViolationEvent{
+			ResourceURN:      string(p.ResourceURN),
+			Analyzer:         string(p.Analyzer),
+			Property:         string(p.Property),
+			Message:          sanitizeMessage(p.Message),
+			EnforcementLevel: string(p.EnforcementLevel),
+		}
+
+	default:
+		contract.Failf("unknown event type '%s'", e.Type)
+	}
+
+	return apiEvent
+}
+
+func convertStepEventMetadata(md engine.StepEventMetadata) apitype.StepEventMetadata {
+	keys := make([]string, len(md.Keys))
+	for i, k := range md.Keys {
+		keys[i] = string(k)
+	}
+
+	return apitype.StepEventMetadata{
+		Op:       string(md.Op),
+		URN:      string(md.URN),
+		Type:     string(md.Type),
+		Old:      convertStepEventStateMetadata(md.Old),
+		New:      convertStepEventStateMetadata(md.New),
+		Keys:     keys,
+		Logical:  md.Logical,
+		Provider: md.Provider,
+	}
+}
+
+func convertStepEventStateMetadata(md *engine.StepEventStateMetadata) *apitype.StepEventStateMetadata {
+	if md == nil {
+		return nil
+	}
+
+	var deps []string
+	for _, dep := range md.Dependencies {
+		deps = append(deps, string(dep))
+	}
+
+	return &apitype.StepEventStateMetadata{
+		Type:         string(md.Type),
+		URN:          string(md.URN),
+		Custom:       md.Custom,
+		Delete:       md.Delete,
+		ID:           string(md.ID),
+		Parent:       string(md.Parent),
+		Protect:      md.Protect,
+		Inputs:       sanitizePropertyMap(md.Inputs),
+		Outputs:      sanitizePropertyMap(md.Outputs),
+		Provider:     md.Provider,
+		InitErrors:   md.InitErrors,
+		Dependencies: deps,
+	}
+}
+
+// durationMilliseconds converts a duration into a whole number of milliseconds.
+func durationMilliseconds(d time.Duration) int64 {
+	return int64(d / time.Millisecond)
+}
+
+// sanitizeMessage strips any colorization from the given message and filters out any secrets.
+func sanitizeMessage(msg string) string {
+	return logging.FilterString(colors.Never.Colorize(msg))
+}
+
+// sanitizePropertyMap converts the given property map into a JSON-friendly form, filtering any secrets out of its
+// string values.  Values that are not yet known are replaced with "[unknown]".
+func sanitizePropertyMap(props resource.PropertyMap) map[string]interface{} {
+	return props.MapRepl(nil, func(v resource.PropertyValue) (interface{}, bool) {
+		switch {
+		case v.IsComputed() || v.IsOutput():
+			return unknownPropertyValue, true
+		case v.IsString():
+			return logging.FilterString(v.StringValue()), true
+		case v.IsAsset():
+			return v.AssetValue().Serialize(), true
+		case v.IsArchive():
+			return v.ArchiveValue().Serialize(), true
+		}
+		return nil, false
+	})
+}
@@ -0,0 +1,113 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+func TestEventLogSequence(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewEventLog(&buf)
+
+	assert.NoError(t, log.Write(engine.Event{
+		Type:    engine.PreludeEvent,
+		Payload: engine.PreludeEventPayload{IsPreview: true, Config: map[string]string{"proj:key": "value"}},
+	}))
+	assert.NoError(t, log.Write(engine.Event{
+		Type: engine.DiagEvent,
+		Payload: engine.DiagEventPayload{
+			Message:  colors.Red + "oh no" + colors.Reset,
+			Severity: diag.Error,
+		},
+	}))
+	assert.NoError(t, log.Write(engine.Event{Type: engine.CancelEvent}))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 3)
+
+	var events []apitype.EngineEvent
+	for _, line := range lines {
+		var e apitype.EngineEvent
+		assert.NoError(t, json.Unmarshal([]byte(line), &e))
+		events = append(events, e)
+	}
+
+	for i, e := range events {
+		assert.Equal(t, apitype.EngineEventSchemaVersion, e.Version)
+		assert.Equal(t, i, e.Sequence)
+		assert.NotZero(t, e.Timestamp)
+	}
+	if assert.NotNil(t, events[0].PreludeEvent) {
+		assert.True(t, events[0].PreludeEvent.IsPreview)
+		assert.Equal(t, "value", events[0].PreludeEvent.Config["proj:key"])
+	}
+	if assert.NotNil(t, events[1].DiagnosticEvent) {
+		assert.Equal(t, "oh no", events[1].DiagnosticEvent.Message)
+		assert.Equal(t, "error", events[1].DiagnosticEvent.Severity)
+	}
+	assert.NotNil(t, events[2].CancelEvent)
+}
+
+func TestConvertResourceEvent(t *testing.T) {
+	urn := resource.URN("urn:pulumi:stack::proj::pkg:mod:Res::res")
+	e := ConvertEngineEvent(engine.Event{
+		Type: engine.ResourcePreEvent,
+		Payload: engine.ResourcePreEventPayload{
+			Metadata: engine.StepEventMetadata{
+				Op:   deploy.OpCreate,
+				URN:  urn,
+				Type: "pkg:mod:Res",
+				New: &engine.StepEventStateMetadata{
+					URN:  urn,
+					Type: "pkg:mod:Res",
+					Inputs: resource.PropertyMap{
+						"name":    resource.NewStringProperty("res"),
+						"size":    resource.NewNumberProperty(3),
+						"pending": resource.MakeComputed(resource.NewStringProperty("")),
+					},
+				},
+			},
+			Planning: true,
+		},
+	})
+
+	if assert.NotNil(t, e.ResourcePreEvent) {
+		md := e.ResourcePreEvent.Metadata
+		assert.Equal(t, "create", md.Op)
+		assert.Equal(t, string(urn), md.URN)
+		assert.Nil(t, md.Old)
+		if assert.NotNil(t, md.New) {
+			assert.Equal(t, map[string]interface{}{
+				"name":    "res",
+				"size":    float64(3),
+				"pending": unknownPropertyValue,
+			}, md.New.Inputs)
+		}
+		assert.True(t, e.ResourcePreEvent.Planning)
+	}
+}
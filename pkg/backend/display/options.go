@@ -27,4 +27,7 @@ type Options struct {
 	IsInteractive        bool                // If we should display things interactively
 	DiffDisplay          bool                // true if we should display things as a rich diff
 	Debug                bool                // true to enable debug output.
+	JSONDisplay          bool                // true to only write events to the event log, rather than rendering them.
+	EventLog             *EventLog           // if non-nil, a log to which every event is written as JSON.
+	PlanRecorder         *PlanRecorder       // if non-nil, a recorder that builds a plan from the steps in a preview.
 }
@@ -0,0 +1,115 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/engine"
+)
+
+// PlanRecorder builds an apitype.UpdatePlan from the events emitted by a preview.
+type PlanRecorder struct {
+	mu      sync.Mutex
+	steps   []apitype.PlanStep
+	changes map[string]int
+}
+
+// NewPlanRecorder creates a new, empty plan recorder.
+func NewPlanRecorder() *PlanRecorder {
+	return &PlanRecorder{}
+}
+
+// Record adds the step described by the given event, if any, to the plan.  Events that are not part of a preview are
+// ignored.
+func (r *PlanRecorder) Record(e engine.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch e.Type {
+	case engine.ResourcePreEvent:
+		p := e.Payload.(engine.ResourcePreEventPayload)
+		if p.Planning {
+			r.steps = append(r.steps, makePlanStep(p.Metadata))
+		}
+	case engine.SummaryEvent:
+		p := e.Payload.(engine.SummaryEventPayload)
+		if p.IsPreview {
+			r.changes = make(map[string]int, len(p.ResourceChanges))
+			for op, count := range p.ResourceChanges {
+				r.changes[string(op)] = count
+			}
+		}
+	}
+}
+
+// Plan returns the plan recorded so far.  The caller is responsible for filling in the project and stack.
+func (r *PlanRecorder) Plan() apitype.UpdatePlan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	steps := make([]apitype.PlanStep, len(r.steps))
+	copy(steps, r.steps)
+	changes := make(map[string]int, len(r.changes))
+	for op, count := range r.changes {
+		changes[op] = count
+	}
+
+	return apitype.UpdatePlan{
+		Version:         apitype.UpdatePlanSchemaVersion,
+		Steps:           steps,
+		ResourceChanges: changes,
+	}
+}
+
+func makePlanStep(md engine.StepEventMetadata) apitype.PlanStep {
+	step := apitype.PlanStep{
+		Op:       string(md.Op),
+		URN:      string(md.URN),
+		Type:     string(md.Type),
+		Provider: md.Provider,
+		Logical:  md.Logical,
+	}
+
+	for _, k := range md.Keys {
+		step.ReplaceKeys = append(step.ReplaceKeys, string(k))
+	}
+
+	if md.Old != nil {
+		step.OldInputs = sanitizePropertyMap(md.Old.Inputs)
+	}
+	if md.New != nil {
+		step.NewInputs = sanitizePropertyMap(md.New.Inputs)
+		step.HasUnknowns = md.New.Inputs.ContainsUnknowns()
+	}
+	if md.Old != nil && md.New != nil {
+		if diff := md.Old.Inputs.Diff(md.New.Inputs); diff != nil {
+			for k := range diff.Adds {
+				step.ChangedKeys = append(step.ChangedKeys, string(k))
+			}
+			for k := range diff.Deletes {
+				step.ChangedKeys = append(step.ChangedKeys, string(k))
+			}
+			for k := range diff.Updates {
+				step.ChangedKeys = append(step.ChangedKeys, string(k))
+			}
+			sort.Strings(step.ChangedKeys)
+		}
+	}
+
+	return step
+}
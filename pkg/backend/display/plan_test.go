@@ -0,0 +1,92 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+func TestPlanRecorder(t *testing.T) {
+	urn := resource.URN("urn:pulumi:stack::proj::pkg:mod:Res::res")
+	oldState := &engine.StepEventStateMetadata{
+		URN:  urn,
+		Type: "pkg:mod:Res",
+		Inputs: resource.PropertyMap{
+			"name": resource.NewStringProperty("res"),
+			"size": resource.NewNumberProperty(1),
+			"gone": resource.NewBoolProperty(true),
+		},
+	}
+	newState := &engine.StepEventStateMetadata{
+		URN:  urn,
+		Type: "pkg:mod:Res",
+		Inputs: resource.PropertyMap{
+			"name":  resource.NewStringProperty("res"),
+			"size":  resource.NewNumberProperty(2),
+			"added": resource.MakeComputed(resource.NewStringProperty("")),
+		},
+	}
+
+	recorder := NewPlanRecorder()
+	recorder.Record(engine.Event{
+		Type: engine.ResourcePreEvent,
+		Payload: engine.ResourcePreEventPayload{
+			Metadata: engine.StepEventMetadata{
+				Op:      deploy.OpReplace,
+				URN:     urn,
+				Type:    "pkg:mod:Res",
+				Old:     oldState,
+				New:     newState,
+				Keys:    []resource.PropertyKey{"size"},
+				Logical: true,
+			},
+			Planning: true,
+		},
+	})
+	// Steps that are not being planned must be ignored.
+	recorder.Record(engine.Event{
+		Type: engine.ResourcePreEvent,
+		Payload: engine.ResourcePreEventPayload{
+			Metadata: engine.StepEventMetadata{Op: deploy.OpDelete, URN: urn, Old: oldState},
+		},
+	})
+	recorder.Record(engine.Event{
+		Type: engine.SummaryEvent,
+		Payload: engine.SummaryEventPayload{
+			IsPreview:       true,
+			ResourceChanges: engine.ResourceChanges{deploy.OpReplace: 1},
+		},
+	})
+
+	plan := recorder.Plan()
+	assert.Equal(t, map[string]int{"replace": 1}, plan.ResourceChanges)
+	if assert.Len(t, plan.Steps, 1) {
+		step := plan.Steps[0]
+		assert.Equal(t, "replace", step.Op)
+		assert.Equal(t, string(urn), step.URN)
+		assert.True(t, step.Logical)
+		assert.Equal(t, []string{"added", "gone", "size"}, step.ChangedKeys)
+		assert.Equal(t, []string{"size"}, step.ReplaceKeys)
+		assert.True(t, step.HasUnknowns)
+		assert.Equal(t, true, step.OldInputs["gone"])
+		assert.Equal(t, unknownPropertyValue, step.NewInputs["added"])
+	}
+}
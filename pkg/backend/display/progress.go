@@ -111,6 +111,13 @@ type ProgressDisplay struct {
 	// messages we're outputting for them.
 	summaryEventPayload *engine.SummaryEventPayload
 
+	// Any policy violations reported by analyzers.  They will be printed as a single report after
+	// all diagnostics.
+	policyViolationPayloads []engine.PolicyViolationEventPayload
+
+	// the timings of the steps performed, reported after the summary of an update.
+	timings stepTimings
+
 	// Any system events we've received.  They will be printed at the bottom of all the status rows
 	systemEventPayloads []engine.StdoutEventPayload
 
@@ -728,6 +735,15 @@ func (display *ProgressDisplay) processEndSteps() {
 		}
 	}
 
+	// Print the report of any policy violations.
+	wrotePolicyViolations := len(display.policyViolationPayloads) > 0
+	if wrotePolicyViolations {
+		if !wroteDiagnosticHeader {
+			display.writeBlankLine()
+		}
+		display.writeSimpleMessage(renderPolicyViolations(display.policyViolationPayloads, display.opts))
+	}
+
 	// If we get stack outputs, display them at the end.
 	var wroteOutputs bool
 	if display.stackUrn != "" && display.seenStackOutputs && !display.opts.SuppressOutputs {
@@ -735,7 +751,7 @@ func (display *ProgressDisplay) processEndSteps() {
 		props := engine.GetResourceOutputsPropertiesString(
 			stackStep, 1, display.isPreview, display.opts.Debug, false /* refresh */)
 		if props != "" {
-			if !wroteDiagnosticHeader {
+			if !wroteDiagnosticHeader && !wrotePolicyViolations {
 				display.writeBlankLine()
 			}
 
@@ -747,12 +763,19 @@ func (display *ProgressDisplay) processEndSteps() {
 
 	// print the summary
 	if display.summaryEventPayload != nil {
-		if !wroteDiagnosticHeader && !wroteOutputs {
+		if !wroteDiagnosticHeader && !wrotePolicyViolations && !wroteOutputs {
 			display.writeBlankLine()
 		}
 
 		msg := renderSummaryEvent(display.action, *display.summaryEventPayload, display.opts)
 		display.writeSimpleMessage(msg)
+
+		if !display.summaryEventPayload.IsPreview {
+			if report := renderTimingReport(&display.timings, display.opts); report != "" {
+				display.writeBlankLine()
+				display.writeSimpleMessage(report)
+			}
+		}
 	}
 }
 
@@ -850,6 +873,8 @@ func (display *ProgressDisplay) getRowForURN(urn resource.URN, metadata *engine.
 }
 
 func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
+	display.timings.record(event)
+
 	switch event.Type {
 	case engine.PreludeEvent:
 		// A prelude event can just be printed out directly to the console.
@@ -874,6 +899,11 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 	case engine.StdoutColorEvent:
 		display.handleSystemEvent(event.Payload.(engine.StdoutEventPayload))
 		return
+	case engine.PolicyViolationEvent:
+		// keep track of policy violations so that we can report them together at the end.
+		payload := event.Payload.(engine.PolicyViolationEventPayload)
+		display.policyViolationPayloads = append(display.policyViolationPayloads, payload)
+		return
 	}
 
 	// At this point, all events should relate to resources.
@@ -0,0 +1,195 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+// slowestStepCount is the number of operations listed in the "Slowest operations" section of the timing report.
+const slowestStepCount = 5
+
+// stepTiming is the time taken to perform a single step during an update.
+type stepTiming struct {
+	URN          resource.URN   // the resource the step was performed on.
+	Op           deploy.StepOp  // the operation performed.
+	Duration     time.Duration  // the time taken to perform the step.
+	Failed       bool           // true if the step failed.
+	Dependencies []resource.URN // the resources the resource depends on.
+}
+
+// stepTimings collects the timings of the steps performed during an update, from the events the engine emits.
+type stepTimings struct {
+	steps []stepTiming
+}
+
+// record adds the timing of the step described by the given event, if any.  Planned steps are ignored, as they are
+// not actually performed.
+func (t *stepTimings) record(e engine.Event) {
+	var step stepTiming
+	switch e.Type {
+	case engine.ResourceOutputsEvent:
+		p := e.Payload.(engine.ResourceOutputsEventPayload)
+		if p.Planning || p.Duration == 0 {
+			return
+		}
+		step = makeStepTiming(p.Metadata, p.Duration)
+	case engine.ResourceOperationFailed:
+		p := e.Payload.(engine.ResourceOperationFailedPayload)
+		step = makeStepTiming(p.Metadata, p.Duration)
+		step.Failed = true
+	default:
+		return
+	}
+	t.steps = append(t.steps, step)
+}
+
+func makeStepTiming(md engine.StepEventMetadata, duration time.Duration) stepTiming {
+	step := stepTiming{URN: md.URN, Op: md.Op, Duration: duration}
+	if md.Res != nil {
+		step.Dependencies = md.Res.Dependencies
+	}
+	return step
+}
+
+// slowest returns up to n of the slowest steps that changed a resource, slowest first.
+func (t *stepTimings) slowest(n int) []stepTiming {
+	var steps []stepTiming
+	for _, step := range t.steps {
+		if step.Op != deploy.OpSame {
+			steps = append(steps, step)
+		}
+	}
+	sort.SliceStable(steps, func(i, j int) bool { return steps[i].Duration > steps[j].Duration })
+	if len(steps) > n {
+		steps = steps[:n]
+	}
+	return steps
+}
+
+// criticalPath returns the chain of steps, linked by dependencies between their resources, with the greatest total
+// duration, in the order the steps were performed.  This is the minimum time the update could have taken with
+// unlimited parallelism.  Deletions are performed in reverse dependency order, after the rest of the update, so
+// they are left out.
+func (t *stepTimings) criticalPath() []stepTiming {
+	steps := make(map[resource.URN]stepTiming)
+	var urns []resource.URN
+	for _, step := range t.steps {
+		if step.Op == deploy.OpDelete || step.Op == deploy.OpDeleteReplaced {
+			continue
+		}
+		if prior, has := steps[step.URN]; has {
+			// A resource may be the subject of several steps, e.g. a read and then an update; count all of them.
+			step.Duration += prior.Duration
+			if prior.Op != deploy.OpSame {
+				step.Op = prior.Op
+			}
+		} else {
+			urns = append(urns, step.URN)
+		}
+		steps[step.URN] = step
+	}
+
+	// For each resource, find the longest chain of dependencies ending with it.
+	totals := make(map[resource.URN]time.Duration)
+	prev := make(map[resource.URN]resource.URN)
+	visiting := make(map[resource.URN]bool)
+	var longest func(urn resource.URN) time.Duration
+	longest = func(urn resource.URN) time.Duration {
+		if total, has := totals[urn]; has {
+			return total
+		}
+		visiting[urn] = true
+		var best time.Duration
+		for _, dep := range steps[urn].Dependencies {
+			if _, has := steps[dep]; !has || visiting[dep] {
+				continue
+			}
+			if total := longest(dep); total > best || prev[urn] == "" {
+				best, prev[urn] = total, dep
+			}
+		}
+		visiting[urn] = false
+		totals[urn] = best + steps[urn].Duration
+		return totals[urn]
+	}
+
+	var end resource.URN
+	for _, urn := range urns {
+		if end == "" || longest(urn) > longest(end) {
+			end = urn
+		}
+	}
+
+	var path []stepTiming
+	for urn := end; urn != ""; urn = prev[urn] {
+		path = append([]stepTiming{steps[urn]}, path...)
+	}
+	return path
+}
+
+// renderTimingReport renders a report of the slowest steps performed during an update, and of the critical path
+// through them, or returns the empty string if no steps changed any resources.
+func renderTimingReport(timings *stepTimings, opts Options) string {
+	slowest := timings.slowest(slowestStepCount)
+	if len(slowest) == 0 {
+		return ""
+	}
+
+	out := &bytes.Buffer{}
+	fprintIgnoreError(out, opts.Color.Colorize(
+		fmt.Sprintf("%sSlowest operations:%s\n", colors.SpecHeadline, colors.Reset)))
+	for _, step := range slowest {
+		fprintIgnoreError(out, opts.Color.Colorize(renderStepTiming(step)))
+	}
+
+	path := timings.criticalPath()
+	var total time.Duration
+	for _, step := range path {
+		total += step.Duration
+	}
+	fprintIgnoreError(out, "\n")
+	fprintIgnoreError(out, opts.Color.Colorize(
+		fmt.Sprintf("%sCritical path (%s):%s\n", colors.SpecHeadline, formatStepDuration(total), colors.Reset)))
+	for _, step := range path {
+		fprintIgnoreError(out, opts.Color.Colorize(renderStepTiming(step)))
+	}
+	return out.String()
+}
+
+func renderStepTiming(step stepTiming) string {
+	op := string(step.Op)
+	if step.Failed {
+		op = colors.SpecError + op + " (failed)" + colors.Reset
+	}
+	return fmt.Sprintf("    %10s  %s %s (%s)\n",
+		formatStepDuration(step.Duration), op, step.URN.Type(), step.URN.Name())
+}
+
+// formatStepDuration rounds a duration to a precision suitable for display.
+func formatStepDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
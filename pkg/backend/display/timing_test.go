@@ -0,0 +1,78 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+func TestRenderTimingReport(t *testing.T) {
+	urn := func(typ, name string) resource.URN {
+		return resource.URN("urn:pulumi:dev::proj::" + typ + "::" + name)
+	}
+	vpc := urn("aws:ec2/vpc:Vpc", "vpc")
+	subnet := urn("aws:ec2/subnet:Subnet", "subnet")
+	db := urn("aws:rds/instance:Instance", "db")
+	bucket := urn("aws:s3/bucket:Bucket", "bucket")
+	old := urn("aws:s3/bucket:Bucket", "old")
+
+	outputs := func(op deploy.StepOp, u resource.URN, d time.Duration, deps ...resource.URN) engine.Event {
+		return engine.Event{Type: engine.ResourceOutputsEvent, Payload: engine.ResourceOutputsEventPayload{
+			Metadata: engine.StepEventMetadata{Op: op, URN: u,
+				Res: &engine.StepEventStateMetadata{URN: u, Dependencies: deps}},
+			Duration: d,
+		}}
+	}
+
+	timings := &stepTimings{}
+	for _, e := range []engine.Event{
+		outputs(deploy.OpSame, vpc, 10*time.Millisecond),
+		outputs(deploy.OpCreate, subnet, 20*time.Second, vpc),
+		outputs(deploy.OpCreate, bucket, 30*time.Second),
+		outputs(deploy.OpCreate, bucket, 0), // component outputs are reported without a duration.
+		outputs(deploy.OpUpdate, db, 15*time.Second, subnet),
+		outputs(deploy.OpDelete, old, 45*time.Second),
+		{Type: engine.ResourceOutputsEvent, Payload: engine.ResourceOutputsEventPayload{
+			Metadata: engine.StepEventMetadata{Op: deploy.OpCreate, URN: old}, Planning: true, Duration: time.Hour,
+		}},
+	} {
+		timings.record(e)
+	}
+
+	expected := "Slowest operations:\n" +
+		"           45s  delete aws:s3/bucket:Bucket (old)\n" +
+		"           30s  create aws:s3/bucket:Bucket (bucket)\n" +
+		"           20s  create aws:ec2/subnet:Subnet (subnet)\n" +
+		"           15s  update aws:rds/instance:Instance (db)\n" +
+		"\n" +
+		"Critical path (35s):\n" +
+		"          10ms  same aws:ec2/vpc:Vpc (vpc)\n" +
+		"           20s  create aws:ec2/subnet:Subnet (subnet)\n" +
+		"           15s  update aws:rds/instance:Instance (db)\n"
+	assert.Equal(t, expected, renderTimingReport(timings, Options{Color: colors.Never}))
+
+	// Nothing is reported for an update that changed nothing.
+	unchanged := &stepTimings{}
+	unchanged.record(outputs(deploy.OpSame, vpc, time.Second))
+	assert.Equal(t, "", renderTimingReport(unchanged, Options{Color: colors.Never}))
+}
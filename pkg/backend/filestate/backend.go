@@ -37,6 +37,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/operations"
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/resource/stack"
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
@@ -156,8 +157,11 @@ func (b *localBackend) CreateStack(ctx context.Context, stackRef backend.StackRe
 	if err != nil {
 		return nil, err
 	}
+	if err = b.saveStackMetadata(stackName, &stackMetadata{Tags: tags}); err != nil {
+		return nil, err
+	}
 
-	stack := newStack(stackRef, file, nil, nil, b)
+	stack := newStack(stackRef, file, nil, nil, tags, b)
 	fmt.Printf("Created stack '%s'\n", stack.Ref())
 
 	return stack, nil
@@ -171,9 +175,13 @@ func (b *localBackend) GetStack(ctx context.Context, stackRef backend.StackRefer
 		return nil, nil
 	case err != nil:
 		return nil, err
-	default:
-		return newStack(stackRef, path, config, snapshot, b), nil
 	}
+
+	meta, err := b.getStackMetadata(stackName)
+	if err != nil {
+		return nil, err
+	}
+	return newStack(stackRef, path, config, snapshot, meta.Tags, b), nil
 }
 
 func (b *localBackend) ListStacks(
@@ -191,7 +199,11 @@ func (b *localBackend) ListStacks(
 		}
 		localStack, ok := stack.(*localStack)
 		contract.Assertf(ok, "localBackend GetStack returned non-localStack")
-		results = append(results, newLocalStackSummary(localStack))
+		lastUpdate, err := b.getLatestUpdate(stackName)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, newLocalStackSummary(localStack, lastUpdate))
 	}
 
 	return results, nil
@@ -212,6 +224,92 @@ func (b *localBackend) RemoveStack(ctx context.Context, stackRef backend.StackRe
 	return false, b.removeStack(stackName)
 }
 
+func (b *localBackend) RenameStack(ctx context.Context, stackRef backend.StackReference,
+	newName tokens.QName) (backend.StackReference, error) {
+
+	stackName := stackRef.Name()
+	newRef := localBackendReference{name: newName}
+	if newName == stackName {
+		return newRef, nil
+	}
+	if err := backend.ValidateStackProperties(string(newName), nil); err != nil {
+		return nil, errors.Wrap(err, "validating stack properties")
+	}
+	if _, err := os.Stat(b.stackPath(newName)); err == nil {
+		return nil, &backend.StackAlreadyExistsError{StackName: string(newName)}
+	}
+
+	config, snapshot, _, err := b.getStack(stackName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The stack name is embedded in every URN, so rewrite them all before saving the stack under its new name.
+	renamed, err := snapshot.RenameStack(newName)
+	if err != nil {
+		return nil, errors.Wrap(err, "renaming resources")
+	}
+	if _, err = b.saveStack(newName, config, renamed); err != nil {
+		return nil, err
+	}
+
+	// Carry the stack's update history and backups along with it.  The files in those directories are named after the
+	// stack, and history is ordered by file name, so rename them as well.
+	if err = renamePath(b.historyDirectory(stackName), b.historyDirectory(newName)); err != nil {
+		return nil, errors.Wrap(err, "moving update history")
+	}
+	if err = renameStackFiles(b.historyDirectory(newName), historyFilePrefix(stackName),
+		historyFilePrefix(newName)); err != nil {
+		return nil, errors.Wrap(err, "renaming update history")
+	}
+	if err = renamePath(b.backupDirectory(stackName), b.backupDirectory(newName)); err != nil {
+		return nil, errors.Wrap(err, "moving backups")
+	}
+	if err = renameStackFiles(b.backupDirectory(newName), backupFilePrefix(b.stackPath(stackName)),
+		backupFilePrefix(b.stackPath(newName))); err != nil {
+		return nil, errors.Wrap(err, "renaming backups")
+	}
+	if err = renamePath(b.metadataPath(stackName), b.metadataPath(newName)); err != nil {
+		return nil, errors.Wrap(err, "moving metadata")
+	}
+
+	return newRef, b.removeStack(stackName)
+}
+
+func (b *localBackend) UpdateStackTags(ctx context.Context, stackRef backend.StackReference,
+	tags map[apitype.StackTagName]string) error {
+
+	stackName := stackRef.Name()
+	if err := backend.ValidateStackProperties(string(stackName), tags); err != nil {
+		return errors.Wrap(err, "validating stack properties")
+	}
+	if _, _, _, err := b.getStack(stackName); err != nil {
+		return err
+	}
+
+	meta, err := b.getStackMetadata(stackName)
+	if err != nil {
+		return err
+	}
+	meta.Tags = tags
+	return b.saveStackMetadata(stackName, meta)
+}
+
+// GetStackLockStatus always reports that the stack is unlocked, because the local backend does not lock stacks during
+// updates.
+func (b *localBackend) GetStackLockStatus(ctx context.Context,
+	stackRef backend.StackReference) (apitype.StackLockStatus, error) {
+
+	if _, _, _, err := b.getStack(stackRef.Name()); err != nil {
+		return apitype.StackLockStatus{}, err
+	}
+	return apitype.StackLockStatus{Locked: false}, nil
+}
+
+func (b *localBackend) ForceUnlockStack(ctx context.Context, stackRef backend.StackReference) error {
+	return errors.New("the local backend does not lock stacks")
+}
+
 func (b *localBackend) GetStackCrypter(stackRef backend.StackReference) (config.Crypter, error) {
 	return symmetricCrypter(stackRef.Name())
 }
@@ -279,10 +377,13 @@ func (b *localBackend) apply(ctx context.Context, kind apitype.UpdateKind, stack
 	stackRef := stack.Ref()
 	stackName := stackRef.Name()
 
-	// Print a banner so it's clear this is a local deployment.
+	// Print a banner so it's clear this is a local deployment.  If events are being emitted as JSON, we must not
+	// write anything else to stdout.
 	actionLabel := backend.ActionLabel(kind, opts.DryRun)
-	fmt.Printf(op.Opts.Display.Color.Colorize(
-		colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stackRef)
+	if !op.Opts.Display.JSONDisplay {
+		fmt.Printf(op.Opts.Display.Color.Colorize(
+			colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stackRef)
+	}
 
 	// Start the update.
 	update, err := b.newUpdate(stackName, op.Proj, op.Root)
@@ -390,7 +491,7 @@ func (b *localBackend) apply(ctx context.Context, kind apitype.UpdateKind, stack
 	}
 
 	// Make sure to print a link to the stack's checkpoint before exiting.
-	if opts.ShowLink {
+	if opts.ShowLink && !op.Opts.Display.JSONDisplay {
 		fmt.Printf(
 			op.Opts.Display.Color.Colorize(
 				colors.SpecHeadline+"Permalink: "+
@@ -418,11 +519,26 @@ func (b *localBackend) GetLogs(ctx context.Context, stackRef backend.StackRefere
 		return nil, err
 	}
 
-	return GetLogsForTarget(target, query)
+	return GetLogsForTarget(ctx, b.d, target, query)
+}
+
+func (b *localBackend) GetMetrics(ctx context.Context, stackRef backend.StackReference,
+	query operations.MetricsQuery) ([]operations.MetricSeries, error) {
+
+	stackName := stackRef.Name()
+	target, err := b.getTarget(stackName)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetMetricsForTarget(target, query)
 }
 
-// GetLogsForTarget fetches stack logs using the config, decrypter, and checkpoint in the given target.
-func GetLogsForTarget(target *deploy.Target, query operations.LogQuery) ([]operations.LogEntry, error) {
+// GetLogsForTarget fetches stack logs using the config, decrypter, and checkpoint in the given target.  Logs for
+// resources without built-in support are requested from their provider plugins.  If the context carries provider
+// plugins, those are used; otherwise, plugins are loaded for this query alone, and report any diagnostics to d.
+func GetLogsForTarget(ctx context.Context, d diag.Sink, target *deploy.Target,
+	query operations.LogQuery) ([]operations.LogEntry, error) {
 	contract.Assert(target != nil)
 	contract.Assert(target.Snapshot != nil)
 
@@ -431,8 +547,18 @@ func GetLogsForTarget(target *deploy.Target, query operations.LogQuery) ([]opera
 		return nil, err
 	}
 
+	plugins := operations.ProviderPluginsFromContext(ctx)
+	if plugins == nil {
+		plugctx, plugErr := plugin.NewContext(d, d, nil, nil, nil, "", nil, nil)
+		if plugErr != nil {
+			return nil, plugErr
+		}
+		defer contract.IgnoreClose(plugctx)
+		plugins = operations.NewProviderPlugins(plugctx.Host)
+	}
+
 	components := operations.NewResourceTree(target.Snapshot.Resources)
-	ops := components.OperationsProvider(config)
+	ops := components.OperationsProviderWithPlugins(config, plugins)
 	logs, err := ops.GetLogs(query)
 	if logs == nil {
 		return nil, err
@@ -440,6 +566,26 @@ func GetLogsForTarget(target *deploy.Target, query operations.LogQuery) ([]opera
 	return *logs, err
 }
 
+// GetMetricsForTarget fetches metric time series for the resources in the given target, using its config, decrypter,
+// and checkpoint.  Only resources with built-in operations providers report metrics, so no provider plugins are loaded.
+func GetMetricsForTarget(target *deploy.Target, query operations.MetricsQuery) ([]operations.MetricSeries, error) {
+	contract.Assert(target != nil)
+	contract.Assert(target.Snapshot != nil)
+
+	config, err := target.Config.Decrypt(target.Decrypter)
+	if err != nil {
+		return nil, err
+	}
+
+	components := operations.NewResourceTree(target.Snapshot.Resources)
+	ops := components.OperationsProvider(config)
+	series, err := ops.GetMetrics(query)
+	if series == nil {
+		return nil, err
+	}
+	return *series, err
+}
+
 func (b *localBackend) ExportDeployment(ctx context.Context,
 	stackRef backend.StackReference) (*apitype.UntypedDeployment, error) {
 
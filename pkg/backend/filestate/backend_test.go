@@ -0,0 +1,133 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// newTestBackend returns a local backend whose state lives in a fresh temporary directory.
+func newTestBackend(t *testing.T) (*localBackend, func()) {
+	dir, err := ioutil.TempDir("", "filestate-test")
+	assert.NoError(t, err)
+	return &localBackend{url: localBackendURLPrefix + dir}, func() { contract.IgnoreError(os.RemoveAll(dir)) }
+}
+
+func TestStackMetadata(t *testing.T) {
+	b, cleanup := newTestBackend(t)
+	defer cleanup()
+
+	// A stack without any recorded metadata has no tags.
+	meta, err := b.getStackMetadata("dev")
+	assert.NoError(t, err)
+	assert.Nil(t, meta.Tags)
+
+	tags := map[apitype.StackTagName]string{"owner": "alice", apitype.ProjectNameTag: "app"}
+	assert.NoError(t, b.saveStackMetadata("dev", &stackMetadata{Tags: tags}))
+	meta, err = b.getStackMetadata("dev")
+	assert.NoError(t, err)
+	assert.Equal(t, tags, meta.Tags)
+
+	assert.NoError(t, b.removeStackMetadata("dev"))
+	_, err = os.Stat(b.metadataPath("dev"))
+	assert.True(t, os.IsNotExist(err))
+
+	// Removing metadata that isn't there is not an error.
+	assert.NoError(t, b.removeStackMetadata("dev"))
+}
+
+func TestStackTagsFollowStack(t *testing.T) {
+	b, cleanup := newTestBackend(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	_, err := b.saveStack("dev", nil, nil)
+	assert.NoError(t, err)
+
+	tags := map[apitype.StackTagName]string{"owner": "alice"}
+	assert.NoError(t, b.UpdateStackTags(ctx, localBackendReference{name: "dev"}, tags))
+	s, err := b.GetStack(ctx, localBackendReference{name: "dev"})
+	assert.NoError(t, err)
+	assert.Equal(t, tags, s.(*localStack).Tags())
+
+	// Tags can't be set on a stack that doesn't exist.
+	assert.Error(t, b.UpdateStackTags(ctx, localBackendReference{name: "missing"}, tags))
+
+	// Renaming a stack carries its tags along with it.
+	_, err = b.RenameStack(ctx, localBackendReference{name: "dev"}, tokens.QName("staging"))
+	assert.NoError(t, err)
+	_, err = os.Stat(b.metadataPath("dev"))
+	assert.True(t, os.IsNotExist(err))
+	s, err = b.GetStack(ctx, localBackendReference{name: "staging"})
+	assert.NoError(t, err)
+	assert.Equal(t, tags, s.(*localStack).Tags())
+
+	// Removing a stack removes its tags, so a new stack by the same name starts without any.
+	_, err = b.RemoveStack(ctx, localBackendReference{name: "staging"}, false)
+	assert.NoError(t, err)
+	_, err = os.Stat(b.metadataPath("staging"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = b.saveStack("staging", nil, nil)
+	assert.NoError(t, err)
+	s, err = b.GetStack(ctx, localBackendReference{name: "staging"})
+	assert.NoError(t, err)
+	assert.Empty(t, s.(*localStack).Tags())
+}
+
+func TestLatestUpdate(t *testing.T) {
+	b, cleanup := newTestBackend(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	_, err := b.saveStack("dev", nil, nil)
+	assert.NoError(t, err)
+
+	// A stack that has never been updated has no latest update.
+	update, err := b.getLatestUpdate("dev")
+	assert.NoError(t, err)
+	assert.Nil(t, update)
+
+	// The latest update is the most recent history file, regardless of the checkpoint copies stored beside it.
+	failed := backend.UpdateInfo{Kind: apitype.PreviewUpdate, Result: backend.FailedResult}
+	assert.NoError(t, b.addToHistory("dev", failed))
+	succeeded := backend.UpdateInfo{Kind: apitype.UpdateUpdate, Result: backend.SucceededResult}
+	assert.NoError(t, b.addToHistory("dev", succeeded))
+	update, err = b.getLatestUpdate("dev")
+	assert.NoError(t, err)
+	assert.Equal(t, apitype.UpdateUpdate, update.Kind)
+	assert.Equal(t, backend.SucceededResult, update.Result)
+
+	summaries, err := b.ListStacks(ctx, nil)
+	assert.NoError(t, err)
+	assert.Len(t, summaries, 1)
+	assert.Equal(t, apitype.UpdateUpdate, *summaries[0].LastUpdateKind())
+	assert.Equal(t, apitype.SucceededResult, *summaries[0].LastUpdateResult())
+
+	// The local backend never reports an update as in progress, consistent with its lock status.
+	assert.False(t, summaries[0].UpdateInProgress())
+	status, err := b.GetStackLockStatus(ctx, localBackendReference{name: "dev"})
+	assert.NoError(t, err)
+	assert.False(t, status.Locked)
+}
@@ -0,0 +1,81 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestate
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/fsutil"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// stackMetadata is the information the local backend records about a stack alongside its checkpoint.  Unlike the
+// checkpoint, it is not rewritten by updates.
+type stackMetadata struct {
+	// Tags are the stack's tags.
+	Tags map[apitype.StackTagName]string `json:"tags,omitempty"`
+}
+
+func (b *localBackend) metadataPath(stack tokens.QName) string {
+	contract.Require(stack != "", "stack")
+	return filepath.Join(b.StateDir(), workspace.MetadataDir, fsutil.QnamePath(stack)+".json")
+}
+
+// getStackMetadata loads the metadata for the given stack.  A stack without any recorded metadata yields an empty
+// metadata object rather than an error.
+func (b *localBackend) getStackMetadata(name tokens.QName) (*stackMetadata, error) {
+	byts, err := ioutil.ReadFile(b.metadataPath(name))
+	if os.IsNotExist(err) {
+		return &stackMetadata{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var meta stackMetadata
+	if err = json.Unmarshal(byts, &meta); err != nil {
+		return nil, errors.Wrapf(err, "reading metadata for stack %s", name)
+	}
+	return &meta, nil
+}
+
+// saveStackMetadata writes out the metadata for the given stack, replacing any that was already there.
+func (b *localBackend) saveStackMetadata(name tokens.QName, meta *stackMetadata) error {
+	byts, err := json.MarshalIndent(meta, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	file := b.metadataPath(name)
+	if err = os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, byts, 0600)
+}
+
+// removeStackMetadata deletes the metadata for the given stack, if any.
+func (b *localBackend) removeStackMetadata(name tokens.QName) error {
+	if err := os.Remove(b.metadataPath(name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
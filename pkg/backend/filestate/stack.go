@@ -24,6 +24,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/operations"
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
 )
 
 // Stack is a local stack.  This simply adds some local-specific properties atop the standard backend stack interface.
@@ -34,20 +35,22 @@ type Stack interface {
 
 // localStack is a local stack descriptor.
 type localStack struct {
-	ref      backend.StackReference // the stack's reference (qualified name).
-	path     string                 // a path to the stack's checkpoint file on disk.
-	config   config.Map             // the stack's config bag.
-	snapshot *deploy.Snapshot       // a snapshot representing the latest deployment state.
-	b        *localBackend          // a pointer to the backend this stack belongs to.
+	ref      backend.StackReference          // the stack's reference (qualified name).
+	path     string                          // a path to the stack's checkpoint file on disk.
+	config   config.Map                      // the stack's config bag.
+	snapshot *deploy.Snapshot                // a snapshot representing the latest deployment state.
+	tags     map[apitype.StackTagName]string // the stack's tags.
+	b        *localBackend                   // a pointer to the backend this stack belongs to.
 }
 
 func newStack(ref backend.StackReference, path string, config config.Map,
-	snapshot *deploy.Snapshot, b *localBackend) Stack {
+	snapshot *deploy.Snapshot, tags map[apitype.StackTagName]string, b *localBackend) Stack {
 	return &localStack{
 		ref:      ref,
 		path:     path,
 		config:   config,
 		snapshot: snapshot,
+		tags:     tags,
 		b:        b,
 	}
 }
@@ -57,11 +60,16 @@ func (s *localStack) Config() config.Map                                     { r
 func (s *localStack) Snapshot(ctx context.Context) (*deploy.Snapshot, error) { return s.snapshot, nil }
 func (s *localStack) Backend() backend.Backend                               { return s.b }
 func (s *localStack) Path() string                                           { return s.path }
+func (s *localStack) Tags() map[apitype.StackTagName]string                  { return s.tags }
 
 func (s *localStack) Remove(ctx context.Context, force bool) (bool, error) {
 	return backend.RemoveStack(ctx, s, force)
 }
 
+func (s *localStack) Rename(ctx context.Context, newName tokens.QName) (backend.StackReference, error) {
+	return backend.RenameStack(ctx, s, newName)
+}
+
 func (s *localStack) Preview(ctx context.Context, op backend.UpdateOperation) (engine.ResourceChanges, error) {
 	return backend.PreviewStack(ctx, s, op)
 }
@@ -82,6 +90,10 @@ func (s *localStack) GetLogs(ctx context.Context, query operations.LogQuery) ([]
 	return backend.GetStackLogs(ctx, s, query)
 }
 
+func (s *localStack) GetMetrics(ctx context.Context, query operations.MetricsQuery) ([]operations.MetricSeries, error) {
+	return backend.GetStackMetrics(ctx, s, query)
+}
+
 func (s *localStack) ExportDeployment(ctx context.Context) (*apitype.UntypedDeployment, error) {
 	return backend.ExportStackDeployment(ctx, s)
 }
@@ -91,11 +103,12 @@ func (s *localStack) ImportDeployment(ctx context.Context, deployment *apitype.U
 }
 
 type localStackSummary struct {
-	s *localStack
+	s          *localStack
+	lastUpdate *backend.UpdateInfo
 }
 
-func newLocalStackSummary(s *localStack) localStackSummary {
-	return localStackSummary{s}
+func newLocalStackSummary(s *localStack, lastUpdate *backend.UpdateInfo) localStackSummary {
+	return localStackSummary{s: s, lastUpdate: lastUpdate}
 }
 
 func (lss localStackSummary) Name() backend.StackReference {
@@ -112,6 +125,29 @@ func (lss localStackSummary) LastUpdate() *time.Time {
 	return nil
 }
 
+func (lss localStackSummary) LastUpdateKind() *apitype.UpdateKind {
+	if lss.lastUpdate == nil {
+		return nil
+	}
+	kind := lss.lastUpdate.Kind
+	return &kind
+}
+
+func (lss localStackSummary) LastUpdateResult() *apitype.UpdateResult {
+	if lss.lastUpdate == nil {
+		return nil
+	}
+	result := apitype.UpdateResult(lss.lastUpdate.Result)
+	return &result
+}
+
+// UpdateInProgress always returns false.  Like GetStackLockStatus, it reflects that the local backend neither locks
+// stacks nor records which updates are running; pending operations in a checkpoint may just as well be left over from
+// an update that was interrupted.
+func (lss localStackSummary) UpdateInProgress() bool {
+	return false
+}
+
 func (lss localStackSummary) ResourceCount() *int {
 	snap := lss.s.snapshot
 	if snap != nil {
@@ -120,3 +156,7 @@ func (lss localStackSummary) ResourceCount() *int {
 	}
 	return nil
 }
+
+func (lss localStackSummary) Tags() map[apitype.StackTagName]string {
+	return lss.s.tags
+}
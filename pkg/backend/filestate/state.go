@@ -207,10 +207,58 @@ func (b *localBackend) removeStack(name tokens.QName) error {
 	file := b.stackPath(name)
 	backupTarget(file)
 
+	if err := b.removeStackMetadata(name); err != nil {
+		return err
+	}
+
 	historyDir := b.historyDirectory(name)
 	return os.RemoveAll(historyDir)
 }
 
+// renamePath moves the file or directory at oldPath to newPath, if it exists.
+func renamePath(oldPath, newPath string) error {
+	if _, err := os.Stat(oldPath); os.IsNotExist(err) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(newPath), 0700); err != nil {
+		return err
+	}
+	return os.Rename(oldPath, newPath)
+}
+
+// renameStackFiles renames every file in dir whose name starts with oldPrefix so that it starts with newPrefix
+// instead.  It is used to keep history and backup file names in sync with the name of a renamed stack.
+func renameStackFiles(dir, oldPrefix, newPrefix string) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasPrefix(file.Name(), oldPrefix) {
+			continue
+		}
+		newFile := newPrefix + strings.TrimPrefix(file.Name(), oldPrefix)
+		if err = os.Rename(filepath.Join(dir, file.Name()), filepath.Join(dir, newFile)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// historyFilePrefix returns the prefix shared by the names of a stack's history files.
+func historyFilePrefix(name tokens.QName) string {
+	return fmt.Sprintf("%s-", name)
+}
+
+// backupFilePrefix returns the prefix shared by the names of the backups of the given stack file.
+func backupFilePrefix(stackPath string) string {
+	stackFile := filepath.Base(stackPath)
+	return strings.TrimSuffix(stackFile, filepath.Ext(stackFile)) + "."
+}
+
 // backupTarget makes a backup of an existing file, in preparation for writing a new one.  Instead of a copy, it
 // simply renames the file, which is simpler, more efficient, etc.
 func backupTarget(file string) string {
@@ -247,10 +295,7 @@ func (b *localBackend) backupStack(name tokens.QName) error {
 	}
 
 	// Write out the new backup checkpoint file.
-	stackFile := filepath.Base(stackPath)
-	ext := filepath.Ext(stackFile)
-	base := strings.TrimSuffix(stackFile, ext)
-	backupFile := fmt.Sprintf("%s.%v%s", base, time.Now().UnixNano(), ext)
+	backupFile := fmt.Sprintf("%s%v%s", backupFilePrefix(stackPath), time.Now().UnixNano(), filepath.Ext(stackPath))
 	return ioutil.WriteFile(filepath.Join(backupDir, backupFile), byts, 0600)
 }
 
@@ -301,20 +346,49 @@ func (b *localBackend) getHistory(name tokens.QName) ([]backend.UpdateInfo, erro
 			continue
 		}
 
-		var update backend.UpdateInfo
-		b, err := ioutil.ReadFile(filepath)
+		update, err := readHistoryFile(filepath)
 		if err != nil {
-			return nil, errors.Wrapf(err, "reading history file %s", filepath)
+			return nil, err
 		}
-		err = json.Unmarshal(b, &update)
-		if err != nil {
-			return nil, errors.Wrapf(err, "reading history file %s", filepath)
+		updates = append(updates, *update)
+	}
+
+	return updates, nil
+}
+
+// getLatestUpdate returns the most recent locally stored update record, or nil if the stack has never been updated.
+// Unlike getHistory, it only reads a single history file.
+func (b *localBackend) getLatestUpdate(name tokens.QName) (*backend.UpdateInfo, error) {
+	contract.Require(name != "", "name")
+
+	dir := b.historyDirectory(name)
+	allFiles, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
 		}
+		return nil, err
+	}
 
-		updates = append(updates, update)
+	for i := len(allFiles) - 1; i >= 0; i-- {
+		if filepath := path.Join(dir, allFiles[i].Name()); strings.HasSuffix(filepath, ".history.json") {
+			return readHistoryFile(filepath)
+		}
 	}
+	return nil, nil
+}
 
-	return updates, nil
+// readHistoryFile reads a single update record from the given history file.
+func readHistoryFile(filepath string) (*backend.UpdateInfo, error) {
+	var update backend.UpdateInfo
+	b, err := ioutil.ReadFile(filepath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading history file %s", filepath)
+	}
+	if err = json.Unmarshal(b, &update); err != nil {
+		return nil, errors.Wrapf(err, "reading history file %s", filepath)
+	}
+	return &update, nil
 }
 
 // addToHistory saves the UpdateInfo and makes a copy of the current Checkpoint file.
@@ -327,7 +401,7 @@ func (b *localBackend) addToHistory(name tokens.QName, update backend.UpdateInfo
 	}
 
 	// Prefix for the update and checkpoint files.
-	pathPrefix := path.Join(dir, fmt.Sprintf("%s-%d", name, time.Now().UnixNano()))
+	pathPrefix := path.Join(dir, fmt.Sprintf("%s%d", historyFilePrefix(name), time.Now().UnixNano()))
 
 	// Save the history file.
 	byts, err := json.MarshalIndent(&update, "", "    ")
@@ -567,6 +567,60 @@ func (b *cloudBackend) RemoveStack(ctx context.Context, stackRef backend.StackRe
 	return b.client.DeleteStack(ctx, stack, force)
 }
 
+func (b *cloudBackend) UpdateStackTags(ctx context.Context, stackRef backend.StackReference,
+	tags map[apitype.StackTagName]string) error {
+
+	stack, err := b.getCloudStackIdentifier(stackRef)
+	if err != nil {
+		return err
+	}
+
+	return b.client.UpdateStackTags(ctx, stack, tags)
+}
+
+func (b *cloudBackend) GetStackLockStatus(ctx context.Context,
+	stackRef backend.StackReference) (apitype.StackLockStatus, error) {
+
+	stack, err := b.getCloudStackIdentifier(stackRef)
+	if err != nil {
+		return apitype.StackLockStatus{}, err
+	}
+
+	return b.client.GetStackLockStatus(ctx, stack)
+}
+
+func (b *cloudBackend) ForceUnlockStack(ctx context.Context, stackRef backend.StackReference) error {
+	stack, err := b.getCloudStackIdentifier(stackRef)
+	if err != nil {
+		return err
+	}
+
+	return b.client.ForceUnlockStack(ctx, stack)
+}
+
+func (b *cloudBackend) RenameStack(ctx context.Context, stackRef backend.StackReference,
+	newName tokens.QName) (backend.StackReference, error) {
+
+	stack, err := b.getCloudStackIdentifier(stackRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = b.client.RenameStack(ctx, stack, string(newName)); err != nil {
+		// If the status is 409 Conflict (stack already exists), return StackAlreadyExistsError.
+		if errResp, ok := err.(*apitype.ErrorResponse); ok && errResp.Code == http.StatusConflict {
+			return nil, &backend.StackAlreadyExistsError{StackName: string(newName)}
+		}
+		return nil, err
+	}
+
+	return cloudBackendReference{
+		owner: stack.Owner,
+		name:  newName,
+		b:     b,
+	}, nil
+}
+
 // cloudCrypter is an encrypter/decrypter that uses the Pulumi cloud to encrypt/decrypt a stack's secrets.
 type cloudCrypter struct {
 	backend *cloudBackend
@@ -687,11 +741,23 @@ func (b *cloudBackend) createAndStartUpdate(
 	}
 
 	// Start the update. We use this opportunity to pass new tags to the service, to pick up any
-	// metadata changes.
-	tags, err := backend.GetStackTags()
+	// metadata changes. Since the service replaces the stack's tags wholesale, we start from the stack's
+	// existing tags so that any user-defined tags are preserved.
+	apistack, err := b.client.GetStack(ctx, stack)
+	if err != nil {
+		return client.UpdateIdentifier{}, 0, "", err
+	}
+	projectTags, err := backend.GetStackTags()
 	if err != nil {
 		return client.UpdateIdentifier{}, 0, "", errors.Wrap(err, "getting stack tags")
 	}
+	tags := make(map[apitype.StackTagName]string)
+	for k, v := range apistack.Tags {
+		tags[k] = v
+	}
+	for k, v := range projectTags {
+		tags[k] = v
+	}
 	version, token, err := b.client.StartUpdate(ctx, update, tags)
 	if err != nil {
 		return client.UpdateIdentifier{}, 0, "", err
@@ -707,10 +773,13 @@ func (b *cloudBackend) createAndStartUpdate(
 // apply actually performs the provided type of update on a stack hosted in the Pulumi Cloud.
 func (b *cloudBackend) apply(ctx context.Context, kind apitype.UpdateKind, stack backend.Stack,
 	op backend.UpdateOperation, opts backend.ApplierOptions, events chan<- engine.Event) (engine.ResourceChanges, error) {
-	// Print a banner so it's clear this is going to the cloud.
+	// Print a banner so it's clear this is going to the cloud.  If events are being emitted as JSON, we must not
+	// write anything else to stdout.
 	actionLabel := backend.ActionLabel(kind, opts.DryRun)
-	fmt.Printf(op.Opts.Display.Color.Colorize(
-		colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stack.Ref())
+	if !op.Opts.Display.JSONDisplay {
+		fmt.Printf(op.Opts.Display.Color.Colorize(
+			colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stack.Ref())
+	}
 
 	// Create an update object to persist results.
 	update, version, token, err := b.createAndStartUpdate(ctx, kind, stack.Ref(), op, opts.DryRun)
@@ -718,7 +787,7 @@ func (b *cloudBackend) apply(ctx context.Context, kind apitype.UpdateKind, stack
 		return nil, err
 	}
 
-	if opts.ShowLink {
+	if opts.ShowLink && !op.Opts.Display.JSONDisplay {
 		// Print a URL at the end of the update pointing to the Pulumi Service.
 		var link string
 		base := b.cloudConsoleStackPath(update.StackIdentifier)
@@ -957,7 +1026,25 @@ func (b *cloudBackend) GetLogs(ctx context.Context, stackRef backend.StackRefere
 	if targetErr != nil {
 		return nil, targetErr
 	}
-	return filestate.GetLogsForTarget(target, logQuery)
+	return filestate.GetLogsForTarget(ctx, b.d, target, logQuery)
+}
+
+func (b *cloudBackend) GetMetrics(ctx context.Context, stackRef backend.StackReference,
+	query operations.MetricsQuery) ([]operations.MetricSeries, error) {
+
+	stack, err := b.GetStack(ctx, stackRef)
+	if err != nil {
+		return nil, err
+	}
+	if stack == nil {
+		return nil, errors.New("stack not found")
+	}
+
+	target, err := b.getTarget(ctx, stackRef)
+	if err != nil {
+		return nil, err
+	}
+	return filestate.GetMetricsForTarget(target, query)
 }
 
 func (b *cloudBackend) ExportDeployment(ctx context.Context,
@@ -85,6 +85,10 @@ func init() {
 	addEndpoint("GET", "/api/stacks/{orgName}/{stackName}/export", "exportStack")
 	addEndpoint("POST", "/api/stacks/{orgName}/{stackName}/import", "importStack")
 	addEndpoint("POST", "/api/stacks/{orgName}/{stackName}/encrypt", "encryptValue")
+	addEndpoint("POST", "/api/stacks/{orgName}/{stackName}/rename", "renameStack")
+	addEndpoint("PATCH", "/api/stacks/{orgName}/{stackName}/tags", "updateStackTags")
+	addEndpoint("GET", "/api/stacks/{orgName}/{stackName}/lock", "getStackLockStatus")
+	addEndpoint("DELETE", "/api/stacks/{orgName}/{stackName}/lock", "forceUnlockStack")
 	addEndpoint("POST", "/api/stacks/{orgName}/{stackName}/decrypt", "decryptValue")
 	addEndpoint("GET", "/api/stacks/{orgName}/{stackName}/logs", "getStackLogs")
 	addEndpoint("GET", "/api/stacks/{orgName}/{stackName}/updates", "getStackUpdates")
@@ -255,6 +255,46 @@ func (pc *Client) DeleteStack(ctx context.Context, stack StackIdentifier, force
 	return false, pc.restCall(ctx, "DELETE", path, nil, nil, nil)
 }
 
+// UpdateStackTags replaces the tags of the indicated stack with the given set.
+func (pc *Client) UpdateStackTags(
+	ctx context.Context, stack StackIdentifier, tags map[apitype.StackTagName]string) error {
+	// Validate names and tags.
+	if err := backend.ValidateStackProperties(stack.Stack, tags); err != nil {
+		return errors.Wrap(err, "validating stack properties")
+	}
+
+	req := apitype.UpdateStackTagsRequest{Tags: tags}
+	return pc.restCall(ctx, "PATCH", getStackPath(stack, "tags"), nil, &req, nil)
+}
+
+// RenameStack renames the indicated stack. The service rewrites the URNs of all of the stack's resources.
+func (pc *Client) RenameStack(ctx context.Context, stack StackIdentifier, newName string) error {
+	// Validate the new name.
+	if err := backend.ValidateStackProperties(newName, nil); err != nil {
+		return errors.Wrap(err, "validating stack properties")
+	}
+
+	req := apitype.StackRenameRequest{NewName: newName}
+	return pc.restCall(ctx, "POST", getStackPath(stack, "rename"), nil, &req, nil)
+}
+
+// GetStackLockStatus returns information about the update, if any, that currently holds the indicated stack's lock.
+func (pc *Client) GetStackLockStatus(ctx context.Context, stack StackIdentifier) (apitype.StackLockStatus, error) {
+	var status apitype.StackLockStatus
+	if err := pc.restCall(ctx, "GET", getStackPath(stack, "lock"), nil, nil, &status); err != nil {
+		return apitype.StackLockStatus{}, err
+	}
+	return status, nil
+}
+
+// ForceUnlockStack breaks the indicated stack's lock, regardless of which update holds it.  The update holding the lock
+// will fail the next time it attempts to renew its lease.
+func (pc *Client) ForceUnlockStack(ctx context.Context, stack StackIdentifier) error {
+	// It is safe to retry this DELETE operation, because it is logically idempotent.
+	return pc.restCallWithOptions(ctx, "DELETE", getStackPath(stack, "lock"), nil, nil, nil,
+		httpCallOptions{RetryAllMethods: true})
+}
+
 // EncryptValue encrypts a plaintext value in the context of the indicated stack.
 func (pc *Client) EncryptValue(ctx context.Context, stack StackIdentifier, plaintext []byte) ([]byte, error) {
 	req := apitype.EncryptValueRequest{Plaintext: plaintext}
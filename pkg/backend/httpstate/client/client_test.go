@@ -0,0 +1,148 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+)
+
+func newTestServerAndClient(handler http.HandlerFunc) (*httptest.Server, *Client) {
+	server := httptest.NewServer(handler)
+	sink := diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{Color: colors.Never})
+	return server, NewClient(server.URL, "test-token", sink)
+}
+
+func TestRenameStack(t *testing.T) {
+	var req apitype.StackRenameRequest
+	server, client := newTestServerAndClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/stacks/owner/old-stack/rename", r.URL.Path)
+		assert.Equal(t, "token test-token", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer server.Close()
+
+	stack := StackIdentifier{Owner: "owner", Stack: "old-stack"}
+	err := client.RenameStack(context.Background(), stack, "new-stack")
+	assert.NoError(t, err)
+	assert.Equal(t, "new-stack", req.NewName)
+}
+
+func TestRenameStackConflict(t *testing.T) {
+	server, client := newTestServerAndClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_, err := w.Write([]byte(`{"code":409,"message":"stack already exists"}`))
+		assert.NoError(t, err)
+	})
+	defer server.Close()
+
+	stack := StackIdentifier{Owner: "owner", Stack: "old-stack"}
+	err := client.RenameStack(context.Background(), stack, "new-stack")
+	errResp, ok := err.(*apitype.ErrorResponse)
+	if assert.True(t, ok) {
+		assert.Equal(t, http.StatusConflict, errResp.Code)
+	}
+}
+
+func TestRenameStackInvalidName(t *testing.T) {
+	server, client := newTestServerAndClient(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request to %s", r.URL.Path)
+	})
+	defer server.Close()
+
+	stack := StackIdentifier{Owner: "owner", Stack: "old-stack"}
+	assert.Error(t, client.RenameStack(context.Background(), stack, "not a valid name"))
+}
+
+func TestUpdateStackTags(t *testing.T) {
+	var req apitype.UpdateStackTagsRequest
+	server, client := newTestServerAndClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assert.Equal(t, "/api/stacks/owner/my-stack/tags", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer server.Close()
+
+	stack := StackIdentifier{Owner: "owner", Stack: "my-stack"}
+	tags := map[apitype.StackTagName]string{"team": "infra"}
+	assert.NoError(t, client.UpdateStackTags(context.Background(), stack, tags))
+	assert.Equal(t, tags, req.Tags)
+}
+
+func TestGetStackLockStatus(t *testing.T) {
+	server, client := newTestServerAndClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/stacks/owner/my-stack/lock", r.URL.Path)
+		_, err := w.Write([]byte(`{"locked":true,"updateID":"abc","kind":"update","requestedBy":"alice",` +
+			`"startTime":1500000000,"lastLeaseRenewal":1500000060,"leaseExpiration":1500000360}`))
+		assert.NoError(t, err)
+	})
+	defer server.Close()
+
+	stack := StackIdentifier{Owner: "owner", Stack: "my-stack"}
+	status, err := client.GetStackLockStatus(context.Background(), stack)
+	assert.NoError(t, err)
+	assert.Equal(t, apitype.StackLockStatus{
+		Locked:           true,
+		UpdateID:         "abc",
+		Kind:             apitype.UpdateUpdate,
+		RequestedBy:      "alice",
+		StartTime:        1500000000,
+		LastLeaseRenewal: 1500000060,
+		LeaseExpiration:  1500000360,
+	}, status)
+}
+
+func TestGetStackLockStatusUnlocked(t *testing.T) {
+	server, client := newTestServerAndClient(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(`{"locked":false}`))
+		assert.NoError(t, err)
+	})
+	defer server.Close()
+
+	stack := StackIdentifier{Owner: "owner", Stack: "my-stack"}
+	status, err := client.GetStackLockStatus(context.Background(), stack)
+	assert.NoError(t, err)
+	assert.False(t, status.Locked)
+	assert.Equal(t, "", status.UpdateID)
+}
+
+func TestForceUnlockStack(t *testing.T) {
+	called := false
+	server, client := newTestServerAndClient(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/api/stacks/owner/my-stack/lock", r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer server.Close()
+
+	stack := StackIdentifier{Owner: "owner", Stack: "my-stack"}
+	assert.NoError(t, client.ForceUnlockStack(context.Background(), stack))
+	assert.True(t, called)
+}
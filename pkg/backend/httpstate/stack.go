@@ -32,10 +32,9 @@ import (
 // Stack is a cloud stack.  This simply adds some cloud-specific properties atop the standard backend stack interface.
 type Stack interface {
 	backend.Stack
-	CloudURL() string                      // the URL to the cloud containing this stack.
-	OrgName() string                       // the organization that owns this stack.
-	ConsoleURL() (string, error)           // the URL to view the stack's information on Pulumi.com
-	Tags() map[apitype.StackTagName]string // the stack's tags.
+	CloudURL() string            // the URL to the cloud containing this stack.
+	OrgName() string             // the organization that owns this stack.
+	ConsoleURL() (string, error) // the URL to view the stack's information on Pulumi.com
 }
 
 type cloudBackendReference struct {
@@ -115,6 +114,10 @@ func (s *cloudStack) Remove(ctx context.Context, force bool) (bool, error) {
 	return backend.RemoveStack(ctx, s, force)
 }
 
+func (s *cloudStack) Rename(ctx context.Context, newName tokens.QName) (backend.StackReference, error) {
+	return backend.RenameStack(ctx, s, newName)
+}
+
 func (s *cloudStack) Preview(ctx context.Context, op backend.UpdateOperation) (engine.ResourceChanges, error) {
 	return backend.PreviewStack(ctx, s, op)
 }
@@ -135,6 +138,10 @@ func (s *cloudStack) GetLogs(ctx context.Context, query operations.LogQuery) ([]
 	return backend.GetStackLogs(ctx, s, query)
 }
 
+func (s *cloudStack) GetMetrics(ctx context.Context, query operations.MetricsQuery) ([]operations.MetricSeries, error) {
+	return backend.GetStackMetrics(ctx, s, query)
+}
+
 func (s *cloudStack) ExportDeployment(ctx context.Context) (*apitype.UntypedDeployment, error) {
 	return backend.ExportStackDeployment(ctx, s)
 }
@@ -178,6 +185,22 @@ func (css cloudStackSummary) LastUpdate() *time.Time {
 	return &t
 }
 
+func (css cloudStackSummary) LastUpdateKind() *apitype.UpdateKind {
+	return css.summary.LastUpdateKind
+}
+
+func (css cloudStackSummary) LastUpdateResult() *apitype.UpdateResult {
+	return css.summary.LastUpdateResult
+}
+
+func (css cloudStackSummary) UpdateInProgress() bool {
+	return css.summary.ActiveUpdate != ""
+}
+
 func (css cloudStackSummary) ResourceCount() *int {
 	return css.summary.ResourceCount
 }
+
+func (css cloudStackSummary) Tags() map[apitype.StackTagName]string {
+	return css.summary.Tags
+}
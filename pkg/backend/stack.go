@@ -26,6 +26,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/operations"
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/gitutil"
 	"github.com/pulumi/pulumi/pkg/workspace"
 )
@@ -36,6 +37,7 @@ type Stack interface {
 	Config() config.Map                                     // the current config map.
 	Snapshot(ctx context.Context) (*deploy.Snapshot, error) // the latest deployment snapshot.
 	Backend() Backend                                       // the backend this stack belongs to.
+	Tags() map[apitype.StackTagName]string                  // the stack's tags.
 
 	// Preview changes to this stack.
 	Preview(ctx context.Context, op UpdateOperation) (engine.ResourceChanges, error)
@@ -48,8 +50,12 @@ type Stack interface {
 
 	// remove this stack.
 	Remove(ctx context.Context, force bool) (bool, error)
+	// rename this stack.
+	Rename(ctx context.Context, newName tokens.QName) (StackReference, error)
 	// list log entries for this stack.
 	GetLogs(ctx context.Context, query operations.LogQuery) ([]operations.LogEntry, error)
+	// list metric time series for this stack's resources.
+	GetMetrics(ctx context.Context, query operations.MetricsQuery) ([]operations.MetricSeries, error)
 	// export this stack's deployment.
 	ExportDeployment(ctx context.Context) (*apitype.UntypedDeployment, error)
 	// import the given deployment into this stack.
@@ -61,6 +67,26 @@ func RemoveStack(ctx context.Context, s Stack, force bool) (bool, error) {
 	return s.Backend().RemoveStack(ctx, s.Ref(), force)
 }
 
+// RenameStack renames the stack, or returns an error if it cannot.
+func RenameStack(ctx context.Context, s Stack, newName tokens.QName) (StackReference, error) {
+	return s.Backend().RenameStack(ctx, s.Ref(), newName)
+}
+
+// GetStackLockStatus returns information about the update, if any, that currently holds the stack's lock.
+func GetStackLockStatus(ctx context.Context, s Stack) (apitype.StackLockStatus, error) {
+	return s.Backend().GetStackLockStatus(ctx, s.Ref())
+}
+
+// ForceUnlockStack breaks the stack's lock, regardless of which update holds it.
+func ForceUnlockStack(ctx context.Context, s Stack) error {
+	return s.Backend().ForceUnlockStack(ctx, s.Ref())
+}
+
+// UpdateStackTags replaces the stack's tags with the given set.
+func UpdateStackTags(ctx context.Context, s Stack, tags map[apitype.StackTagName]string) error {
+	return s.Backend().UpdateStackTags(ctx, s.Ref(), tags)
+}
+
 // PreviewStack previews changes to this stack.
 func PreviewStack(ctx context.Context, s Stack, op UpdateOperation) (engine.ResourceChanges, error) {
 	return s.Backend().Preview(ctx, s.Ref(), op)
@@ -96,6 +122,11 @@ func GetStackLogs(ctx context.Context, s Stack, query operations.LogQuery) ([]op
 	return s.Backend().GetLogs(ctx, s.Ref(), query)
 }
 
+// GetStackMetrics fetches metric time series for the current stack in the current backend.
+func GetStackMetrics(ctx context.Context, s Stack, query operations.MetricsQuery) ([]operations.MetricSeries, error) {
+	return s.Backend().GetMetrics(ctx, s.Ref(), query)
+}
+
 // ExportStackDeployment exports the given stack's deployment as an opaque JSON message.
 func ExportStackDeployment(ctx context.Context, s Stack) (*apitype.UntypedDeployment, error) {
 	return s.Backend().ExportDeployment(ctx, s.Ref())
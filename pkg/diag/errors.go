@@ -23,6 +23,11 @@ func newError(urn resource.URN, id ID, message string) *Diag {
 	return &Diag{URN: urn, ID: id, Message: message}
 }
 
+// newWarning registers a new warning message underneath the given id.
+func newWarning(urn resource.URN, id ID, message string) *Diag {
+	return &Diag{URN: urn, ID: id, Message: message}
+}
+
 // Plan and apply errors are in the [2000,3000) range.
 
 func GetPlanApplyFailedError(urn resource.URN) *Diag {
@@ -52,3 +57,19 @@ func GetAnalyzeResourceFailureError(urn resource.URN) *Diag {
 func GetPreviewFailedError(urn resource.URN) *Diag {
 	return newError(urn, 2005, "Preview failed: %v")
 }
+
+func GetAnalyzeResourceAdvisoryWarning(urn resource.URN) *Diag {
+	return newWarning(urn, 2006,
+		"Analyzer '%v' reported an advisory resource issue:\n"+
+			"\tResource: %v\n"+
+			"\tProperty: %v\n"+
+			"\tReason: %v")
+}
+
+func GetAnalyzeStackFailureError(urn resource.URN) *Diag {
+	return newError(urn, 2007,
+		"Analyzer '%v' reported a stack error:\n"+
+			"\tResource: %v\n"+
+			"\tProperty: %v\n"+
+			"\tReason: %v")
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/logging"
@@ -47,6 +48,7 @@ const (
 	ResourcePreEvent        EventType = "resource-pre"
 	ResourceOutputsEvent    EventType = "resource-outputs"
 	ResourceOperationFailed EventType = "resource-operationfailed"
+	PolicyViolationEvent    EventType = "policy-violation"
 )
 
 func cancelEvent() Event {
@@ -85,12 +87,14 @@ type ResourceOperationFailedPayload struct {
 	Metadata StepEventMetadata
 	Status   resource.Status
 	Steps    int
+	Duration time.Duration // the time spent applying the step before it failed.
 }
 
 type ResourceOutputsEventPayload struct {
 	Metadata StepEventMetadata
 	Planning bool
 	Debug    bool
+	Duration time.Duration // the time taken to apply the step, or zero if it was not applied (e.g. when planning).
 }
 
 type ResourcePreEventPayload struct {
@@ -99,6 +103,15 @@ type ResourcePreEventPayload struct {
 	Debug    bool
 }
 
+// PolicyViolationEventPayload is the payload for an event with type `policy-violation`
+type PolicyViolationEventPayload struct {
+	ResourceURN      resource.URN            // the resource that violated the policy, or "" for the whole stack.
+	Analyzer         tokens.QName            // the analyzer that reported the violation.
+	Property         resource.PropertyKey    // the property that violated the policy, if any.
+	Message          string                  // a description of the violation.
+	EnforcementLevel plugin.EnforcementLevel // whether the violation blocks the update or is only advisory.
+}
+
 type StepEventMetadata struct {
 	Op       deploy.StepOp           // the operation performed by this step.
 	URN      resource.URN            // the resource URN (for before and after).
@@ -141,6 +154,8 @@ type StepEventStateMetadata struct {
 	// InitErrors is the set of errors encountered in the process of initializing resource (i.e.,
 	// during create or update).
 	InitErrors []string
+	// the resources this resource depends on.
+	Dependencies []resource.URN
 }
 
 func makeEventEmitter(events chan<- Event, update UpdateInfo) (eventEmitter, error) {
@@ -203,17 +218,18 @@ func makeStepEventStateMetadata(state *resource.State, debug bool) *StepEventSta
 	}
 
 	return &StepEventStateMetadata{
-		Type:       state.Type,
-		URN:        state.URN,
-		Custom:     state.Custom,
-		Delete:     state.Delete,
-		ID:         state.ID,
-		Parent:     state.Parent,
-		Protect:    state.Protect,
-		Inputs:     filterPropertyMap(state.Inputs, debug),
-		Outputs:    filterPropertyMap(state.Outputs, debug),
-		Provider:   state.Provider,
-		InitErrors: state.InitErrors,
+		Type:         state.Type,
+		URN:          state.URN,
+		Custom:       state.Custom,
+		Delete:       state.Delete,
+		ID:           state.ID,
+		Parent:       state.Parent,
+		Protect:      state.Protect,
+		Inputs:       filterPropertyMap(state.Inputs, debug),
+		Outputs:      filterPropertyMap(state.Outputs, debug),
+		Provider:     state.Provider,
+		InitErrors:   state.InitErrors,
+		Dependencies: state.Dependencies,
 	}
 }
 
@@ -327,7 +343,7 @@ func filterPropertyMap(propertyMap resource.PropertyMap, debug bool) resource.Pr
 }
 
 func (e *eventEmitter) resourceOperationFailedEvent(
-	step deploy.Step, status resource.Status, steps int, debug bool) {
+	step deploy.Step, status resource.Status, steps int, duration time.Duration, debug bool) {
 
 	contract.Requiref(e != nil, "e", "!= nil")
 
@@ -337,11 +353,14 @@ func (e *eventEmitter) resourceOperationFailedEvent(
 			Metadata: makeStepEventMetadata(step.Op(), step, debug),
 			Status:   status,
 			Steps:    steps,
+			Duration: duration,
 		},
 	}
 }
 
-func (e *eventEmitter) resourceOutputsEvent(op deploy.StepOp, step deploy.Step, planning bool, debug bool) {
+func (e *eventEmitter) resourceOutputsEvent(op deploy.StepOp, step deploy.Step, planning bool,
+	duration time.Duration, debug bool) {
+
 	contract.Requiref(e != nil, "e", "!= nil")
 
 	e.Chan <- Event{
@@ -350,6 +369,7 @@ func (e *eventEmitter) resourceOutputsEvent(op deploy.StepOp, step deploy.Step,
 			Metadata: makeStepEventMetadata(op, step, debug),
 			Planning: planning,
 			Debug:    debug,
+			Duration: duration,
 		},
 	}
 }
@@ -369,6 +389,21 @@ func (e *eventEmitter) resourcePreEvent(
 	}
 }
 
+func (e *eventEmitter) policyViolationEvent(analyzer tokens.QName, failure plugin.AnalyzeFailure) {
+	contract.Requiref(e != nil, "e", "!= nil")
+
+	e.Chan <- Event{
+		Type: PolicyViolationEvent,
+		Payload: PolicyViolationEventPayload{
+			ResourceURN:      failure.URN,
+			Analyzer:         analyzer,
+			Property:         failure.Property,
+			Message:          logging.FilterString(failure.Reason),
+			EnforcementLevel: failure.EnforcementLevel,
+		},
+	}
+}
+
 func (e *eventEmitter) preludeEvent(isPreview bool, cfg config.Map) {
 	contract.Requiref(e != nil, "e", "!= nil")
 
@@ -2039,3 +2039,82 @@ func TestPreviewWithPendingOperations(t *testing.T) {
 	_, err = op.Run(project, target, options, false, nil)
 	assert.EqualError(t, err, deploy.PlanPendingOperationsError{}.Error())
 }
+
+// Tests that a mandatory stack-wide policy violation blocks an update before any of its steps execute.
+func TestMandatoryStackPolicyBlocksUpdate(t *testing.T) {
+	creates := 0
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				CreateF: func(urn resource.URN,
+					news resource.PropertyMap) (resource.ID, resource.PropertyMap, resource.Status, error) {
+
+					creates++
+					return resource.ID(urn.Name()), news, resource.StatusOK, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		for _, name := range []string{"resA", "resB"} {
+			_, _, _, err := monitor.RegisterResource("pkgA:m:typA", name, true, "", false, nil, "",
+				resource.PropertyMap{})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	level := plugin.Mandatory
+	var analyzeErr error
+	analyzer := &deploytest.Analyzer{
+		Info: workspace.PluginInfo{Name: "policy", Kind: workspace.AnalyzerPlugin},
+		AnalyzeStackF: func(resources []plugin.AnalyzerResource) ([]plugin.AnalyzeFailure, error) {
+			if analyzeErr != nil {
+				return nil, analyzeErr
+			}
+			custom := 0
+			for _, r := range resources {
+				if r.Custom {
+					custom++
+				}
+			}
+			if custom < 2 {
+				return nil, nil
+			}
+			return []plugin.AnalyzeFailure{{
+				Reason:           "at most one typA resource is allowed",
+				EnforcementLevel: level,
+			}}, nil
+		},
+	}
+
+	p := &TestPlan{}
+	op := TestOp(Update)
+	options := UpdateOptions{
+		Analyzers: []string{"policy"},
+		host:      deploytest.NewPluginHostWithAnalyzers(nil, nil, program, []plugin.Analyzer{analyzer}, loaders...),
+	}
+	project, target := p.GetProject(), p.GetTarget(nil)
+
+	// A mandatory violation must fail the update without creating anything.
+	_, err := op.Run(project, target, options, false, nil)
+	assert.EqualError(t, err, "the update was blocked by mandatory policy violations; no changes were made")
+	assert.Equal(t, 0, creates)
+
+	// An advisory violation is only a warning.
+	level = plugin.Advisory
+	_, err = op.Run(project, target, options, false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, creates)
+
+	// If the policies cannot be checked, the update must fail without creating anything.
+	analyzeErr = errors.New("policy pack unavailable")
+	_, err = op.Run(project, target, options, false, nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "checking policies")
+	}
+	assert.Equal(t, 2, creates)
+}
@@ -132,6 +132,7 @@ func plan(ctx *Context, info *planContext, opts planOptions, dryRun bool) (*plan
 	if err != nil {
 		return nil, err
 	}
+	plugctx.Lock = opts.Lock
 
 	opts.trustDependencies = proj.TrustResourceDependencies()
 	// Now create the state source.  This may issue an error if it can't create the source.  This entails,
@@ -143,15 +144,39 @@ func plan(ctx *Context, info *planContext, opts planOptions, dryRun bool) (*plan
 
 	// If there are any analyzers in the project file, add them.
 	var analyzers []tokens.QName
+	seen := make(map[tokens.QName]bool)
+	addAnalyzer := func(a tokens.QName) {
+		if !seen[a] {
+			seen[a] = true
+			analyzers = append(analyzers, a)
+		}
+	}
 	if as := projinfo.Proj.Analyzers; as != nil {
 		for _, a := range *as {
-			analyzers = append(analyzers, a)
+			addAnalyzer(a)
 		}
 	}
 
 	// Append any analyzers from the command line.
 	for _, a := range opts.Analyzers {
-		analyzers = append(analyzers, tokens.QName(a))
+		addAnalyzer(tokens.QName(a))
+	}
+
+	// Pass any settings from the `policy` section of the project and stack files to the analyzers that use them.
+	for _, a := range analyzers {
+		settings, has := opts.Policy[a]
+		if !has {
+			continue
+		}
+		analyzer, analyzerErr := plugctx.Host.Analyzer(a)
+		if analyzerErr != nil {
+			return nil, analyzerErr
+		} else if analyzer == nil {
+			return nil, errors.Errorf("analyzer '%v' could not be loaded from your $PATH", a)
+		}
+		if analyzerErr = analyzer.Configure(resource.NewPropertyMapFromMap(settings)); analyzerErr != nil {
+			return nil, errors.Wrapf(analyzerErr, "configuring analyzer '%v'", a)
+		}
 	}
 
 	// Generate a plan; this API handles all interesting cases (create, update, delete).
@@ -210,6 +235,7 @@ func (res *planResult) Walk(cancelCtx *Context, events deploy.Events, preview bo
 			Refresh:           res.Options.Refresh,
 			RefreshOnly:       res.Options.isRefresh,
 			TrustDependencies: res.Options.trustDependencies,
+			ApprovedPlan:      res.Options.ApprovedPlan,
 		}
 		err = res.Plan.Execute(ctx, opts, preview)
 		close(done)
@@ -286,7 +312,7 @@ func (acts *planActions) OnResourceStepPre(step deploy.Step) (interface{}, error
 }
 
 func (acts *planActions) OnResourceStepPost(ctx interface{},
-	step deploy.Step, status resource.Status, err error) error {
+	step deploy.Step, status resource.Status, err error, timing deploy.StepTiming) error {
 	acts.MapLock.Lock()
 	assertSeen(acts.Seen, step)
 	acts.MapLock.Unlock()
@@ -316,7 +342,7 @@ func (acts *planActions) OnResourceStepPost(ctx interface{},
 			acts.MapLock.Unlock()
 		}
 
-		acts.Opts.Events.resourceOutputsEvent(op, step, true /*planning*/, acts.Opts.Debug)
+		acts.Opts.Events.resourceOutputsEvent(op, step, true /*planning*/, 0 /*duration*/, acts.Opts.Debug)
 	}
 
 	return nil
@@ -333,11 +359,15 @@ func (acts *planActions) OnResourceOutputs(step deploy.Step) error {
 	}
 
 	// Print the resource outputs separately.
-	acts.Opts.Events.resourceOutputsEvent(step.Op(), step, true /*planning*/, acts.Opts.Debug)
+	acts.Opts.Events.resourceOutputsEvent(step.Op(), step, true /*planning*/, 0 /*duration*/, acts.Opts.Debug)
 
 	return nil
 }
 
+func (acts *planActions) OnPolicyViolation(analyzer tokens.QName, failure plugin.AnalyzeFailure) {
+	acts.Opts.Events.policyViolationEvent(analyzer, failure)
+}
+
 func assertSeen(seen map[resource.URN]deploy.Step, step deploy.Step) {
 	_, has := seen[step.URN()]
 	contract.Assertf(has, "URN '%v' had not been marked as seen", step.URN())
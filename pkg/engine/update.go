@@ -15,12 +15,15 @@
 package engine
 
 import (
+	"io/ioutil"
 	"sync"
 	"time"
 
 	"github.com/blang/semver"
+	"github.com/pkg/errors"
 
 	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/resource/plugin"
@@ -44,6 +47,15 @@ type UpdateOptions struct {
 	// true if the plan should refresh before executing.
 	Refresh bool
 
+	// the settings to pass to each analyzer, keyed by analyzer name.
+	Policy workspace.PolicyConfig
+
+	// an optional previously approved plan; any step that deviates from it aborts the update.
+	ApprovedPlan *deploy.ApprovedPlan
+
+	// an optional plugin lock; if present, only the exact plugin versions it pins may be loaded.
+	Lock *workspace.Lock
+
 	// true if we should report events for steps that involve default providers.
 	reportDefaultProviderSteps bool
 
@@ -149,6 +161,8 @@ func update(ctx *Context, info *planContext, opts planOptions, dryRun bool) (Res
 		if dryRun {
 			// If a dry run, just print the plan, don't actually carry out the deployment.
 			resourceChanges, err = printPlan(ctx, result, dryRun)
+		} else if policyErr := checkMandatoryPolicies(ctx, info, opts, result); policyErr != nil {
+			return nil, policyErr
 		} else {
 			// Otherwise, we will actually deploy the latest bits.
 			opts.Events.preludeEvent(dryRun, result.Ctx.Update.GetTarget().Config)
@@ -169,6 +183,78 @@ func update(ctx *Context, info *planContext, opts planOptions, dryRun bool) (Res
 	return resourceChanges, err
 }
 
+// checkMandatoryPolicies gives any analyzers a chance to inspect all of the resources that an update will register
+// before the update changes anything.  Stack-wide analysis can only happen once the program has registered every
+// resource, by which point an update would already have executed the steps for them, so the program is first run as
+// a quiet preview.  Only mandatory policy violations are reported, and advisory violations are left to the update
+// itself.  If the preview fails for any other reason, the policies could not be checked, so the update fails too; the
+// preview's errors are the only other diagnostics that are reported.
+func checkMandatoryPolicies(ctx *Context, info *planContext, opts planOptions, result *planResult) error {
+	if opts.isRefresh || len(result.Plan.Analyzers()) == 0 {
+		return nil
+	}
+
+	quiet := diag.DefaultSink(ioutil.Discard, ioutil.Discard, diag.FormatOptions{Color: colors.Never})
+	opts.Diag, opts.StatusDiag = errorSink{Sink: quiet, errors: opts.Diag}, quiet
+	preview, err := plan(ctx, info, opts, true /*dryRun*/)
+	if err != nil {
+		return errors.Wrap(err, "checking policies")
+	}
+	defer contract.IgnoreClose(preview)
+
+	actions := &policyActions{Opts: opts}
+	err = preview.Walk(ctx, actions, true)
+	if actions.Mandatory {
+		return errors.New("the update was blocked by mandatory policy violations; no changes were made")
+	} else if err != nil {
+		return errors.Wrap(err, "checking policies; no changes were made")
+	}
+	return nil
+}
+
+// errorSink discards every diagnostic except errors, which it forwards to another sink.
+type errorSink struct {
+	diag.Sink
+	errors diag.Sink
+}
+
+func (s errorSink) Logf(sev diag.Severity, d *diag.Diag, args ...interface{}) {
+	if sev == diag.Error {
+		s.errors.Logf(sev, d, args...)
+	}
+}
+
+func (s errorSink) Errorf(d *diag.Diag, args ...interface{}) {
+	s.errors.Errorf(d, args...)
+}
+
+// policyActions ignores every step of a plan, and only reports the mandatory policy violations that it encounters.
+type policyActions struct {
+	Opts      planOptions
+	Mandatory bool
+}
+
+func (acts *policyActions) OnResourceStepPre(step deploy.Step) (interface{}, error) {
+	return nil, nil
+}
+
+func (acts *policyActions) OnResourceStepPost(ctx interface{},
+	step deploy.Step, status resource.Status, err error, timing deploy.StepTiming) error {
+	return nil
+}
+
+func (acts *policyActions) OnResourceOutputs(step deploy.Step) error {
+	return nil
+}
+
+func (acts *policyActions) OnPolicyViolation(analyzer tokens.QName, failure plugin.AnalyzeFailure) {
+	if failure.EnforcementLevel == plugin.Advisory {
+		return
+	}
+	acts.Mandatory = true
+	acts.Opts.Events.policyViolationEvent(analyzer, failure)
+}
+
 // pluginActions listens for plugin events and persists the set of loaded plugins
 // to the snapshot.
 type pluginActions struct {
@@ -217,7 +303,7 @@ func (acts *updateActions) OnResourceStepPre(step deploy.Step) (interface{}, err
 }
 
 func (acts *updateActions) OnResourceStepPost(ctx interface{},
-	step deploy.Step, status resource.Status, err error) error {
+	step deploy.Step, status resource.Status, err error, timing deploy.StepTiming) error {
 	acts.MapLock.Lock()
 	assertSeen(acts.Seen, step)
 	acts.MapLock.Unlock()
@@ -244,7 +330,7 @@ func (acts *updateActions) OnResourceStepPost(ctx interface{},
 		// Issue a true, bonafide error.
 		acts.Opts.Diag.Errorf(diag.GetPlanApplyFailedError(errorURN), err)
 		if reportStep {
-			acts.Opts.Events.resourceOperationFailedEvent(step, status, acts.Steps, acts.Opts.Debug)
+			acts.Opts.Events.resourceOperationFailedEvent(step, status, acts.Steps, timing.Duration(), acts.Opts.Debug)
 		}
 	} else if reportStep {
 		op, record := step.Op(), step.Logical()
@@ -265,7 +351,7 @@ func (acts *updateActions) OnResourceStepPost(ctx interface{},
 		// not show outputs for component resources at this point: any that exist must be from a previous execution of
 		// the Pulumi program, as component resources only report outputs via calls to RegisterResourceOutputs.
 		if step.Res().Custom || acts.Opts.Refresh && step.Op() == deploy.OpRefresh {
-			acts.Opts.Events.resourceOutputsEvent(op, step, false /*planning*/, acts.Opts.Debug)
+			acts.Opts.Events.resourceOutputsEvent(op, step, false /*planning*/, timing.Duration(), acts.Opts.Debug)
 		}
 	}
 
@@ -282,10 +368,14 @@ func (acts *updateActions) OnResourceOutputs(step deploy.Step) error {
 
 	// Check for a default provider step and skip reporting if necessary.
 	if acts.Opts.reportDefaultProviderSteps || !isDefaultProviderStep(step) {
-		acts.Opts.Events.resourceOutputsEvent(step.Op(), step, false /*planning*/, acts.Opts.Debug)
+		acts.Opts.Events.resourceOutputsEvent(step.Op(), step, false /*planning*/, 0 /*duration*/, acts.Opts.Debug)
 	}
 
 	// There's a chance there are new outputs that weren't written out last time.
 	// We need to perform another snapshot write to ensure they get written out.
 	return acts.Context.SnapshotManager.RegisterResourceOutputs(step)
 }
+
+func (acts *updateActions) OnPolicyViolation(analyzer tokens.QName, failure plugin.AnalyzeFailure) {
+	acts.Opts.Events.policyViolationEvent(analyzer, failure)
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"strings"
 
 	"github.com/pulumi/pulumi/pkg/graph"
 	"github.com/pulumi/pulumi/pkg/util/contract"
@@ -79,8 +80,15 @@ func Print(g graph.Graph, w io.Writer) error {
 		if _, err := b.WriteString(fmt.Sprintf("%v%v", indent, id)); err != nil {
 			return err
 		}
+		var attrs []string
 		if label := v.Label(); label != "" {
-			if _, err := b.WriteString(fmt.Sprintf(" [label=\"%v\"]", label)); err != nil {
+			attrs = append(attrs, fmt.Sprintf("label=\"%v\"", label))
+		}
+		if color := v.Color(); color != "" {
+			attrs = append(attrs, fmt.Sprintf("color=\"%v\"", color))
+		}
+		if len(attrs) > 0 {
+			if _, err := b.WriteString(fmt.Sprintf(" [%v]", strings.Join(attrs, ", "))); err != nil {
 				return err
 			}
 		}
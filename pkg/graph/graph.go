@@ -36,6 +36,7 @@ type Vertex interface {
 	Label() string     // the vertex's label.
 	Ins() []Edge       // incoming edges from other vertices within the graph to this vertex.
 	Outs() []Edge      // outgoing edges from this vertex to other vertices within the graph.
+	Color() string     // an optional color for this vertex, for when this graph is displayed.
 }
 
 // Edge is a directed edge from one vertex to another.
@@ -46,3 +47,24 @@ type Edge interface {
 	From() Vertex      // the vertex this edge connects from.
 	Color() string     // an optional color for this edge, for when this graph is displayed.
 }
+
+// Vertices returns every vertex reachable from the graph's roots, in breadth-first order.  Each vertex appears once.
+func Vertices(g Graph) []Vertex {
+	var vertices []Vertex
+	queued := make(map[Vertex]bool)
+	for _, root := range g.Roots() {
+		if to := root.To(); !queued[to] {
+			queued[to] = true
+			vertices = append(vertices, to)
+		}
+	}
+	for i := 0; i < len(vertices); i++ {
+		for _, out := range vertices[i].Outs() {
+			if to := out.To(); !queued[to] {
+				queued[to] = true
+				vertices = append(vertices, to)
+			}
+		}
+	}
+	return vertices
+}
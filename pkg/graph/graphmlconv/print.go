@@ -0,0 +1,82 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package graphmlconv converts a resource graph into its GraphML equivalent.  GraphML is an XML format understood by
+// many graph analysis and layout tools, such as yEd, Gephi and NetworkX.  Please see http://graphml.graphdrawing.org/
+// for the specification.
+package graphmlconv
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/pulumi/pulumi/pkg/graph"
+)
+
+const header = `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="label" for="all" attr.name="label" attr.type="string"/>
+  <key id="color" for="all" attr.name="color" attr.type="string"/>
+  <graph id="G" edgedefault="directed">
+`
+
+const footer = `  </graph>
+</graphml>
+`
+
+// Print prints a resource graph.
+func Print(g graph.Graph, w io.Writer) error {
+	// As in dotconv, we ignore write errors until the end, relying on the flush to report them.
+	b := bufio.NewWriter(w)
+
+	_, _ = b.WriteString(header)
+
+	vertices := graph.Vertices(g)
+	ids := make(map[graph.Vertex]string, len(vertices))
+	for i, v := range vertices {
+		ids[v] = "Resource" + strconv.Itoa(i)
+	}
+
+	for _, v := range vertices {
+		_, _ = b.WriteString(fmt.Sprintf("    <node id=\"%s\">\n", ids[v]))
+		writeData(b, "label", v.Label())
+		writeData(b, "color", v.Color())
+		_, _ = b.WriteString("    </node>\n")
+	}
+
+	for _, v := range vertices {
+		for _, out := range v.Outs() {
+			_, _ = b.WriteString(fmt.Sprintf("    <edge source=\"%s\" target=\"%s\">\n", ids[v], ids[out.To()]))
+			writeData(b, "label", out.Label())
+			writeData(b, "color", out.Color())
+			_, _ = b.WriteString("    </edge>\n")
+		}
+	}
+
+	_, _ = b.WriteString(footer)
+	return b.Flush()
+}
+
+// writeData writes a <data> element for the given key, unless the value is empty.
+func writeData(b *bufio.Writer, key string, value string) {
+	if value == "" {
+		return
+	}
+	_, _ = b.WriteString(fmt.Sprintf("      <data key=\"%s\">", key))
+	_ = xml.EscapeText(b, []byte(value))
+	_, _ = b.WriteString("</data>\n")
+}
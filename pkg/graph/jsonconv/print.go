@@ -0,0 +1,76 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonconv converts a resource graph into a JSON document listing its vertices and edges, for consumption by
+// other tools.
+package jsonconv
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/pulumi/pulumi/pkg/graph"
+)
+
+// Graph is the JSON representation of a resource graph.
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// Node is the JSON representation of a vertex in a resource graph.
+type Node struct {
+	ID    string `json:"id"`
+	Label string `json:"label,omitempty"`
+	Color string `json:"color,omitempty"`
+}
+
+// Edge is the JSON representation of an edge in a resource graph.
+type Edge struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Label string `json:"label,omitempty"`
+	Color string `json:"color,omitempty"`
+}
+
+// Convert converts a resource graph into its JSON representation.
+func Convert(g graph.Graph) Graph {
+	vertices := graph.Vertices(g)
+	ids := make(map[graph.Vertex]string, len(vertices))
+	for i, v := range vertices {
+		ids[v] = "Resource" + strconv.Itoa(i)
+	}
+
+	result := Graph{Nodes: []Node{}, Edges: []Edge{}}
+	for _, v := range vertices {
+		result.Nodes = append(result.Nodes, Node{ID: ids[v], Label: v.Label(), Color: v.Color()})
+		for _, out := range v.Outs() {
+			result.Edges = append(result.Edges, Edge{
+				From:  ids[v],
+				To:    ids[out.To()],
+				Label: out.Label(),
+				Color: out.Color(),
+			})
+		}
+	}
+	return result
+}
+
+// Print prints a resource graph.
+func Print(g graph.Graph, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	return enc.Encode(Convert(g))
+}
@@ -0,0 +1,83 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mermaidconv converts a resource graph into a Mermaid flowchart.  Mermaid diagrams can be rendered directly
+// by many Markdown viewers, including GitHub's.  Please see https://mermaid-js.github.io/mermaid/ for a description
+// of the flowchart syntax.
+package mermaidconv
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/graph"
+)
+
+// Print prints a resource graph.
+func Print(g graph.Graph, w io.Writer) error {
+	// As in dotconv, we ignore write errors until the end, relying on the flush to report them.
+	b := bufio.NewWriter(w)
+	indent := "    "
+
+	_, _ = b.WriteString("graph TD\n")
+
+	vertices := graph.Vertices(g)
+	ids := make(map[graph.Vertex]string, len(vertices))
+	for i, v := range vertices {
+		ids[v] = "Resource" + strconv.Itoa(i)
+	}
+
+	// First declare each vertex along with its label and, if it has one, its color.
+	for _, v := range vertices {
+		id := ids[v]
+		if label := v.Label(); label != "" {
+			_, _ = b.WriteString(fmt.Sprintf("%s%s[\"%s\"]\n", indent, id, escape(label)))
+		} else {
+			_, _ = b.WriteString(fmt.Sprintf("%s%s\n", indent, id))
+		}
+		if color := v.Color(); color != "" {
+			_, _ = b.WriteString(fmt.Sprintf("%sstyle %s stroke:%s,stroke-width:2px\n", indent, id, color))
+		}
+	}
+
+	// Next print each edge.  Mermaid styles edges by their index in the order they are declared.
+	var linkStyles []string
+	edges := 0
+	for _, v := range vertices {
+		for _, out := range v.Outs() {
+			arrow := "-->"
+			if label := out.Label(); label != "" {
+				arrow = fmt.Sprintf("-->|%s|", escape(label))
+			}
+			_, _ = b.WriteString(fmt.Sprintf("%s%s %s %s\n", indent, ids[v], arrow, ids[out.To()]))
+			if color := out.Color(); color != "" {
+				linkStyles = append(linkStyles, fmt.Sprintf("%slinkStyle %d stroke:%s\n", indent, edges, color))
+			}
+			edges++
+		}
+	}
+	for _, style := range linkStyles {
+		_, _ = b.WriteString(style)
+	}
+
+	return b.Flush()
+}
+
+// escape replaces characters that would otherwise end a quoted Mermaid label with their entity codes.
+func escape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "|", "#124;").Replace(s)
+}
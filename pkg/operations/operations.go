@@ -15,7 +15,10 @@
 package operations
 
 import (
+	"regexp"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 // LogEntry is a row in the logs for a running compute service
@@ -29,6 +32,9 @@ type LogEntry struct {
 // - Full URN: "<namespace>::<alloc>::<type>::<name>"
 // - Type + Name: "<type>::<name>"
 // - Name: "<name>"
+//
+// Any of these may contain the glob wildcards '*', which matches any sequence of characters, and '?', which matches any
+// single character; e.g. "aws:lambda/*::*" or "api-*".
 type ResourceFilter string
 
 // LogQuery represents the parameters to a log query operation. All fields are
@@ -44,6 +50,67 @@ type LogQuery struct {
 	EndTime *time.Time `url:"endTime,unix"`
 	// ResourceFilter is a string indicating that logs should be limited to a resource or resources
 	ResourceFilter *ResourceFilter `url:"resourceFilter"`
+	// Filter is an optional regular expression indicating that only logs whose messages match it should be produced.
+	Filter *string `url:"filter"`
+}
+
+// filterLogs returns the given logs whose messages match the query's message filter, if any.
+func (q LogQuery) filterLogs(logs []LogEntry) ([]LogEntry, error) {
+	if q.Filter == nil {
+		return logs, nil
+	}
+	re, err := regexp.Compile(*q.Filter)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid log filter '%s'", *q.Filter)
+	}
+
+	var filtered []LogEntry
+	for _, log := range logs {
+		if re.MatchString(log.Message) {
+			filtered = append(filtered, log)
+		}
+	}
+	return filtered, nil
+}
+
+// MetricName identifies a kind of metric collected for a resource.
+type MetricName string
+
+const (
+	// MetricInvocations counts the number of times a function was invoked.
+	MetricInvocations MetricName = "invocations"
+	// MetricErrors counts the number of invocations or requests that failed.
+	MetricErrors MetricName = "errors"
+	// MetricLatency is the average time, in milliseconds, taken to serve an invocation or request.
+	MetricLatency MetricName = "latency"
+)
+
+// MetricsQuery represents the parameters to a metrics query operation.  All fields are optional.
+type MetricsQuery struct {
+	// StartTime is an optional time indicating that only data points from after this time should be produced.
+	// Defaults to an hour before EndTime.
+	StartTime *time.Time
+	// EndTime is an optional time indicating that only data points from before this time should be produced.
+	// Defaults to the present.
+	EndTime *time.Time
+	// Period is the interval each data point summarizes.  Defaults to one minute.
+	Period time.Duration
+	// ResourceFilter is a string indicating that metrics should be limited to a resource or resources.
+	ResourceFilter *ResourceFilter
+}
+
+// MetricDataPoint is a single value in a metric time series.
+type MetricDataPoint struct {
+	Timestamp int64   // the start of the period summarized by this point, in milliseconds since the Unix epoch.
+	Value     float64 // the value of the metric over the period.
+}
+
+// MetricSeries is a time series of a single metric for a single resource.
+type MetricSeries struct {
+	ID     string            // the resource the series was collected for.
+	Name   MetricName        // the metric.
+	Unit   string            // the unit of the values, e.g. "count" or "milliseconds".
+	Points []MetricDataPoint // the data points, in timestamp order.
 }
 
 // Provider is the interface for making operational requests about the
@@ -51,5 +118,6 @@ type LogQuery struct {
 type Provider interface {
 	// GetLogs returns logs matching a query
 	GetLogs(query LogQuery) (*[]LogEntry, error)
-	// TODO[pulumi/pulumi#609] Add support for metrics
+	// GetMetrics returns metric time series matching a query
+	GetMetrics(query MetricsQuery) (*[]MetricSeries, error)
 }
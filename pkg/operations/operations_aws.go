@@ -22,7 +22,9 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 
 	"github.com/pulumi/pulumi/pkg/resource/config"
@@ -60,7 +62,8 @@ func AWSOperationsProvider(
 	}
 
 	connection := &awsConnection{
-		logSvc: cloudwatchlogs.New(sess),
+		logSvc:    cloudwatchlogs.New(sess),
+		metricSvc: cloudwatch.New(sess),
 	}
 
 	prov := &awsOpsProvider{
@@ -136,8 +139,90 @@ func (ops *awsOpsProvider) GetLogs(query LogQuery) (*[]LogEntry, error) {
 	}
 }
 
+// lambdaMetrics maps the metrics we report for Lambda functions onto the CloudWatch metrics and statistics that
+// back them.
+var lambdaMetrics = []struct {
+	name      MetricName
+	metric    string
+	statistic string
+	unit      string
+}{
+	{MetricInvocations, "Invocations", cloudwatch.StatisticSum, "count"},
+	{MetricErrors, "Errors", cloudwatch.StatisticSum, "count"},
+	{MetricLatency, "Duration", cloudwatch.StatisticAverage, "milliseconds"},
+}
+
+func (ops *awsOpsProvider) GetMetrics(query MetricsQuery) (*[]MetricSeries, error) {
+	state := ops.component.State
+	logging.V(6).Infof("GetMetrics[%v]", state.URN)
+	switch state.Type {
+	case awsFunctionType:
+		functionName := state.Outputs["name"].StringValue()
+		startTime, endTime, period := query.window()
+
+		type result struct {
+			series MetricSeries
+			err    error
+		}
+		ch := make(chan result, len(lambdaMetrics))
+		for _, m := range lambdaMetrics {
+			go func(name MetricName, metric, statistic, unit string) {
+				points, err := ops.awsConnection.getMetricStatistics("AWS/Lambda", metric, statistic,
+					"FunctionName", functionName, startTime, endTime, period)
+				ch <- result{
+					series: MetricSeries{ID: string(state.URN.Name()), Name: name, Unit: unit, Points: points},
+					err:    err,
+				}
+			}(m.name, m.metric, m.statistic, m.unit)
+		}
+
+		var series []MetricSeries
+		var err error
+		for range lambdaMetrics {
+			r := <-ch
+			if r.err != nil {
+				err = multierror.Append(err, r.err)
+				continue
+			}
+			series = append(series, r.series)
+		}
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(series, func(i, j int) bool { return series[i].Name < series[j].Name })
+		logging.V(5).Infof("GetMetrics[%v] return %d series", state.URN, len(series))
+		return &series, nil
+	default:
+		// Else this resource kind does not produce any metrics.
+		logging.V(6).Infof("GetMetrics[%v] does not produce metrics", state.URN)
+		return nil, nil
+	}
+}
+
+// window returns the time range and period covered by a metrics query, applying defaults for any that are unset.
+// CloudWatch requires the period to be a multiple of a minute, so it is rounded up accordingly.
+func (query MetricsQuery) window() (time.Time, time.Time, time.Duration) {
+	endTime := time.Now()
+	if query.EndTime != nil {
+		endTime = *query.EndTime
+	}
+	startTime := endTime.Add(-time.Hour)
+	if query.StartTime != nil {
+		startTime = *query.StartTime
+	}
+	period := query.Period
+	if period < time.Minute {
+		period = time.Minute
+	}
+	if rem := period % time.Minute; rem != 0 {
+		period += time.Minute - rem
+	}
+	return startTime, endTime, period
+}
+
 type awsConnection struct {
-	logSvc *cloudwatchlogs.CloudWatchLogs
+	logSvc    *cloudwatchlogs.CloudWatchLogs
+	metricSvc *cloudwatch.CloudWatch
 }
 
 var awsDefaultSession *session.Session
@@ -223,3 +308,49 @@ func (p *awsConnection) getLogsForLogGroupsConcurrently(
 
 	return logs
 }
+
+func (p *awsConnection) getMetricStatistics(
+	namespace, metric, statistic, dimension, dimensionValue string,
+	startTime, endTime time.Time,
+	period time.Duration) ([]MetricDataPoint, error) {
+
+	resp, err := p.metricSvc.GetMetricStatistics(&cloudwatch.GetMetricStatisticsInput{
+		Namespace:  aws.String(namespace),
+		MetricName: aws.String(metric),
+		Dimensions: []*cloudwatch.Dimension{{
+			Name:  aws.String(dimension),
+			Value: aws.String(dimensionValue),
+		}},
+		StartTime:  aws.Time(startTime),
+		EndTime:    aws.Time(endTime),
+		Period:     aws.Int64(int64(period / time.Second)),
+		Statistics: []*string{aws.String(statistic)},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting %s metric %s for %s", namespace, metric, dimensionValue)
+	}
+
+	points := make([]MetricDataPoint, 0, len(resp.Datapoints))
+	for _, dp := range resp.Datapoints {
+		var value float64
+		switch statistic {
+		case cloudwatch.StatisticSum:
+			value = aws.Float64Value(dp.Sum)
+		case cloudwatch.StatisticAverage:
+			value = aws.Float64Value(dp.Average)
+		case cloudwatch.StatisticMaximum:
+			value = aws.Float64Value(dp.Maximum)
+		case cloudwatch.StatisticMinimum:
+			value = aws.Float64Value(dp.Minimum)
+		case cloudwatch.StatisticSampleCount:
+			value = aws.Float64Value(dp.SampleCount)
+		}
+		points = append(points, MetricDataPoint{
+			Timestamp: aws.TimeUnixMilli(aws.TimeValue(dp.Timestamp)),
+			Value:     value,
+		})
+	}
+	// CloudWatch does not return data points in any particular order.
+	sort.Slice(points, func(i, j int) bool { return points[i].Timestamp < points[j].Timestamp })
+	return points, nil
+}
@@ -15,9 +15,20 @@
 package operations
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/tokens"
 )
 
 func TestSessionCache(t *testing.T) {
@@ -47,3 +58,82 @@ func TestSessionCache(t *testing.T) {
 	assert.Equal(t, "456", creds.SecretAccessKey)
 	assert.Equal(t, "hij", creds.SessionToken)
 }
+
+// fakeCloudWatch serves GetMetricStatistics requests, answering each metric with the given (unordered) data points.
+func fakeCloudWatch(t *testing.T, datapoints map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "GetMetricStatistics", r.Form.Get("Action"))
+		assert.Equal(t, "AWS/Lambda", r.Form.Get("Namespace"))
+		assert.Equal(t, "FunctionName", r.Form.Get("Dimensions.member.1.Name"))
+		assert.Equal(t, "my-function-1234", r.Form.Get("Dimensions.member.1.Value"))
+		assert.Equal(t, "120", r.Form.Get("Period"))
+
+		metric := r.Form.Get("MetricName")
+		fmt.Fprintf(w, `<GetMetricStatisticsResponse xmlns="http://monitoring.amazonaws.com/doc/2010-08-01/">
+  <GetMetricStatisticsResult>
+    <Label>%s</Label>
+    <Datapoints>%s</Datapoints>
+  </GetMetricStatisticsResult>
+  <ResponseMetadata><RequestId>test</RequestId></ResponseMetadata>
+</GetMetricStatisticsResponse>`, metric, datapoints[metric])
+	}))
+}
+
+func TestAWSGetMetrics(t *testing.T) {
+	server := fakeCloudWatch(t, map[string]string{
+		"Invocations": `
+      <member><Timestamp>2018-01-01T00:02:00Z</Timestamp><Sum>7</Sum><Unit>Count</Unit></member>
+      <member><Timestamp>2018-01-01T00:00:00Z</Timestamp><Sum>3</Sum><Unit>Count</Unit></member>`,
+		"Errors": `
+      <member><Timestamp>2018-01-01T00:00:00Z</Timestamp><Sum>1</Sum><Unit>Count</Unit></member>`,
+		"Duration": `
+      <member><Timestamp>2018-01-01T00:00:00Z</Timestamp><Average>12.5</Average><Unit>Milliseconds</Unit></member>`,
+	})
+	defer server.Close()
+
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:    aws.String(server.URL),
+		Region:      aws.String("us-west-2"),
+		Credentials: credentials.NewStaticCredentials("AKIA123", "456", ""),
+		DisableSSL:  aws.Bool(true),
+	})
+	assert.NoError(t, err)
+
+	urn := resource.NewURN("test", "test", "", awsFunctionType, tokens.QName("my-function"))
+	state := resource.NewState(awsFunctionType, urn, true, false, "my-function-1234", resource.PropertyMap{},
+		resource.PropertyMap{"name": resource.NewStringProperty("my-function-1234")}, "", false, false, nil, nil, "")
+	prov := &awsOpsProvider{
+		awsConnection: &awsConnection{metricSvc: cloudwatch.New(sess)},
+		component:     &Resource{State: state},
+	}
+
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(10 * time.Minute)
+	series, err := prov.GetMetrics(MetricsQuery{StartTime: &start, EndTime: &end, Period: 90 * time.Second})
+	assert.NoError(t, err)
+	if !assert.NotNil(t, series) {
+		return
+	}
+
+	minute := func(m int) int64 { return aws.TimeUnixMilli(start.Add(time.Duration(m) * time.Minute)) }
+	assert.Equal(t, []MetricSeries{
+		{ID: "my-function", Name: MetricErrors, Unit: "count", Points: []MetricDataPoint{
+			{Timestamp: minute(0), Value: 1},
+		}},
+		{ID: "my-function", Name: MetricInvocations, Unit: "count", Points: []MetricDataPoint{
+			{Timestamp: minute(0), Value: 3},
+			{Timestamp: minute(2), Value: 7},
+		}},
+		{ID: "my-function", Name: MetricLatency, Unit: "milliseconds", Points: []MetricDataPoint{
+			{Timestamp: minute(0), Value: 12.5},
+		}},
+	}, *series)
+
+	// Resources other than functions produce no metrics.
+	prov.component = &Resource{State: resource.NewState(awsLogGroupType, urn, true, false, "", resource.PropertyMap{},
+		resource.PropertyMap{}, "", false, false, nil, nil, "")}
+	series, err = prov.GetMetrics(MetricsQuery{})
+	assert.NoError(t, err)
+	assert.Nil(t, series)
+}
@@ -169,6 +169,12 @@ func (ops *cloudOpsProvider) GetLogs(query LogQuery) (*[]LogEntry, error) {
 	}
 }
 
+func (ops *cloudOpsProvider) GetMetrics(query MetricsQuery) (*[]MetricSeries, error) {
+	// `@pulumi/cloud` components add nothing to the metrics of the AWS resources they are built from, so decline and
+	// let the query recur into those children.
+	return nil, nil
+}
+
 type encodedLogEvent struct {
 	ID        string `json:"id"`
 	Timestamp int64  `json:"timestamp"`
@@ -0,0 +1,152 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+)
+
+// ProviderPlugins loads, on demand, the provider plugins that manage resources, so that operational queries about
+// those resources can be answered by the plugins themselves.  Plugins stay loaded for as long as their host does, so
+// repeated queries -- e.g. each poll while following logs -- reuse them rather than loading them again.  A plugin that
+// cannot be loaded or configured is reported once, as a warning, after which its resources are treated as having no
+// logs.
+type ProviderPlugins struct {
+	host      plugin.Host
+	providers map[providers.Reference]plugin.Provider // the loaded plugins, or nil for those that are unavailable.
+	m         sync.Mutex
+}
+
+// NewProviderPlugins creates a ProviderPlugins that loads provider plugins through the given host.
+func NewProviderPlugins(host plugin.Host) *ProviderPlugins {
+	return &ProviderPlugins{
+		host:      host,
+		providers: make(map[providers.Reference]plugin.Provider),
+	}
+}
+
+// providerPluginsKey is the type of the context key for ProviderPlugins.
+type providerPluginsKey struct{}
+
+// ContextWithProviderPlugins returns a new context.Context that carries the given provider plugins, so that operational
+// queries made with it use those plugins instead of loading their own.
+func ContextWithProviderPlugins(ctx context.Context, plugins *ProviderPlugins) context.Context {
+	return context.WithValue(ctx, providerPluginsKey{}, plugins)
+}
+
+// ProviderPluginsFromContext retrieves the provider plugins carried by the given context, if any.
+func ProviderPluginsFromContext(ctx context.Context) *ProviderPlugins {
+	plugins, _ := ctx.Value(providerPluginsKey{}).(*ProviderPlugins)
+	return plugins
+}
+
+// get returns the provider plugin for the given provider reference, loading and configuring it if necessary, or nil
+// if the plugin is unavailable.  states holds the provider resources in the tree being queried, by URN.
+func (pp *ProviderPlugins) get(ref providers.Reference, states map[resource.URN]*resource.State) plugin.Provider {
+	pp.m.Lock()
+	defer pp.m.Unlock()
+
+	if provider, ok := pp.providers[ref]; ok {
+		return provider
+	}
+
+	provider, err := pp.load(ref, states[ref.URN()])
+	if err != nil {
+		pp.host.Log(diag.Warning, ref.URN(), fmt.Sprintf("logs are unavailable for the resources it manages: %v", err), 0)
+	} else {
+		logging.V(7).Infof("loaded provider %v for operations", ref)
+	}
+	pp.providers[ref] = provider
+	return provider
+}
+
+// load loads and configures the provider plugin for the given provider reference.
+func (pp *ProviderPlugins) load(ref providers.Reference, state *resource.State) (plugin.Provider, error) {
+	if state == nil || state.ID != ref.ID() {
+		return nil, errors.Errorf("unknown provider '%v'", ref)
+	}
+
+	version, err := providers.GetProviderVersion(state.Inputs)
+	if err != nil {
+		return nil, errors.Errorf("could not parse version for provider '%v': %v", ref.URN(), err)
+	}
+	provider, err := pp.host.Provider(providers.GetProviderPackage(ref.URN().Type()), version)
+	if err != nil {
+		return nil, errors.Errorf("could not load plugin for provider '%v': %v", ref.URN(), err)
+	} else if provider == nil {
+		return nil, errors.Errorf("could not find plugin for provider '%v'", ref.URN())
+	}
+	if err = provider.Configure(state.Inputs); err != nil {
+		contract.IgnoreError(pp.host.CloseProvider(provider))
+		return nil, errors.Errorf("could not configure provider '%v': %v", ref.URN(), err)
+	}
+	return provider, nil
+}
+
+// PluginOperationsProvider creates an OperationsProvider that answers operational queries about a resource by asking
+// the provider plugin that manages it.
+func PluginOperationsProvider(provider plugin.Provider, component *Resource) (Provider, error) {
+	return &pluginOpsProvider{
+		provider:  provider,
+		component: component,
+	}, nil
+}
+
+type pluginOpsProvider struct {
+	provider  plugin.Provider
+	component *Resource
+}
+
+var _ Provider = (*pluginOpsProvider)(nil)
+
+func (ops *pluginOpsProvider) GetLogs(query LogQuery) (*[]LogEntry, error) {
+	state := ops.component.State
+	logging.V(6).Infof("GetLogs[%v]", state.URN)
+
+	entries, err := ops.provider.GetLogs(state.URN, state.ID, state.Outputs, query.StartTime, query.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		// The provider does not serve logs for this resource.
+		return nil, nil
+	}
+
+	logs := make([]LogEntry, 0, len(entries))
+	for _, entry := range entries {
+		logs = append(logs, LogEntry{
+			ID:        entry.ID,
+			Timestamp: entry.Timestamp,
+			Message:   entry.Message,
+		})
+	}
+	return &logs, nil
+}
+
+func (ops *pluginOpsProvider) GetMetrics(query MetricsQuery) (*[]MetricSeries, error) {
+	// Provider plugins do not yet serve metrics.
+	return nil, nil
+}
@@ -0,0 +1,194 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/deploytest"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func TestPluginOperationsProviderGetLogs(t *testing.T) {
+	newURN := func(typ tokens.Type, name string) resource.URN {
+		return resource.NewURN("test", "test", "", typ, tokens.QName(name))
+	}
+
+	provURN := newURN(providers.MakeProviderType("pkgA"), "default")
+	provRef, err := providers.NewReference(provURN, "provider-id")
+	assert.NoError(t, err)
+
+	componentURN := newURN("pkgA:m:component", "component")
+	serviceURN := newURN("pkgA:m:service", "service")
+	childURN := newURN("pkgA:m:function", "function")
+	otherURN := newURN("pkgA:m:bucket", "bucket")
+
+	states := []*resource.State{
+		resource.NewState(provURN.Type(), provURN, true, false, "provider-id",
+			resource.PropertyMap{"region": resource.NewStringProperty("us-west-2")}, resource.PropertyMap{},
+			"", false, false, nil, nil, ""),
+		resource.NewState(componentURN.Type(), componentURN, false, false, "", resource.PropertyMap{},
+			resource.PropertyMap{}, "", false, false, nil, nil, ""),
+		resource.NewState(serviceURN.Type(), serviceURN, true, false, "service-id", resource.PropertyMap{},
+			resource.PropertyMap{"name": resource.NewStringProperty("svc")}, componentURN, false, false, nil, nil,
+			provRef.String()),
+		resource.NewState(childURN.Type(), childURN, true, false, "function-id", resource.PropertyMap{},
+			resource.PropertyMap{}, componentURN, false, false, nil, nil, provRef.String()),
+		resource.NewState(otherURN.Type(), otherURN, true, false, "bucket-id", resource.PropertyMap{},
+			resource.PropertyMap{}, "", false, false, nil, nil, provRef.String()),
+	}
+
+	start := time.Unix(100, 0)
+	var configured resource.PropertyMap
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{
+				ConfigureF: func(news resource.PropertyMap) error {
+					configured = news
+					return nil
+				},
+				GetLogsF: func(urn resource.URN, id resource.ID, props resource.PropertyMap,
+					startTime, endTime *time.Time) ([]plugin.LogEntry, error) {
+
+					assert.Equal(t, &start, startTime)
+					assert.Nil(t, endTime)
+					switch urn {
+					case serviceURN:
+						assert.Equal(t, resource.ID("service-id"), id)
+						assert.Equal(t, "svc", props["name"].StringValue())
+						return []plugin.LogEntry{
+							{ID: "svc", Timestamp: 300, Message: "second"},
+							{ID: "svc", Timestamp: 200, Message: "first"},
+						}, nil
+					case childURN:
+						return []plugin.LogEntry{{ID: "fn", Timestamp: 250, Message: "in between"}}, nil
+					default:
+						// This provider does not serve logs for buckets.
+						return nil, nil
+					}
+				},
+			}, nil
+		}),
+	}
+	host := deploytest.NewPluginHost(nil, nil, nil, loaders...)
+
+	tree := NewResourceTree(states)
+	logs, err := tree.OperationsProviderWithPlugins(nil, NewProviderPlugins(host)).GetLogs(LogQuery{StartTime: &start})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-west-2", configured["region"].StringValue())
+	if assert.NotNil(t, logs) {
+		assert.Equal(t, []LogEntry{
+			{ID: "svc", Timestamp: 200, Message: "first"},
+			{ID: "fn", Timestamp: 250, Message: "in between"},
+			{ID: "svc", Timestamp: 300, Message: "second"},
+		}, *logs)
+	}
+
+	// Filtering by resource only returns the logs served for that resource.
+	filter := ResourceFilter("function")
+	logs, err = tree.OperationsProviderWithPlugins(nil, NewProviderPlugins(host)).GetLogs(LogQuery{
+		StartTime:      &start,
+		ResourceFilter: &filter,
+	})
+	assert.NoError(t, err)
+	if assert.NotNil(t, logs) {
+		assert.Equal(t, []LogEntry{{ID: "fn", Timestamp: 250, Message: "in between"}}, *logs)
+	}
+
+	// Without a plugin host, no provider plugins are consulted.
+	logs, err = tree.OperationsProvider(nil).GetLogs(LogQuery{StartTime: &start})
+	assert.NoError(t, err)
+	if assert.NotNil(t, logs) {
+		assert.Empty(t, *logs)
+	}
+}
+
+func TestProviderPluginsReuseAndFailures(t *testing.T) {
+	newURN := func(typ tokens.Type, name string) resource.URN {
+		return resource.NewURN("test", "test", "", typ, tokens.QName(name))
+	}
+
+	goodURN := newURN(providers.MakeProviderType("pkgA"), "good")
+	goodRef, err := providers.NewReference(goodURN, "good-id")
+	assert.NoError(t, err)
+	badURN := newURN(providers.MakeProviderType("pkgB"), "bad")
+	badRef, err := providers.NewReference(badURN, "bad-id")
+	assert.NoError(t, err)
+
+	functionURN := newURN("pkgA:m:function", "function")
+	bucketURN := newURN("pkgB:m:bucket", "bucket")
+	states := []*resource.State{
+		resource.NewState(goodURN.Type(), goodURN, true, false, "good-id", resource.PropertyMap{},
+			resource.PropertyMap{}, "", false, false, nil, nil, ""),
+		resource.NewState(badURN.Type(), badURN, true, false, "bad-id", resource.PropertyMap{},
+			resource.PropertyMap{}, "", false, false, nil, nil, ""),
+		resource.NewState(functionURN.Type(), functionURN, true, false, "function-id", resource.PropertyMap{},
+			resource.PropertyMap{}, "", false, false, nil, nil, goodRef.String()),
+		resource.NewState(bucketURN.Type(), bucketURN, true, false, "bucket-id", resource.PropertyMap{},
+			resource.PropertyMap{}, "", false, false, nil, nil, badRef.String()),
+	}
+
+	loads := 0
+	loaders := []*deploytest.ProviderLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			loads++
+			return &deploytest.Provider{
+				GetLogsF: func(urn resource.URN, id resource.ID, props resource.PropertyMap,
+					startTime, endTime *time.Time) ([]plugin.LogEntry, error) {
+					return []plugin.LogEntry{{ID: "fn", Timestamp: 100, Message: "hello"}}, nil
+				},
+			}, nil
+		}),
+		deploytest.NewProviderLoader("pkgB", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			loads++
+			return &deploytest.Provider{
+				ConfigureF: func(news resource.PropertyMap) error {
+					return errors.New("missing credentials")
+				},
+			}, nil
+		}),
+	}
+	var stderr bytes.Buffer
+	sink := diag.DefaultSink(ioutil.Discard, &stderr, diag.FormatOptions{Color: colors.Never})
+	plugins := NewProviderPlugins(deploytest.NewPluginHost(sink, sink, nil, loaders...))
+
+	// A provider that cannot be configured does not fail the query; its resources simply have no logs.
+	tree := NewResourceTree(states)
+	for i := 0; i < 2; i++ {
+		logs, err := tree.OperationsProviderWithPlugins(nil, plugins).GetLogs(LogQuery{})
+		assert.NoError(t, err)
+		if assert.NotNil(t, logs) {
+			assert.Equal(t, []LogEntry{{ID: "fn", Timestamp: 100, Message: "hello"}}, *logs)
+		}
+	}
+
+	// Each plugin is loaded, and each failure reported, only once across queries.
+	assert.Equal(t, 2, loads)
+	assert.Equal(t, 1, strings.Count(stderr.String(), "could not configure provider"))
+	assert.Contains(t, stderr.String(), "missing credentials")
+}
@@ -15,11 +15,14 @@
 package operations
 
 import (
+	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 )
@@ -120,10 +123,35 @@ func (r *Resource) OperationsProvider(config map[config.Key]string) Provider {
 	}
 }
 
+// OperationsProviderWithPlugins gets an OperationsProvider for this resource that, for resources that have no built-in
+// operations provider, dispatches operational queries to the resource's provider plugin, if it is available.
+func (r *Resource) OperationsProviderWithPlugins(config map[config.Key]string, plugins *ProviderPlugins) Provider {
+	states := make(map[resource.URN]*resource.State)
+	var collect func(r *Resource)
+	collect = func(r *Resource) {
+		if r.State != nil && providers.IsProviderType(r.State.Type) {
+			states[r.State.URN] = r.State
+		}
+		for _, child := range r.Children {
+			collect(child)
+		}
+	}
+	collect(r)
+
+	return &resourceOperations{
+		resource:       r,
+		config:         config,
+		plugins:        plugins,
+		providerStates: states,
+	}
+}
+
 // ResourceOperations is an OperationsProvider for Resources
 type resourceOperations struct {
-	resource *Resource
-	config   map[config.Key]string
+	resource       *Resource
+	config         map[config.Key]string
+	plugins        *ProviderPlugins                 // the plugins to query, or nil to use only built-in providers.
+	providerStates map[resource.URN]*resource.State // the provider resources in the tree, by URN.
 }
 
 var _ Provider = (*resourceOperations)(nil)
@@ -142,6 +170,7 @@ func (ops *resourceOperations) GetLogs(query LogQuery) (*[]LogEntry, error) {
 			StartTime:      query.StartTime,
 			EndTime:        query.EndTime,
 			ResourceFilter: nil,
+			Filter:         query.Filter,
 		}
 		// Try to get an operations provider for this resource, it may be `nil`
 		opsProvider, err := ops.getOperationsProvider()
@@ -157,7 +186,11 @@ func (ops *resourceOperations) GetLogs(query LogQuery) (*[]LogEntry, error) {
 				return logsResult, err
 			}
 			if logsResult != nil {
-				return logsResult, nil
+				filtered, err := query.filterLogs(*logsResult)
+				if err != nil {
+					return nil, err
+				}
+				return &filtered, nil
 			}
 		}
 	}
@@ -168,8 +201,10 @@ func (ops *resourceOperations) GetLogs(query LogQuery) (*[]LogEntry, error) {
 	errch := make(chan error)
 	for _, child := range ops.resource.Children {
 		childOps := &resourceOperations{
-			resource: child,
-			config:   ops.config,
+			resource:       child,
+			config:         ops.config,
+			plugins:        ops.plugins,
+			providerStates: ops.providerStates,
 		}
 		go func() {
 			childLogs, err := childOps.GetLogs(query)
@@ -217,9 +252,81 @@ func (ops *resourceOperations) GetLogs(query LogQuery) (*[]LogEntry, error) {
 		lastLogTimestamp = log.Timestamp
 		retLogs = append(retLogs, log)
 	}
+	retLogs, err = query.filterLogs(retLogs)
+	if err != nil {
+		return nil, err
+	}
 	return &retLogs, nil
 }
 
+// GetMetrics gets metric time series for a Resource
+func (ops *resourceOperations) GetMetrics(query MetricsQuery) (*[]MetricSeries, error) {
+	if ops.resource == nil {
+		return nil, nil
+	}
+
+	// Only get metrics for this resource if it matches the resource filter query
+	if ops.matchesResourceFilter(query.ResourceFilter) {
+		// Clear the resource filter so that we don't filter out metrics from any children of this resource.
+		query = MetricsQuery{
+			StartTime:      query.StartTime,
+			EndTime:        query.EndTime,
+			Period:         query.Period,
+			ResourceFilter: nil,
+		}
+		opsProvider, err := ops.getOperationsProvider()
+		if err != nil {
+			return nil, err
+		}
+		if opsProvider != nil {
+			// As with logs, an operations provider is responsible for the metrics of the resource's children.
+			metricsResult, err := opsProvider.GetMetrics(query)
+			if err != nil || metricsResult != nil {
+				return metricsResult, err
+			}
+		}
+	}
+	// Otherwise recur into children in parallel and aggregate their metrics.
+	var series []MetricSeries
+	ch := make(chan *[]MetricSeries)
+	errch := make(chan error)
+	for _, child := range ops.resource.Children {
+		childOps := &resourceOperations{
+			resource:       child,
+			config:         ops.config,
+			plugins:        ops.plugins,
+			providerStates: ops.providerStates,
+		}
+		go func() {
+			childSeries, err := childOps.GetMetrics(query)
+			ch <- childSeries
+			errch <- err
+		}()
+	}
+	var err error
+	for range ops.resource.Children {
+		childSeries := <-ch
+		childErr := <-errch
+		if childErr != nil {
+			err = multierror.Append(err, childErr)
+		}
+		if childSeries != nil {
+			series = append(series, *childSeries...)
+		}
+	}
+	if err != nil {
+		return &series, err
+	}
+	// Children complete in arbitrary order, so sort to make the result stable.
+	sort.SliceStable(series, func(i, j int) bool {
+		if series[i].ID != series[j].ID {
+			return series[i].ID < series[j].ID
+		}
+		return series[i].Name < series[j].Name
+	})
+	return &series, nil
+}
+
 // matchesResourceFilter determines whether this resource matches the provided resource filter.
 func (ops *resourceOperations) matchesResourceFilter(filter *ResourceFilter) bool {
 	if filter == nil {
@@ -242,9 +349,25 @@ func (ops *resourceOperations) matchesResourceFilter(filter *ResourceFilter) boo
 		// The filter matched the '<name>' part of the URN
 		return true
 	}
+	if strings.ContainsAny(string(*filter), "*?") {
+		// The filter is a glob; match it against each of the forms above.
+		glob := globToRegexp(string(*filter))
+		return glob.MatchString(string(urn)) ||
+			glob.MatchString(string(urn.Type())+"::"+string(urn.Name())) ||
+			glob.MatchString(string(urn.Name()))
+	}
 	return false
 }
 
+// globToRegexp converts a glob, in which '*' matches any sequence of characters and '?' matches any single character,
+// into an equivalent anchored regular expression.
+func globToRegexp(glob string) *regexp.Regexp {
+	expr := regexp.QuoteMeta(glob)
+	expr = strings.Replace(expr, `\*`, ".*", -1)
+	expr = strings.Replace(expr, `\?`, ".", -1)
+	return regexp.MustCompile("^" + expr + "$")
+}
+
 func (ops *resourceOperations) getOperationsProvider() (Provider, error) {
 	if ops.resource == nil || ops.resource.State == nil {
 		return nil, nil
@@ -254,7 +377,19 @@ func (ops *resourceOperations) getOperationsProvider() (Provider, error) {
 		return CloudOperationsProvider(ops.config, ops.resource)
 	case "aws":
 		return AWSOperationsProvider(ops.config, ops.resource)
-	default:
+	}
+
+	// Otherwise, ask the resource's provider plugin, if any.
+	if ops.plugins == nil || ops.resource.State.Provider == "" {
+		return nil, nil
+	}
+	ref, err := providers.ParseReference(ops.resource.State.Provider)
+	if err != nil {
+		return nil, err
+	}
+	provider := ops.plugins.get(ref, ops.providerStates)
+	if provider == nil {
 		return nil, nil
 	}
+	return PluginOperationsProvider(provider, ops.resource)
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/stack"
 )
 
@@ -111,3 +112,43 @@ func TestCrawler(t *testing.T) {
 	assert.Equal(t, 1, len(function.State.Inputs))
 	assert.Equal(t, 3, len(function.Children))
 }
+
+func TestMatchesResourceFilter(t *testing.T) {
+	urn := resource.NewURN("dev", "proj", "", "aws:lambda/function:Function", "api-handler")
+	ops := &resourceOperations{resource: &Resource{State: &resource.State{URN: urn}}}
+	matches := func(filter string) bool {
+		rf := ResourceFilter(filter)
+		return ops.matchesResourceFilter(&rf)
+	}
+
+	assert.True(t, matches(string(urn)))
+	assert.True(t, matches("aws:lambda/function:Function::api-handler"))
+	assert.True(t, matches("api-handler"))
+	assert.True(t, matches("api-*"))
+	assert.True(t, matches("aws:lambda/*::*"))
+	assert.True(t, matches("urn:pulumi:dev::*::api-handle?"))
+	assert.False(t, matches("api"))
+	assert.False(t, matches("aws:s3/*::*"))
+	assert.False(t, matches("web-*"))
+}
+
+func TestLogQueryFilter(t *testing.T) {
+	logs := []LogEntry{
+		{ID: "a", Timestamp: 1, Message: "GET /index.html 200"},
+		{ID: "a", Timestamp: 2, Message: "GET /missing 404"},
+		{ID: "b", Timestamp: 3, Message: "POST /api 500"},
+	}
+
+	filtered, err := LogQuery{}.filterLogs(logs)
+	assert.NoError(t, err)
+	assert.Equal(t, logs, filtered)
+
+	filter := " [45][0-9][0-9]$"
+	filtered, err = LogQuery{Filter: &filter}.filterLogs(logs)
+	assert.NoError(t, err)
+	assert.Equal(t, logs[1:], filtered)
+
+	invalid := "("
+	_, err = LogQuery{Filter: &invalid}.filterLogs(logs)
+	assert.Error(t, err)
+}
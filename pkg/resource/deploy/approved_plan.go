@@ -0,0 +1,145 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+)
+
+// ApprovedPlan records the steps that a previously reviewed preview determined an update would perform.  When a plan is
+// executed with an approved plan, the step generator refuses to perform any step that deviates from it: an operation
+// that was not approved for a resource, or a change to a property that the approved plan did not change.
+//
+// Steps that were approved but are not performed are not considered deviations, since they cannot do any harm.  For the
+// same reason, leaving a resource unchanged is never a deviation, even if the approved plan would have changed it.
+type ApprovedPlan struct {
+	resources map[resource.URN]*approvedResource
+}
+
+// approvedResource records the operations and property changes that were approved for a single resource.
+type approvedResource struct {
+	ops         map[StepOp]bool
+	changedKeys map[resource.PropertyKey]bool
+}
+
+// NewApprovedPlan creates a new, empty approved plan.
+func NewApprovedPlan() *ApprovedPlan {
+	return &ApprovedPlan{resources: make(map[resource.URN]*approvedResource)}
+}
+
+// Approve records that the given operation may be performed on the resource with the given URN, and that the operation
+// may change the given input properties.
+func (p *ApprovedPlan) Approve(urn resource.URN, op StepOp, changedKeys []resource.PropertyKey) {
+	res, has := p.resources[urn]
+	if !has {
+		res = &approvedResource{
+			ops:         make(map[StepOp]bool),
+			changedKeys: make(map[resource.PropertyKey]bool),
+		}
+		p.resources[urn] = res
+	}
+
+	res.ops[op] = true
+	for _, k := range changedKeys {
+		res.changedKeys[k] = true
+	}
+}
+
+// CheckStep returns an error describing how the given step deviates from the approved plan, if it does.
+func (p *ApprovedPlan) CheckStep(step Step) error {
+	urn := step.URN()
+
+	// Default providers are managed by the engine rather than the program, and are not reported by previews, so they
+	// are not subject to approval.
+	if providers.IsProviderType(urn.Type()) && urn.Name() == "default" {
+		return nil
+	}
+
+	// Leaving a resource unchanged is always allowed, e.g. when its inputs have converged since the plan was approved.
+	if step.Op() == OpSame {
+		return nil
+	}
+
+	res, has := p.resources[urn]
+	if !has {
+		return errors.Errorf("resource %v would be %s, but it is not in the approved plan", urn, describeOp(step.Op()))
+	}
+	if !res.ops[step.Op()] {
+		return errors.Errorf("resource %v would be %s, but the approved plan only allows it to be %s",
+			urn, describeOp(step.Op()), res.describeOps())
+	}
+
+	// If the step changes an existing resource, ensure that it only changes the properties that were approved.
+	if old, new := step.Old(), step.New(); old != nil && new != nil {
+		if diff := old.Inputs.Diff(new.Inputs); diff != nil {
+			var unapproved []string
+			for _, k := range diff.Keys() {
+				if !diff.Same(k) && !res.changedKeys[k] {
+					unapproved = append(unapproved, string(k))
+				}
+			}
+			if len(unapproved) > 0 {
+				return errors.Errorf("resource %v would be %s with changes to %s, which the approved plan does not change",
+					urn, describeOp(step.Op()), strings.Join(unapproved, ", "))
+			}
+		}
+	}
+
+	return nil
+}
+
+// describeOps returns a human-readable list of the operations approved for a resource.
+func (res *approvedResource) describeOps() string {
+	var ops []string
+	for op := range res.ops {
+		ops = append(ops, describeOp(op))
+	}
+	sort.Strings(ops)
+	return strings.Join(ops, " or ")
+}
+
+// describeOp returns a human-readable description of an operation, for use in diagnostics.
+func describeOp(op StepOp) string {
+	switch op {
+	case OpSame:
+		return "left unchanged"
+	case OpCreate:
+		return "created"
+	case OpUpdate:
+		return "updated"
+	case OpDelete:
+		return "deleted"
+	case OpReplace:
+		return "replaced"
+	case OpCreateReplacement:
+		return "created as a replacement"
+	case OpDeleteReplaced:
+		return "deleted after being replaced"
+	case OpRead:
+		return "read"
+	case OpReadReplacement:
+		return "read as a replacement"
+	case OpRefresh:
+		return "refreshed"
+	default:
+		return string(op)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func newApprovedPlanTestState(name string, inputs resource.PropertyMap) *resource.State {
+	typ := tokens.Type("test:index:Component")
+	return &resource.State{
+		Type:    typ,
+		URN:     resource.NewURN("teststack", "pkg", "", typ, tokens.QName(name)),
+		Inputs:  inputs,
+		Outputs: make(resource.PropertyMap),
+	}
+}
+
+func TestApprovedPlanCheckStep(t *testing.T) {
+	old := newApprovedPlanTestState("a", resource.PropertyMap{
+		"foo": resource.NewStringProperty("bar"),
+		"baz": resource.NewNumberProperty(1),
+	})
+	changed := newApprovedPlanTestState("a", resource.PropertyMap{
+		"foo": resource.NewStringProperty("qux"),
+		"baz": resource.NewNumberProperty(1),
+	})
+	other := newApprovedPlanTestState("b", make(resource.PropertyMap))
+
+	plan := NewApprovedPlan()
+	plan.Approve(old.URN, OpUpdate, []resource.PropertyKey{"foo"})
+
+	// An approved update that only changes approved properties is allowed.
+	assert.NoError(t, plan.CheckStep(NewUpdateStep(nil, nil, old, changed, nil)))
+
+	// An update that changes a property the plan did not change is rejected.
+	unexpected := newApprovedPlanTestState("a", resource.PropertyMap{
+		"foo": resource.NewStringProperty("qux"),
+		"baz": resource.NewNumberProperty(2),
+	})
+	err := plan.CheckStep(NewUpdateStep(nil, nil, old, unexpected, nil))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), string(old.URN))
+		assert.Contains(t, err.Error(), "baz")
+	}
+
+	// An operation that was not approved for the resource is rejected.
+	err = plan.CheckStep(NewDeleteStep(nil, old))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "would be deleted")
+		assert.Contains(t, err.Error(), "only allows it to be updated")
+	}
+
+	// A resource that is not in the plan at all is rejected.
+	err = plan.CheckStep(NewDeleteStep(nil, other))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), string(other.URN))
+		assert.Contains(t, err.Error(), "not in the approved plan")
+	}
+
+	// Leaving a resource unchanged is allowed, whether the plan approved updating it or did not mention it at all.
+	assert.NoError(t, plan.CheckStep(NewSameStep(nil, nil, old, old)))
+	assert.NoError(t, plan.CheckStep(NewSameStep(nil, nil, other, other)))
+
+	// Default providers are not reported by previews, and so are always allowed.
+	provType := providers.MakeProviderType("pkgA")
+	prov := &resource.State{
+		Type:    provType,
+		URN:     resource.NewURN("teststack", "pkg", "", provType, "default"),
+		Custom:  true,
+		ID:      "provid",
+		Inputs:  make(resource.PropertyMap),
+		Outputs: make(resource.PropertyMap),
+	}
+	assert.NoError(t, plan.CheckStep(NewDeleteStep(nil, prov)))
+}
@@ -48,6 +48,7 @@ type pluginHost struct {
 	languageRuntime plugin.LanguageRuntime
 	sink            diag.Sink
 	statusSink      diag.Sink
+	analyzers       map[tokens.QName]plugin.Analyzer
 
 	providers map[plugin.Provider]struct{}
 	m         sync.Mutex
@@ -65,6 +66,19 @@ func NewPluginHost(sink, statusSink diag.Sink, languageRuntime plugin.LanguageRu
 	}
 }
 
+// NewPluginHostWithAnalyzers creates a plugin host that, in addition to the given language runtime and providers,
+// serves the given analyzers by name.
+func NewPluginHostWithAnalyzers(sink, statusSink diag.Sink, languageRuntime plugin.LanguageRuntime,
+	analyzers []plugin.Analyzer, providerLoaders ...*ProviderLoader) plugin.Host {
+
+	host := NewPluginHost(sink, statusSink, languageRuntime, providerLoaders...).(*pluginHost)
+	host.analyzers = make(map[tokens.QName]plugin.Analyzer)
+	for _, a := range analyzers {
+		host.analyzers[a.Name()] = a
+	}
+	return host
+}
+
 func (host *pluginHost) Provider(pkg tokens.Package, version *semver.Version) (plugin.Provider, error) {
 	var best *ProviderLoader
 	for _, l := range host.providerLoaders {
@@ -124,6 +138,9 @@ func (host *pluginHost) LogStatus(sev diag.Severity, urn resource.URN, msg strin
 	host.statusSink.Logf(sev, diag.StreamMessage(urn, msg, streamID))
 }
 func (host *pluginHost) Analyzer(nm tokens.QName) (plugin.Analyzer, error) {
+	if a, has := host.analyzers[nm]; has {
+		return a, nil
+	}
 	return nil, errors.New("unsupported")
 }
 func (host *pluginHost) CloseProvider(provider plugin.Provider) error {
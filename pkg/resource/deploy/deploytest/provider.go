@@ -15,6 +15,8 @@
 package deploytest
 
 import (
+	"time"
+
 	"github.com/blang/semver"
 	uuid "github.com/satori/go.uuid"
 
@@ -49,6 +51,8 @@ type Provider struct {
 		props resource.PropertyMap) (resource.PropertyMap, resource.Status, error)
 	InvokeF func(tok tokens.ModuleMember,
 		inputs resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error)
+	GetLogsF func(urn resource.URN, id resource.ID, props resource.PropertyMap,
+		startTime, endTime *time.Time) ([]plugin.LogEntry, error)
 
 	CancelF func() error
 }
@@ -148,3 +152,10 @@ func (prov *Provider) Invoke(tok tokens.ModuleMember,
 	}
 	return prov.InvokeF(tok, args)
 }
+func (prov *Provider) GetLogs(urn resource.URN, id resource.ID, props resource.PropertyMap,
+	startTime, endTime *time.Time) ([]plugin.LogEntry, error) {
+	if prov.GetLogsF == nil {
+		return nil, nil
+	}
+	return prov.GetLogsF(urn, id, props, startTime, endTime)
+}
@@ -16,6 +16,7 @@ package deploy
 
 import (
 	"context"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
@@ -37,6 +38,8 @@ type Options struct {
 	Refresh           bool   // whether or not to refresh before executing the plan.
 	RefreshOnly       bool   // whether or not to exit after refreshing.
 	TrustDependencies bool   // whether or not to trust the resource dependency graph.
+
+	ApprovedPlan *ApprovedPlan // an optional plan that each step must conform to.
 }
 
 // DegreeOfParallelism returns the degree of parallelism that should be used during the
@@ -48,11 +51,23 @@ func (o Options) DegreeOfParallelism() int {
 	return o.Parallel
 }
 
+// StepTiming records when the application of a step started and finished.
+type StepTiming struct {
+	Start time.Time // the time at which the step began to be applied.
+	End   time.Time // the time at which the step finished being applied.
+}
+
+// Duration returns the time taken to apply the step.
+func (t StepTiming) Duration() time.Duration {
+	return t.End.Sub(t.Start)
+}
+
 // Events is an interface that can be used to hook interesting engine/planning events.
 type Events interface {
 	OnResourceStepPre(step Step) (interface{}, error)
-	OnResourceStepPost(ctx interface{}, step Step, status resource.Status, err error) error
+	OnResourceStepPost(ctx interface{}, step Step, status resource.Status, err error, timing StepTiming) error
 	OnResourceOutputs(step Step) error
+	OnPolicyViolation(analyzer tokens.QName, failure plugin.AnalyzeFailure)
 }
 
 // PlanPendingOperationsError is an error returned from `NewPlan` if there exist pending operations in the
@@ -231,6 +246,7 @@ func (p *Plan) Diag() diag.Sink                        { return p.ctx.Diag }
 func (p *Plan) Prev() *Snapshot                        { return p.prev }
 func (p *Plan) Olds() map[resource.URN]*resource.State { return p.olds }
 func (p *Plan) Source() Source                         { return p.source }
+func (p *Plan) Analyzers() []tokens.QName              { return p.analyzers }
 
 func (p *Plan) GetProvider(ref providers.Reference) (plugin.Provider, bool) {
 	return p.providers.GetProvider(ref)
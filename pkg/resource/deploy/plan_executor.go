@@ -147,7 +147,27 @@ func (pe *planExecutor) Execute(callerCtx context.Context, opts Options, preview
 				}
 
 				if event.Event == nil {
-					deleteSteps := pe.stepGen.GenerateDeletes()
+					// Now that the program has finished, give any analyzers a chance to inspect the entire stack before
+					// deleting anything.
+					if res := pe.stepGen.AnalyzeStack(); res != nil {
+						if resErr := res.Error(); resErr != nil {
+							logging.V(4).Infof("planExecutor.Execute(...): error analyzing stack: %v", resErr)
+							pe.reportError("", resErr)
+						}
+						cancel()
+						return false, result.TODO()
+					}
+
+					deleteSteps, res := pe.stepGen.GenerateDeletes()
+					if res != nil {
+						if resErr := res.Error(); resErr != nil {
+							logging.V(4).Infof("planExecutor.Execute(...): error generating deletes: %v", resErr)
+							pe.reportError("", resErr)
+						}
+						cancel()
+						return false, result.TODO()
+					}
+
 					deletes := pe.stepGen.ScheduleDeletes(deleteSteps)
 
 					// ScheduleDeletes gives us a list of lists of steps. Each list of steps can safely be executed in
@@ -46,7 +46,8 @@ func MakeProviderType(pkg tokens.Package) tokens.Type {
 	return tokens.Type("pulumi:providers:" + pkg)
 }
 
-func getProviderPackage(typ tokens.Type) tokens.Package {
+// GetProviderPackage returns the package whose resources are managed by providers of the given provider type.
+func GetProviderPackage(typ tokens.Type) tokens.Package {
 	contract.Require(IsProviderType(typ), "typ")
 	return tokens.Package(typ.Name())
 }
@@ -17,6 +17,7 @@ package providers
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
@@ -30,9 +31,9 @@ import (
 	"github.com/pulumi/pulumi/pkg/workspace"
 )
 
-// getProviderVersion fetches and parses a provider version from the given property map. If the version property is not
+// GetProviderVersion fetches and parses a provider version from the given property map. If the version property is not
 // present, this function returns nil.
-func getProviderVersion(inputs resource.PropertyMap) (*semver.Version, error) {
+func GetProviderVersion(inputs resource.PropertyMap) (*semver.Version, error) {
 	versionProp, ok := inputs["version"]
 	if !ok {
 		return nil, nil
@@ -98,11 +99,11 @@ func NewRegistry(host plugin.Host, prev []*resource.State, isPreview bool) (*Reg
 		}
 
 		// Parse the provider version, then load, configure, and register the provider.
-		version, err := getProviderVersion(res.Inputs)
+		version, err := GetProviderVersion(res.Inputs)
 		if err != nil {
 			return nil, errors.Errorf("could not parse version for provider '%v': %v", urn, err)
 		}
-		provider, err := host.Provider(getProviderPackage(urn.Type()), version)
+		provider, err := host.Provider(GetProviderPackage(urn.Type()), version)
 		if provider == nil {
 			return nil, errors.Errorf("could not find plugin for provider '%v'", urn)
 		}
@@ -202,11 +203,11 @@ func (r *Registry) Check(urn resource.URN, olds, news resource.PropertyMap,
 	logging.V(7).Infof("%s executing (#olds=%d,#news=%d", label, len(olds), len(news))
 
 	// Parse the version from the provider properties and load the provider.
-	version, err := getProviderVersion(news)
+	version, err := GetProviderVersion(news)
 	if err != nil {
 		return nil, []plugin.CheckFailure{{Property: "version", Reason: err.Error()}}, nil
 	}
-	provider, err := r.host.Provider(getProviderPackage(urn.Type()), version)
+	provider, err := r.host.Provider(GetProviderPackage(urn.Type()), version)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -352,6 +353,11 @@ func (r *Registry) Invoke(tok tokens.ModuleMember,
 	return nil, nil, errors.New("the provider registry is not invokable")
 }
 
+func (r *Registry) GetLogs(urn resource.URN, id resource.ID, props resource.PropertyMap,
+	startTime, endTime *time.Time) ([]plugin.LogEntry, error) {
+	return nil, errors.New("provider resources do not have logs")
+}
+
 func (r *Registry) GetPluginInfo() (workspace.PluginInfo, error) {
 	// return an error: this should not be called for the provider registry
 	return workspace.PluginInfo{}, errors.New("the provider registry does not report plugin info")
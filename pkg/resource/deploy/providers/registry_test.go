@@ -16,6 +16,7 @@ package providers
 
 import (
 	"testing"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
@@ -133,6 +134,10 @@ func (prov *testProvider) Invoke(tok tokens.ModuleMember,
 	args resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
 	return nil, nil, errors.New("unsupported")
 }
+func (prov *testProvider) GetLogs(urn resource.URN, id resource.ID, props resource.PropertyMap,
+	startTime, endTime *time.Time) ([]plugin.LogEntry, error) {
+	return nil, errors.New("unsupported")
+}
 func (prov *testProvider) GetPluginInfo() (workspace.PluginInfo, error) {
 	return workspace.PluginInfo{
 		Name:    "testProvider",
@@ -279,9 +284,9 @@ func TestNewRegistryOldState(t *testing.T) {
 
 		assert.True(t, p.(*testProvider).configured)
 
-		assert.Equal(t, getProviderPackage(old.Type), p.Pkg())
+		assert.Equal(t, GetProviderPackage(old.Type), p.Pkg())
 
-		ver, err := getProviderVersion(old.Inputs)
+		ver, err := GetProviderVersion(old.Inputs)
 		assert.NoError(t, err)
 		if ver != nil {
 			info, err := p.GetPluginInfo()
@@ -402,7 +407,7 @@ func TestCRUD(t *testing.T) {
 		assert.True(t, ok)
 		assert.NotNil(t, p)
 
-		assert.Equal(t, getProviderPackage(old.Type), p.Pkg())
+		assert.Equal(t, GetProviderPackage(old.Type), p.Pkg())
 	}
 
 	// Create a new provider for each package.
@@ -541,7 +546,7 @@ func TestCRUDPreview(t *testing.T) {
 		assert.True(t, ok)
 		assert.NotNil(t, p)
 
-		assert.Equal(t, getProviderPackage(old.Type), p.Pkg())
+		assert.Equal(t, GetProviderPackage(old.Type), p.Pkg())
 	}
 
 	// Create a new provider for each package.
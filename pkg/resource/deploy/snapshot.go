@@ -23,6 +23,7 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/workspace"
 )
 
@@ -140,3 +141,66 @@ func (snap *Snapshot) VerifyIntegrity() error {
 
 	return nil
 }
+
+// RenameStack returns a copy of this snapshot in which every resource belongs to the given stack.  Because the stack
+// name is part of each resource's URN, this rewrites each resource's URN along with its parent, dependency, and
+// provider references.  The root stack resource, which is named after the stack, is renamed to match, so that the
+// next update does not replace it.
+func (snap *Snapshot) RenameStack(newName tokens.QName) (*Snapshot, error) {
+	if snap == nil {
+		return nil, nil
+	}
+
+	renameURN := func(urn resource.URN) resource.URN {
+		if urn == "" {
+			return ""
+		}
+		if urn == resource.DefaultRootStackURN(urn.Stack(), urn.Project()) {
+			return resource.DefaultRootStackURN(newName, urn.Project())
+		}
+		return urn.RenameStack(newName)
+	}
+	renameState := func(state *resource.State) (*resource.State, error) {
+		var deps []resource.URN
+		for _, dep := range state.Dependencies {
+			deps = append(deps, renameURN(dep))
+		}
+
+		provider := state.Provider
+		if provider != "" {
+			ref, err := providers.ParseReference(provider)
+			if err != nil {
+				return nil, errors.Errorf("failed to parse provider reference for resource %s: %v", state.URN, err)
+			}
+			ref, err = providers.NewReference(renameURN(ref.URN()), ref.ID())
+			if err != nil {
+				return nil, err
+			}
+			provider = ref.String()
+		}
+
+		return resource.NewState(state.Type, renameURN(state.URN), state.Custom, state.Delete, state.ID,
+			state.Inputs, state.Outputs, renameURN(state.Parent), state.Protect, state.External, deps,
+			state.InitErrors, provider), nil
+	}
+
+	resources := make([]*resource.State, len(snap.Resources))
+	for i, state := range snap.Resources {
+		renamed, err := renameState(state)
+		if err != nil {
+			return nil, err
+		}
+		resources[i] = renamed
+	}
+
+	var ops []resource.Operation
+	for _, op := range snap.PendingOperations {
+		renamed, err := renameState(op.Resource)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, resource.NewOperation(renamed, op.Type))
+	}
+
+	return NewSnapshot(snap.Manifest, resources, ops), nil
+}
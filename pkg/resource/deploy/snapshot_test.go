@@ -0,0 +1,87 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func TestSnapshotRenameStack(t *testing.T) {
+	provType := providers.MakeProviderType("pkgA")
+	provURN := resource.NewURN("teststack", "pkg", "", provType, "default")
+	prov := &resource.State{
+		Type:    provType,
+		URN:     provURN,
+		Custom:  true,
+		ID:      "provid",
+		Inputs:  make(resource.PropertyMap),
+		Outputs: make(resource.PropertyMap),
+	}
+	provRef, err := providers.NewReference(provURN, "provid")
+	assert.NoError(t, err)
+
+	root := &resource.State{
+		Type:    resource.RootStackType,
+		URN:     resource.DefaultRootStackURN("teststack", "pkg"),
+		Inputs:  make(resource.PropertyMap),
+		Outputs: make(resource.PropertyMap),
+	}
+	resourceA := newResource("a")
+	resourceA.Parent = root.URN
+	resourceB := newResource("b")
+	resourceB.Parent = resourceA.URN
+	resourceB.Dependencies = []resource.URN{resourceA.URN}
+	resourceB.Provider = provRef.String()
+	resourceC := newResource("c")
+
+	snap := newSnapshot([]*resource.State{root, prov, resourceA, resourceB}, []resource.Operation{
+		resource.NewOperation(resourceC, resource.OperationTypeCreating),
+	})
+	snap.Manifest.Magic = snap.Manifest.NewMagic()
+
+	renamed, err := snap.RenameStack("newstack")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.NoError(t, renamed.VerifyIntegrity())
+
+	for _, res := range renamed.Resources {
+		assert.Equal(t, tokens.QName("newstack"), res.URN.Stack())
+	}
+
+	// The root stack resource is named after the stack, so it is renamed too.
+	assert.Equal(t, resource.DefaultRootStackURN("newstack", "pkg"), renamed.Resources[0].URN)
+	assert.Equal(t, renamed.Resources[0].URN, renamed.Resources[2].Parent)
+
+	b := renamed.Resources[3]
+	assert.Equal(t, renamed.Resources[2].URN, b.Parent)
+	assert.Equal(t, []resource.URN{renamed.Resources[2].URN}, b.Dependencies)
+	ref, err := providers.ParseReference(b.Provider)
+	assert.NoError(t, err)
+	assert.Equal(t, renamed.Resources[1].URN, ref.URN())
+	assert.Equal(t, resource.ID("provid"), ref.ID())
+	assert.Len(t, renamed.PendingOperations, 1)
+	assert.Equal(t, tokens.QName("newstack"), renamed.PendingOperations[0].Resource.URN.Stack())
+
+	// The original snapshot must be left untouched.
+	assert.Equal(t, tokens.QName("teststack"), snap.Resources[3].URN.Stack())
+	assert.Equal(t, resourceA.URN, snap.Resources[3].Parent)
+}
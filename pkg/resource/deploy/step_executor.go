@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/pkg/diag"
@@ -266,7 +267,9 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 	}
 
 	se.log(workerID, "applying step %v on %v (preview %v)", step.Op(), step.URN(), se.preview)
+	timing := StepTiming{Start: time.Now()}
 	status, stepComplete, err := step.Apply(se.preview)
+	timing.End = time.Now()
 
 	if err == nil {
 		// If we have a state object, and this is a create or update, remember it, as we may need to update it later.
@@ -281,7 +284,7 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 	}
 
 	if events != nil {
-		if postErr := events.OnResourceStepPost(payload, step, status, err); postErr != nil {
+		if postErr := events.OnResourceStepPost(payload, step, status, err, timing); postErr != nil {
 			se.log(workerID, "step %v on %v failed post-resource step: %v", step.Op(), step.URN(), postErr)
 			return errors.Wrap(postErr, "post-step event returned an error")
 		}
@@ -20,6 +20,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/resource/deploy/providers"
 	"github.com/pulumi/pulumi/pkg/resource/graph"
 	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/logging"
 	"github.com/pulumi/pulumi/pkg/util/result"
@@ -41,11 +42,21 @@ type stepGenerator struct {
 	creates        map[resource.URN]bool    // set of URNs created in this plan
 	sames          map[resource.URN]bool    // set of URNs that were not changed in this plan
 	pendingDeletes map[*resource.State]bool // set of resources (not URNs!) that are pending deletion
+
+	analyzed []plugin.AnalyzerResource // the resources registered or read by the program, for stack analysis
 }
 
 // GenerateReadSteps is responsible for producing one or more steps required to service
 // a ReadResourceEvent coming from the language host.
 func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, *result.Result) {
+	steps, res := sg.generateReadSteps(event)
+	if res != nil {
+		return nil, res
+	}
+	return sg.checkApproved(steps)
+}
+
+func (sg *stepGenerator) generateReadSteps(event ReadResourceEvent) ([]Step, *result.Result) {
 	urn := sg.plan.generateURN(event.Parent(), event.Type(), event.Name())
 	newState := resource.NewState(event.Type(),
 		urn,
@@ -79,6 +90,7 @@ func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, *re
 		logging.V(7).Infof(
 			"stepGenerator.GenerateReadSteps(...): replacing existing resource %s, ids don't match", urn)
 		sg.replaces[urn] = true
+		sg.recordRead(newState)
 		return []Step{
 			NewReadReplacementStep(sg.plan, event, old, newState),
 			NewReplaceStep(sg.plan, old, newState, nil, true),
@@ -90,6 +102,7 @@ func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, *re
 	}
 
 	sg.reads[urn] = true
+	sg.recordRead(newState)
 	return []Step{
 		NewReadStep(sg.plan, event, old, newState),
 	}, nil
@@ -102,6 +115,14 @@ func (sg *stepGenerator) GenerateReadSteps(event ReadResourceEvent) ([]Step, *re
 // and Check on the provider associated with that resource. If those fail, an error
 // is returned.
 func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *result.Result) {
+	steps, res := sg.generateSteps(event)
+	if res != nil {
+		return nil, res
+	}
+	return sg.checkApproved(steps)
+}
+
+func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, *result.Result) {
 	var invalid bool // will be set to true if this object fails validation.
 
 	goal := event.Goal()
@@ -183,23 +204,33 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 	}
 
 	// Next, give each analyzer -- if any -- a chance to inspect the resource too.
-	for _, a := range sg.plan.analyzers {
-		var analyzer plugin.Analyzer
-		analyzer, err = sg.plan.ctx.Host.Analyzer(a)
-		if err != nil {
-			return nil, result.FromError(err)
-		} else if analyzer == nil {
-			return nil, result.Errorf("analyzer '%v' could not be loaded from your $PATH", a)
+	if len(sg.plan.analyzers) > 0 {
+		r := plugin.AnalyzerResource{
+			URN:          urn,
+			Type:         new.Type,
+			Properties:   inputs,
+			Parent:       goal.Parent,
+			Dependencies: goal.Dependencies,
+			Provider:     goal.Provider,
+			Custom:       goal.Custom,
 		}
-		var failures []plugin.AnalyzeFailure
-		failures, err = analyzer.Analyze(new.Type, inputs)
-		if err != nil {
-			return nil, result.FromError(err)
+		if hasOld && !recreating && !wasExternal {
+			r.OldProperties = oldInputs
 		}
-		for _, failure := range failures {
-			invalid = true
-			sg.plan.Diag().Errorf(
-				diag.GetAnalyzeResourceFailureError(urn), a, urn, failure.Property, failure.Reason)
+		sg.analyzed = append(sg.analyzed, r)
+
+		for _, a := range sg.plan.analyzers {
+			analyzer, res := sg.loadAnalyzer(a)
+			if res != nil {
+				return nil, res
+			}
+			failures, analyzeErr := analyzer.Analyze(r)
+			if analyzeErr != nil {
+				return nil, result.FromError(analyzeErr)
+			}
+			if sg.reportAnalyzeFailures(a, urn, failures, false /*stack*/) {
+				invalid = true
+			}
 		}
 	}
 
@@ -402,7 +433,9 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, *re
 	return []Step{NewCreateStep(sg.plan, event, new)}, nil
 }
 
-func (sg *stepGenerator) GenerateDeletes() []Step {
+// GenerateDeletes produces delete steps for all resources that were present in the previous snapshot but were not
+// registered by the program, as well as for any resources that were replaced and are now pending deletion.
+func (sg *stepGenerator) GenerateDeletes() ([]Step, *result.Result) {
 	// To compute the deletion list, we must walk the list of old resources *backwards*.  This is because the list is
 	// stored in dependency order, and earlier elements are possibly leaf nodes for later elements.  We must not delete
 	// dependencies prior to their dependent nodes.
@@ -451,7 +484,106 @@ func (sg *stepGenerator) GenerateDeletes() []Step {
 			}
 		}
 	}
-	return dels
+	return sg.checkApproved(dels)
+}
+
+// AnalyzeStack gives each analyzer -- if any -- a chance to inspect all of the resources that the program registered
+// or read at once.  This must be called after the program has finished, but before any deletes are generated.  If any
+// analyzer reports a mandatory failure, the returned result indicates that the plan should not proceed.
+func (sg *stepGenerator) AnalyzeStack() *result.Result {
+	invalid := false
+	for _, a := range sg.plan.analyzers {
+		analyzer, res := sg.loadAnalyzer(a)
+		if res != nil {
+			return res
+		}
+		failures, err := analyzer.AnalyzeStack(sg.analyzed)
+		if err != nil {
+			return result.FromError(err)
+		}
+		if sg.reportAnalyzeFailures(a, "", failures, true /*stack*/) {
+			invalid = true
+		}
+	}
+	if invalid {
+		return result.Bail()
+	}
+	return nil
+}
+
+// recordRead records a resource read by the program so that it may be included in stack analysis.
+func (sg *stepGenerator) recordRead(state *resource.State) {
+	if len(sg.plan.analyzers) == 0 {
+		return
+	}
+	sg.analyzed = append(sg.analyzed, plugin.AnalyzerResource{
+		URN:          state.URN,
+		Type:         state.Type,
+		Properties:   state.Inputs,
+		Parent:       state.Parent,
+		Dependencies: state.Dependencies,
+		Provider:     state.Provider,
+		Custom:       state.Custom,
+	})
+}
+
+// loadAnalyzer loads the analyzer with the given name.
+func (sg *stepGenerator) loadAnalyzer(name tokens.QName) (plugin.Analyzer, *result.Result) {
+	analyzer, err := sg.plan.ctx.Host.Analyzer(name)
+	if err != nil {
+		return nil, result.FromError(err)
+	} else if analyzer == nil {
+		return nil, result.Errorf("analyzer '%v' could not be loaded from your $PATH", name)
+	}
+	return analyzer, nil
+}
+
+// reportAnalyzeFailures reports the failures returned by an analyzer, and returns true if any of them are mandatory.
+// If the plan has an events callback, each failure is reported to it as a policy violation; otherwise, mandatory
+// failures are issued as errors and advisory ones as warnings.
+func (sg *stepGenerator) reportAnalyzeFailures(name tokens.QName, urn resource.URN, failures []plugin.AnalyzeFailure,
+	stack bool) bool {
+
+	mandatory := false
+	for _, failure := range failures {
+		if failure.URN == "" {
+			failure.URN = urn
+		}
+		if failure.EnforcementLevel != plugin.Advisory {
+			mandatory = true
+		}
+
+		if e := sg.opts.Events; e != nil {
+			e.OnPolicyViolation(name, failure)
+			continue
+		}
+
+		switch {
+		case failure.EnforcementLevel == plugin.Advisory:
+			sg.plan.Diag().Warningf(diag.GetAnalyzeResourceAdvisoryWarning(failure.URN),
+				name, failure.URN, failure.Property, failure.Reason)
+		case stack:
+			sg.plan.Diag().Errorf(diag.GetAnalyzeStackFailureError(failure.URN),
+				name, failure.URN, failure.Property, failure.Reason)
+		default:
+			sg.plan.Diag().Errorf(diag.GetAnalyzeResourceFailureError(failure.URN),
+				name, failure.URN, failure.Property, failure.Reason)
+		}
+	}
+	return mandatory
+}
+
+// checkApproved ensures that each of the given steps is allowed by the approved plan, if there is one.
+func (sg *stepGenerator) checkApproved(steps []Step) ([]Step, *result.Result) {
+	if sg.opts.ApprovedPlan == nil {
+		return steps, nil
+	}
+	for _, step := range steps {
+		if err := sg.opts.ApprovedPlan.CheckStep(step); err != nil {
+			return nil, result.FromError(err)
+		}
+	}
+	return steps, nil
 }
 
 // GeneratePendingDeletes generates delete steps for all resources that are pending deletion. This function should be
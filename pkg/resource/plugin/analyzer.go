@@ -31,13 +31,43 @@ type Analyzer interface {
 	// Name fetches an analyzer's qualified name.
 	Name() tokens.QName
 	// Analyze analyzes a single resource object, and returns any errors that it finds.
-	Analyze(t tokens.Type, props resource.PropertyMap) ([]AnalyzeFailure, error)
+	Analyze(r AnalyzerResource) ([]AnalyzeFailure, error)
+	// AnalyzeStack analyzes all resources in a stack at once, after the program has registered all of them, and returns
+	// any errors that it finds.  Before an update changes anything, the engine runs the program as a preview and
+	// analyzes the resources it proposes, so that mandatory failures block the update before any step executes.
+	AnalyzeStack(resources []AnalyzerResource) ([]AnalyzeFailure, error)
 	// GetPluginInfo returns this plugin's information.
 	GetPluginInfo() (workspace.PluginInfo, error)
+	// Configure passes the analyzer its settings from the `policy` section of the project and stack files.
+	Configure(config resource.PropertyMap) error
 }
 
+// AnalyzerResource describes a resource to be analyzed, along with the context in which it is being deployed.
+type AnalyzerResource struct {
+	URN           resource.URN         // the URN of the resource.
+	Type          tokens.Type          // the type of the resource.
+	Properties    resource.PropertyMap // the resource's proposed input properties.
+	OldProperties resource.PropertyMap // the resource's current input properties, or nil if it does not yet exist.
+	Parent        resource.URN         // the URN of the resource's parent, if any.
+	Dependencies  []resource.URN       // the URNs of the resources this resource depends on.
+	Provider      string               // a reference to the provider that manages the resource, if any.
+	Custom        bool                 // true if the resource is managed by a provider.
+}
+
+// EnforcementLevel indicates how the engine treats an analysis failure.
+type EnforcementLevel string
+
+const (
+	// Mandatory failures are errors that prevent an update from proceeding.
+	Mandatory EnforcementLevel = "mandatory"
+	// Advisory failures are reported as warnings, but do not prevent an update from proceeding.
+	Advisory EnforcementLevel = "advisory"
+)
+
 // AnalyzeFailure indicates that resource analysis failed; it contains the property and reason for the failure.
 type AnalyzeFailure struct {
-	Property resource.PropertyKey // the property that failed the analysis.
-	Reason   string               // the reason the property failed the analysis.
+	Property         resource.PropertyKey // the property that failed the analysis.
+	Reason           string               // the reason the property failed the analysis.
+	EnforcementLevel EnforcementLevel     // how the engine treats this failure.
+	URN              resource.URN         // the resource that failed a stack analysis (or "" if general).
 }
@@ -20,6 +20,8 @@ import (
 
 	"github.com/blang/semver"
 	pbempty "github.com/golang/protobuf/ptypes/empty"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	"google.golang.org/grpc/codes"
 
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/tokens"
@@ -75,17 +77,23 @@ func (a *analyzer) label() string {
 }
 
 // Analyze analyzes a single resource object, and returns any errors that it finds.
-func (a *analyzer) Analyze(t tokens.Type, props resource.PropertyMap) ([]AnalyzeFailure, error) {
-	label := fmt.Sprintf("%s.Analyze(%s)", a.label(), t)
-	logging.V(7).Infof("%s executing (#props=%d)", label, len(props))
-	mprops, err := MarshalProperties(props, MarshalOptions{})
+func (a *analyzer) Analyze(r AnalyzerResource) ([]AnalyzeFailure, error) {
+	label := fmt.Sprintf("%s.Analyze(%s)", a.label(), r.Type)
+	logging.V(7).Infof("%s executing (#props=%d)", label, len(r.Properties))
+	req, err := marshalAnalyzerResource(r)
 	if err != nil {
 		return nil, err
 	}
 
 	resp, err := a.client.Analyze(a.ctx.Request(), &pulumirpc.AnalyzeRequest{
-		Type:       string(t),
-		Properties: mprops,
+		Type:          req.Type,
+		Properties:    req.Properties,
+		Urn:           req.Urn,
+		Parent:        req.Parent,
+		Dependencies:  req.Dependencies,
+		Provider:      req.Provider,
+		Custom:        req.Custom,
+		OldProperties: req.OldProperties,
 	})
 	if err != nil {
 		rpcError := rpcerror.Convert(err)
@@ -93,17 +101,91 @@ func (a *analyzer) Analyze(t tokens.Type, props resource.PropertyMap) ([]Analyze
 		return nil, rpcError
 	}
 
-	var failures []AnalyzeFailure
-	for _, failure := range resp.GetFailures() {
-		failures = append(failures, AnalyzeFailure{
-			Property: resource.PropertyKey(failure.Property),
-			Reason:   failure.Reason,
-		})
+	failures := unmarshalAnalyzeFailures(resp.GetFailures())
+	logging.V(7).Infof("%s success: failures=#%d", label, len(failures))
+	return failures, nil
+}
+
+// AnalyzeStack analyzes all resources in a stack at once, and returns any errors that it finds.
+func (a *analyzer) AnalyzeStack(resources []AnalyzerResource) ([]AnalyzeFailure, error) {
+	label := fmt.Sprintf("%s.AnalyzeStack()", a.label())
+	logging.V(7).Infof("%s executing (#resources=%d)", label, len(resources))
+
+	var reqs []*pulumirpc.AnalyzerResource
+	for _, r := range resources {
+		req, err := marshalAnalyzerResource(r)
+		if err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, req)
 	}
+
+	resp, err := a.client.AnalyzeStack(a.ctx.Request(), &pulumirpc.AnalyzeStackRequest{Resources: reqs})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		logging.V(7).Infof("%s failed: err=%v", label, rpcError)
+
+		// Older analyzers only know how to analyze individual resources.  In such cases, there is nothing to report.
+		if rpcError.Code() == codes.Unimplemented {
+			return nil, nil
+		}
+
+		return nil, rpcError
+	}
+
+	failures := unmarshalAnalyzeFailures(resp.GetFailures())
 	logging.V(7).Infof("%s success: failures=#%d", label, len(failures))
 	return failures, nil
 }
 
+// marshalAnalyzerResource converts a resource into its RPC representation.
+func marshalAnalyzerResource(r AnalyzerResource) (*pulumirpc.AnalyzerResource, error) {
+	props, err := MarshalProperties(r.Properties, MarshalOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var oldProps *_struct.Struct
+	if r.OldProperties != nil {
+		if oldProps, err = MarshalProperties(r.OldProperties, MarshalOptions{}); err != nil {
+			return nil, err
+		}
+	}
+
+	var deps []string
+	for _, dep := range r.Dependencies {
+		deps = append(deps, string(dep))
+	}
+
+	return &pulumirpc.AnalyzerResource{
+		Type:          string(r.Type),
+		Properties:    props,
+		Urn:           string(r.URN),
+		Parent:        string(r.Parent),
+		Dependencies:  deps,
+		Provider:      r.Provider,
+		Custom:        r.Custom,
+		OldProperties: oldProps,
+	}, nil
+}
+
+// unmarshalAnalyzeFailures converts the failures returned by an analyzer into their engine representation.
+func unmarshalAnalyzeFailures(failures []*pulumirpc.AnalyzeFailure) []AnalyzeFailure {
+	var results []AnalyzeFailure
+	for _, failure := range failures {
+		level := Mandatory
+		if failure.GetEnforcementLevel() == pulumirpc.EnforcementLevel_ADVISORY {
+			level = Advisory
+		}
+		results = append(results, AnalyzeFailure{
+			Property:         resource.PropertyKey(failure.GetProperty()),
+			Reason:           failure.GetReason(),
+			EnforcementLevel: level,
+			URN:              resource.URN(failure.GetUrn()),
+		})
+	}
+	return results
+}
+
 // GetPluginInfo returns this plugin's information.
 func (a *analyzer) GetPluginInfo() (workspace.PluginInfo, error) {
 	label := fmt.Sprintf("%s.GetPluginInfo()", a.label())
@@ -132,6 +214,31 @@ func (a *analyzer) GetPluginInfo() (workspace.PluginInfo, error) {
 	}, nil
 }
 
+// Configure passes the analyzer its settings from the `policy` section of the project and stack files.
+func (a *analyzer) Configure(config resource.PropertyMap) error {
+	label := fmt.Sprintf("%s.Configure()", a.label())
+	logging.V(7).Infof("%s executing (#config=%d)", label, len(config))
+	mconfig, err := MarshalProperties(config, MarshalOptions{Label: label})
+	if err != nil {
+		return err
+	}
+
+	if _, err = a.client.Configure(a.ctx.Request(), &pulumirpc.ConfigureAnalyzerRequest{Config: mconfig}); err != nil {
+		rpcError := rpcerror.Convert(err)
+		logging.V(7).Infof("%s failed: err=%v", label, rpcError)
+
+		// Older analyzers do not accept any settings.  In such cases, there is nothing to configure.
+		if rpcError.Code() == codes.Unimplemented {
+			return nil
+		}
+
+		return rpcError
+	}
+
+	logging.V(7).Infof("%s success", label)
+	return nil
+}
+
 // Close tears down the underlying plugin RPC connection and process.
 func (a *analyzer) Close() error {
 	return a.plug.Close()
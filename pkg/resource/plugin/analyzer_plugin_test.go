@@ -0,0 +1,74 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+func TestMarshalAnalyzerResource(t *testing.T) {
+	r := AnalyzerResource{
+		URN:          "urn:pulumi:test::proj::pkg:index:Bucket::logs",
+		Type:         "pkg:index:Bucket",
+		Properties:   resource.PropertyMap{"acl": resource.NewStringProperty("private")},
+		Parent:       "urn:pulumi:test::proj::pulumi:pulumi:Stack::proj-test",
+		Dependencies: []resource.URN{"urn:pulumi:test::proj::pkg:index:Bucket::data"},
+		Provider:     "urn:pulumi:test::proj::pulumi:providers:pkg::default::id",
+		Custom:       true,
+	}
+
+	req, err := marshalAnalyzerResource(r)
+	assert.NoError(t, err)
+	assert.Equal(t, string(r.URN), req.Urn)
+	assert.Equal(t, string(r.Type), req.Type)
+	assert.Equal(t, string(r.Parent), req.Parent)
+	assert.Equal(t, []string{"urn:pulumi:test::proj::pkg:index:Bucket::data"}, req.Dependencies)
+	assert.Equal(t, r.Provider, req.Provider)
+	assert.True(t, req.Custom)
+	assert.Equal(t, "private", req.Properties.Fields["acl"].GetStringValue())
+
+	// A resource that does not exist yet has no old properties.
+	assert.Nil(t, req.OldProperties)
+
+	r.OldProperties = resource.PropertyMap{"acl": resource.NewStringProperty("public-read")}
+	req, err = marshalAnalyzerResource(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "public-read", req.OldProperties.Fields["acl"].GetStringValue())
+}
+
+func TestUnmarshalAnalyzeFailures(t *testing.T) {
+	failures := unmarshalAnalyzeFailures([]*pulumirpc.AnalyzeFailure{
+		{Property: "acl", Reason: "buckets must be private"},
+		{
+			Reason:           "every bucket needs an access-log bucket",
+			EnforcementLevel: pulumirpc.EnforcementLevel_ADVISORY,
+			Urn:              "urn:pulumi:test::proj::pkg:index:Bucket::data",
+		},
+	})
+
+	assert.Equal(t, []AnalyzeFailure{
+		{Property: "acl", Reason: "buckets must be private", EnforcementLevel: Mandatory},
+		{
+			Reason:           "every bucket needs an access-log bucket",
+			EnforcementLevel: Advisory,
+			URN:              "urn:pulumi:test::proj::pkg:index:Bucket::data",
+		},
+	}, failures)
+}
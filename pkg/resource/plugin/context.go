@@ -21,6 +21,7 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/diag"
 	"github.com/pulumi/pulumi/pkg/util/rpcutil"
+	"github.com/pulumi/pulumi/pkg/workspace"
 )
 
 // Context is used to group related operations together so that associated OS resources can be cached, shared, and
@@ -31,6 +32,9 @@ type Context struct {
 	Host       Host      // the host that can be used to fetch providers.
 	Pwd        string    // the working directory to spawn all plugins in.
 
+	// Lock, if non-nil, restricts the plugins that may be loaded to exactly the versions it pins.
+	Lock *workspace.Lock
+
 	tracingSpan opentracing.Span // the OpenTracing span to parent requests within.
 }
 
@@ -16,6 +16,7 @@ package plugin
 
 import (
 	"os"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/hashicorp/go-multierror"
@@ -198,6 +199,7 @@ func (host *defaultHost) Analyzer(name tokens.QName) (Analyzer, error) {
 
 			// Memoize the result.
 			host.plugins = append(host.plugins, info)
+			host.recordPluginUse(info)
 			host.analyzerPlugins[name] = &analyzerPlugin{Plugin: plug, Info: info}
 			if host.events != nil {
 				if eventerr := host.events.OnPluginLoad(info); eventerr != nil {
@@ -216,14 +218,29 @@ func (host *defaultHost) Analyzer(name tokens.QName) (Analyzer, error) {
 
 func (host *defaultHost) Provider(pkg tokens.Package, version *semver.Version) (Provider, error) {
 	plugin, err := host.loadPlugin(func() (interface{}, error) {
-		// Try to load and bind to a plugin.
-		plug, err := NewProvider(host, host.ctx, pkg, version)
+		// Try to load and bind to a plugin, restricting ourselves to the locked version if there is a lock.
+		var plug Provider
+		var err error
+		if host.ctx.Lock != nil {
+			plug, err = NewLockedProvider(host, host.ctx, pkg, version, host.ctx.Lock)
+		} else {
+			plug, err = NewProvider(host, host.ctx, pkg, version)
+		}
 		if err == nil && plug != nil {
 			info, infoerr := plug.GetPluginInfo()
 			if infoerr != nil {
 				return nil, infoerr
 			}
 
+			// A locked plugin must report exactly the version it was locked at.
+			if locked, ok := host.ctx.Lock.Find(workspace.ResourcePlugin, string(pkg), version); ok {
+				if info.Version == nil || info.Version.String() != locked.Version {
+					contract.IgnoreError(plug.Close())
+					return nil, errors.Errorf("resource plugin %s reports version %v, but %s pins version %s",
+						pkg, info.Version, workspace.LockFile, locked.Version)
+				}
+			}
+
 			// Warn if the plugin version was not what we expected
 			if version != nil && !cmdutil.IsTruthy(os.Getenv("PULUMI_DEV")) {
 				if info.Version == nil || !info.Version.GTE(*version) {
@@ -249,6 +266,7 @@ func (host *defaultHost) Provider(pkg tokens.Package, version *semver.Version) (
 			if !alreadyReported {
 				host.reportedResourcePlugins[key] = struct{}{}
 				host.plugins = append(host.plugins, info)
+				host.recordPluginUse(info)
 			}
 			host.resourcePlugins[plug] = &resourcePlugin{Plugin: plug, Info: info}
 			if host.events != nil && !alreadyReported {
@@ -284,6 +302,7 @@ func (host *defaultHost) LanguageRuntime(runtime string) (LanguageRuntime, error
 
 			// Memoize the result.
 			host.plugins = append(host.plugins, info)
+			host.recordPluginUse(info)
 			host.languagePlugins[runtime] = &languagePlugin{Plugin: plug, Info: info}
 			if host.events != nil {
 				if eventerr := host.events.OnPluginLoad(info); eventerr != nil {
@@ -300,16 +319,34 @@ func (host *defaultHost) LanguageRuntime(runtime string) (LanguageRuntime, error
 	return plugin.(LanguageRuntime), nil
 }
 
+// recordPluginUse notes that a plugin was loaded so that `pulumi plugin prune` knows it is still in use.  Failing to
+// record a use is not worth failing the operation over.
+func (host *defaultHost) recordPluginUse(info workspace.PluginInfo) {
+	if err := workspace.RecordPluginUse(info, time.Now()); err != nil {
+		logging.V(5).Infof("failed to record use of %s plugin %s: %v", info.Kind, info, err)
+	}
+}
+
 func (host *defaultHost) ListPlugins() []workspace.PluginInfo {
 	return host.plugins
 }
 
-// EnsurePlugins ensures all plugins in the given array are loaded and ready to use.  If any plugins are missing,
-// and/or there are errors loading one or more plugins, a non-nil error is returned.
+// EnsurePlugins ensures all plugins in the given array are loaded and ready to use.  Missing plugins are first
+// installed from any configured plugin mirrors.  If any plugins are still missing, and/or there are errors loading one
+// or more plugins, a non-nil error is returned.
 func (host *defaultHost) EnsurePlugins(plugins []workspace.PluginInfo, kinds Flags) error {
 	// Use a multieerror to track failures so we can return one big list of all failures at the end.
 	var result error
+	mirrors := workspace.GetPluginMirrors(host.ctx.Pwd)
 	for _, plugin := range plugins {
+		// If mirrors are configured, fetch any missing plugins from them before trying to load anything.
+		if len(mirrors) > 0 {
+			if err := host.installFromMirrors(plugin, kinds, mirrors); err != nil {
+				result = multierror.Append(result, err)
+				continue
+			}
+		}
+
 		switch plugin.Kind {
 		case workspace.AnalyzerPlugin:
 			if kinds&AnalyzerPlugins != 0 {
@@ -340,6 +377,38 @@ func (host *defaultHost) EnsurePlugins(plugins []workspace.PluginInfo, kinds Fla
 	return result
 }
 
+// installFromMirrors installs a wanted resource or analyzer plugin from the given mirrors if no suitable version is
+// already installed: the locked version if plugins are locked, or otherwise the requested version or newer.  Language
+// plugins ship alongside the CLI, so they are never fetched.
+func (host *defaultHost) installFromMirrors(plugin workspace.PluginInfo, kinds Flags, mirrors []string) error {
+	switch {
+	case plugin.Kind == workspace.ResourcePlugin && kinds&ResourcePlugins != 0:
+	case plugin.Kind == workspace.AnalyzerPlugin && kinds&AnalyzerPlugins != 0:
+	default:
+		return nil
+	}
+
+	var checksum string
+	if locked, ok := host.ctx.Lock.Find(plugin.Kind, plugin.Name, plugin.Version); ok {
+		plugin, checksum = locked.Info(), locked.Checksum()
+		if workspace.HasPlugin(plugin) {
+			return nil
+		}
+	} else if plugin.Version == nil {
+		return nil
+	} else if has, err := workspace.HasPluginGTE(plugin); err != nil || has {
+		return err
+	}
+
+	host.ctx.StatusDiag.Infoerrf(diag.Message("", "[%s plugin %s] installing from mirror"), plugin.Kind, plugin)
+	source, err := plugin.InstallFromMirrors(mirrors, checksum)
+	if err != nil {
+		return errors.Wrapf(err, "failed to install %s plugin %s", plugin.Kind, plugin)
+	}
+	logging.V(5).Infof("installed %s plugin %s from %s", plugin.Kind, plugin, source)
+	return nil
+}
+
 // GetRequiredPlugins lists a full set of plugins that will be required by the given program.
 func (host *defaultHost) GetRequiredPlugins(info ProgInfo, kinds Flags) ([]workspace.PluginInfo, error) {
 	var plugins []workspace.PluginInfo
@@ -16,6 +16,7 @@ package plugin
 
 import (
 	"io"
+	"time"
 
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/tokens"
@@ -68,6 +69,10 @@ type Provider interface {
 	Invoke(tok tokens.ModuleMember, args resource.PropertyMap) (resource.PropertyMap, []CheckFailure, error)
 	// GetPluginInfo returns this plugin's information.
 	GetPluginInfo() (workspace.PluginInfo, error)
+	// GetLogs returns the log entries emitted by a resource, optionally restricted to those emitted at or after
+	// startTime and before endTime.  If the provider does not serve logs for the resource, the result is nil.
+	GetLogs(urn resource.URN, id resource.ID, props resource.PropertyMap,
+		startTime, endTime *time.Time) ([]LogEntry, error)
 
 	// SignalCancellation asks all resource providers to gracefully shut down and abort any ongoing
 	// operations. Operation aborted in this way will return an error (e.g., `Update` and `Create`
@@ -77,6 +82,13 @@ type Provider interface {
 	SignalCancellation() error
 }
 
+// LogEntry is a single log entry emitted by a resource.
+type LogEntry struct {
+	ID        string // an identifier for the source of the entry, such as a log stream or container.
+	Timestamp int64  // the time the entry was emitted, in milliseconds since the Unix epoch.
+	Message   string // the text of the entry.
+}
+
 // CheckFailure indicates that a call to check failed; it contains the property and reason for the failure.
 type CheckFailure struct {
 	Property resource.PropertyKey // the property that failed checking.
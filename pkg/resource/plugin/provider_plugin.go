@@ -17,6 +17,7 @@ package plugin
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/blang/semver"
 	pbempty "github.com/golang/protobuf/ptypes/empty"
@@ -60,6 +61,30 @@ func NewProvider(host Host, ctx *Context, pkg tokens.Package, version *semver.Ve
 		})
 	}
 
+	return newProviderFromPath(host, ctx, pkg, path)
+}
+
+// NewLockedProvider loads exactly the requested version of a resource provider plugin, refusing to load it if the
+// given lock does not pin that version, or if it was not installed from the tarball the lock pins.  An unversioned
+// request loads the newest locked version.
+func NewLockedProvider(host Host, ctx *Context, pkg tokens.Package, version *semver.Version,
+	lock *workspace.Lock) (Provider, error) {
+	locked, ok := lock.Find(workspace.ResourcePlugin, string(pkg), version)
+	if !ok && version != nil {
+		return nil, errors.Errorf("resource plugin %s version %s was requested, but it is not pinned in %s",
+			pkg, version, workspace.LockFile)
+	} else if !ok {
+		return nil, errors.Errorf("resource plugin %s is not pinned in %s", pkg, workspace.LockFile)
+	}
+	path, err := locked.Verify()
+	if err != nil {
+		return nil, err
+	}
+	return newProviderFromPath(host, ctx, pkg, path)
+}
+
+// newProviderFromPath launches the resource provider plugin executable at the given path.
+func newProviderFromPath(host Host, ctx *Context, pkg tokens.Package, path string) (Provider, error) {
 	plug, err := newPlugin(ctx, path, fmt.Sprintf("%v (resource)", pkg), []string{host.ServerAddr()})
 	if err != nil {
 		return nil, err
@@ -641,6 +666,70 @@ func (p *provider) GetPluginInfo() (workspace.PluginInfo, error) {
 	}, nil
 }
 
+// GetLogs returns the log entries emitted by a resource, or nil if the provider does not serve logs for it.
+func (p *provider) GetLogs(urn resource.URN, id resource.ID, props resource.PropertyMap,
+	startTime, endTime *time.Time) ([]LogEntry, error) {
+	contract.Assert(urn != "")
+	contract.Assert(id != "")
+
+	label := fmt.Sprintf("%s.GetLogs(%s,%s)", p.label(), id, urn)
+	logging.V(7).Infof("%s executing (#props=%v)", label, len(props))
+
+	// Get the RPC client and ensure it's configured.
+	client, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// If the provider is not fully configured, it cannot serve any logs.
+	if !p.cfgknown {
+		return nil, nil
+	}
+
+	marshaled, err := MarshalProperties(props, MarshalOptions{Label: label, ElideAssetContents: true})
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pulumirpc.GetLogsRequest{
+		Id:         string(id),
+		Urn:        string(urn),
+		Properties: marshaled,
+	}
+	if startTime != nil {
+		req.StartTime = startTime.UnixNano() / int64(time.Millisecond)
+	}
+	if endTime != nil {
+		req.EndTime = endTime.UnixNano() / int64(time.Millisecond)
+	}
+
+	resp, err := client.GetLogs(p.ctx.Request(), req)
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		logging.V(7).Infof("%s failed: %v", label, rpcError.Message())
+
+		// Serving logs is optional.  Providers that do not serve logs for this resource, including those that
+		// predate the GetLogs method, have nothing to report.
+		if rpcError.Code() == codes.Unimplemented {
+			return nil, nil
+		}
+
+		return nil, rpcError
+	}
+
+	entries := make([]LogEntry, 0, len(resp.GetEntries()))
+	for _, entry := range resp.GetEntries() {
+		entries = append(entries, LogEntry{
+			ID:        entry.GetId(),
+			Timestamp: entry.GetTimestamp(),
+			Message:   entry.GetMessage(),
+		})
+	}
+
+	logging.V(7).Infof("%s success (#entries=%d)", label, len(entries))
+	return entries, nil
+}
+
 func (p *provider) SignalCancellation() error {
 	_, err := p.clientRaw.Cancel(p.ctx.Request(), &pbempty.Empty{})
 	if err != nil {
@@ -102,3 +102,10 @@ func (urn URN) Type() tokens.Type {
 func (urn URN) Name() tokens.QName {
 	return tokens.QName(strings.Split(urn.URNName(), URNNameDelimiter)[3])
 }
+
+// RenameStack returns a copy of the URN with its stack part replaced by the given stack name.
+func (urn URN) RenameStack(stack tokens.QName) URN {
+	parts := strings.SplitN(urn.URNName(), URNNameDelimiter, 2)
+	contract.Assertf(len(parts) == 2, "Urn is: '%s'", string(urn))
+	return URN(URNPrefix + string(stack) + URNNameDelimiter + parts[1])
+}
@@ -49,3 +49,16 @@ func TestURNRoundTripping2(t *testing.T) {
 	assert.Equal(t, typ, urn.Type())
 	assert.Equal(t, name, urn.Name())
 }
+
+func TestURNRenameStack(t *testing.T) {
+	proj := tokens.PackageName("foo/bar/baz")
+	parentType := tokens.Type("parent$type")
+	typ := tokens.Type("bang:boom/fizzle:MajorResource")
+	name := tokens.QName("a-swell-resource")
+	urn := NewURN("stck", proj, parentType, typ, name)
+	renamed := urn.RenameStack("other-stck")
+	assert.Equal(t, NewURN("other-stck", proj, parentType, typ, name), renamed)
+	assert.Equal(t, tokens.QName("other-stck"), renamed.Stack())
+	assert.Equal(t, proj, renamed.Project())
+	assert.Equal(t, name, renamed.Name())
+}
@@ -16,6 +16,8 @@ package ciutil
 
 import (
 	"os"
+	"regexp"
+	"strings"
 )
 
 // Vars contains a set of metadata variables about a CI system.
@@ -30,6 +32,33 @@ type Vars struct {
 	BuildURL string
 	// SHA is the SHA hash of the code repo at which this build/job is running.
 	SHA string
+	// PRNumber is the optional number of the pull (or merge) request this build/job is running for.
+	PRNumber string
+	// BranchName is the optional name of the branch this build/job is running for. For pull requests, this is
+	// the branch being merged.
+	BranchName string
+}
+
+// trailingNumberRegex matches the number at the end of a pull request URL or Git reference.
+var trailingNumberRegex = regexp.MustCompile(`/([0-9]+)(/merge|/head)?$`)
+
+// prNumber returns the value of the named environment variable if it holds a pull request number. CI systems
+// variously leave it empty or set it to "false" for builds that aren't for a pull request.
+func prNumber(envVar string) string {
+	if v := os.Getenv(envVar); v != "" && v != "false" {
+		return v
+	}
+	return ""
+}
+
+// firstEnv returns the value of the first of the named environment variables that is set.
+func firstEnv(envVars ...string) string {
+	for _, e := range envVars {
+		if v := os.Getenv(e); v != "" {
+			return v
+		}
+	}
+	return ""
 }
 
 // DetectVars detects and returns the CI variables for the current environment.
@@ -39,17 +68,51 @@ func DetectVars() Vars {
 	// try to detect some additional CI-specific metadata that the CLI will use. It's okay if we can't,
 	// we'll just have reduced functionality for our various CI integrations.
 	switch v.Name {
+	case AppVeyor:
+		// We are running in AppVeyor. See https://www.appveyor.com/docs/environment-variables/.
+		v.PRNumber = prNumber("APPVEYOR_PULL_REQUEST_NUMBER")
+		v.BranchName = firstEnv("APPVEYOR_PULL_REQUEST_HEAD_REPO_BRANCH", "APPVEYOR_REPO_BRANCH")
+	case Buildkite:
+		// We are running in Buildkite. See https://buildkite.com/docs/pipelines/environment-variables.
+		v.PRNumber = prNumber("BUILDKITE_PULL_REQUEST")
+		v.BranchName = os.Getenv("BUILDKITE_BRANCH")
+	case CircleCI:
+		// We are running in CircleCI. See https://circleci.com/docs/2.0/env-vars/. CIRCLE_PR_NUMBER is only set
+		// for pull requests from forks, so fall back to the number at the end of the pull request's URL.
+		v.PRNumber = prNumber("CIRCLE_PR_NUMBER")
+		if v.PRNumber == "" {
+			if m := trailingNumberRegex.FindStringSubmatch(os.Getenv("CIRCLE_PULL_REQUEST")); m != nil {
+				v.PRNumber = m[1]
+			}
+		}
+		v.BranchName = os.Getenv("CIRCLE_BRANCH")
+	case GitHub:
+		// We are running in GitHub Actions. See https://help.github.com/en/actions/reference/environment-variables.
+		// Pull request builds run on a reference of the form refs/pull/<number>/merge.
+		ref := os.Getenv("GITHUB_REF")
+		if m := trailingNumberRegex.FindStringSubmatch(ref); strings.HasPrefix(ref, "refs/pull/") && m != nil {
+			v.PRNumber = m[1]
+		}
+		v.BranchName = os.Getenv("GITHUB_HEAD_REF")
+		if v.BranchName == "" && strings.HasPrefix(ref, "refs/heads/") {
+			v.BranchName = strings.TrimPrefix(ref, "refs/heads/")
+		}
+		v.SHA = os.Getenv("GITHUB_SHA")
 	case GitLab:
 		// We are running in GitLab CI. See https://docs.gitlab.com/ee/ci/variables/.
 		v.BuildID = os.Getenv("CI_JOB_ID")
 		v.BuildURL = os.Getenv("CI_JOB_URL")
 		v.SHA = os.Getenv("CI_COMMIT_SHA")
+		v.PRNumber = os.Getenv("CI_MERGE_REQUEST_IID")
+		v.BranchName = firstEnv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "CI_COMMIT_REF_NAME")
 	case Travis:
 		// We are running in Travis. See https://docs.travis-ci.com/user/environment-variables/. Travis doesn't
 		// set a build URL in its environment -- see  https://github.com/travis-ci/travis-ci/issues/8935.
 		v.BuildID = os.Getenv("TRAVIS_JOB_ID")
 		v.BuildType = os.Getenv("TRAVIS_EVENT_TYPE")
 		v.SHA = os.Getenv("TRAVIS_PULL_REQUEST_SHA")
+		v.PRNumber = prNumber("TRAVIS_PULL_REQUEST")
+		v.BranchName = firstEnv("TRAVIS_PULL_REQUEST_BRANCH", "TRAVIS_BRANCH")
 	}
 	return v
 }
@@ -0,0 +1,65 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fsutil
+
+import (
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// lockPollInterval is how often a blocked LockFile call retries.
+const lockPollInterval = 50 * time.Millisecond
+
+// FileLock is an advisory lock between processes, held for as long as its lock file exists.
+type FileLock struct {
+	path string
+}
+
+// LockFile acquires the lock represented by the file at path, waiting up to timeout for any other holder to release
+// it.  A lock file older than staleAfter is assumed to have been abandoned by a process that died while holding it, and
+// is broken, so locks must only be held for short periods.
+func LockFile(path string, timeout, staleAfter time.Duration) (*FileLock, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			if err = f.Close(); err != nil {
+				return nil, err
+			}
+			return &FileLock{path: path}, nil
+		} else if !os.IsExist(err) {
+			return nil, errors.Wrapf(err, "acquiring lock %s", path)
+		}
+
+		if info, statErr := os.Stat(path); statErr == nil && time.Since(info.ModTime()) > staleAfter {
+			if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return nil, errors.Wrapf(err, "breaking stale lock %s", path)
+			}
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, errors.Errorf("timed out waiting for lock %s", path)
+		}
+		time.Sleep(lockPollInterval)
+	}
+}
+
+// Unlock releases the lock.
+func (l *FileLock) Unlock() error {
+	return os.Remove(l.path)
+}
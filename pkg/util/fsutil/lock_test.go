@@ -0,0 +1,51 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fsutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-lock")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "test.lock")
+
+	lock, err := LockFile(path, time.Second, time.Minute)
+	assert.NoError(t, err)
+
+	// A second locker times out while the lock is held...
+	_, err = LockFile(path, 100*time.Millisecond, time.Minute)
+	assert.Error(t, err)
+
+	// ...but gets it once the lock is released.
+	assert.NoError(t, lock.Unlock())
+	lock, err = LockFile(path, time.Second, time.Minute)
+	assert.NoError(t, err)
+
+	// An abandoned lock is broken once it becomes stale.
+	old := time.Now().Add(-time.Hour)
+	assert.NoError(t, os.Chtimes(path, old, old))
+	_, err = LockFile(path, 100*time.Millisecond, time.Minute)
+	assert.NoError(t, err)
+	assert.NoError(t, lock.Unlock())
+}
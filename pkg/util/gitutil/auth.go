@@ -0,0 +1,185 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitutil
+
+import (
+	"bufio"
+	"bytes"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport/http"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport/ssh"
+)
+
+const (
+	// GitTokenEnvVar is a token used to authenticate HTTPS Git operations against the hosts in GitTokenHostEnvVar.
+	GitTokenEnvVar = "PULUMI_GIT_TOKEN"
+	// GitTokenHostEnvVar is a comma-separated list of the hosts the token in GitTokenEnvVar may be sent to.  The token
+	// is never sent to any other host.
+	GitTokenHostEnvVar = "PULUMI_GIT_TOKEN_HOST"
+	// GitUsernameEnvVar is the username sent along with the token in GitTokenEnvVar. Most hosts ignore it.
+	GitUsernameEnvVar = "PULUMI_GIT_USERNAME"
+	// GitSSHKeyEnvVar is the path to a private key used to authenticate SSH Git operations instead of the SSH agent.
+	GitSSHKeyEnvVar = "PULUMI_GIT_SSH_KEY"
+	// GitSSHPassphraseEnvVar is the passphrase for the private key in GitSSHKeyEnvVar, if it has one.
+	GitSSHPassphraseEnvVar = "PULUMI_GIT_SSH_PASSPHRASE"
+
+	defaultGitUsername = "git"
+)
+
+// scpLikeURLRegex matches scp-like Git URLs such as git@github.com:owner/repo.git.
+var scpLikeURLRegex = regexp.MustCompile(`^([A-Za-z0-9._-]+)@([A-Za-z0-9.-]+):(.+)$`)
+
+// IsGitSSHURL returns true if the URL refers to a Git repository over SSH, either as an ssh:// URL or in the
+// scp-like form user@host:path.
+func IsGitSSHURL(rawurl string) bool {
+	if strings.HasPrefix(rawurl, "ssh://") {
+		return true
+	}
+	return scpLikeURLRegex.MatchString(rawurl)
+}
+
+// normalizeGitSSHURL rewrites an scp-like URL (git@github.com:owner/repo) as an equivalent ssh:// URL. Other URLs
+// are returned unchanged.
+func normalizeGitSSHURL(rawurl string) string {
+	m := scpLikeURLRegex.FindStringSubmatch(rawurl)
+	if m == nil {
+		return rawurl
+	}
+	return "ssh://" + m[1] + "@" + m[2] + "/" + strings.TrimPrefix(m[3], "/")
+}
+
+// GitAuth returns the method used to authenticate against the Git repository at the given URL, or nil if the
+// repository should be accessed anonymously.
+//
+// For SSH URLs the key in PULUMI_GIT_SSH_KEY is used if set, and the running SSH agent otherwise. For HTTPS URLs
+// the token in PULUMI_GIT_TOKEN is used if it is set and the URL's host is listed in PULUMI_GIT_TOKEN_HOST; otherwise
+// Git's configured credential helpers are consulted.
+func GitAuth(rawurl string) (transport.AuthMethod, error) {
+	if IsGitSSHURL(rawurl) {
+		u, err := url.Parse(normalizeGitSSHURL(rawurl))
+		if err != nil {
+			return nil, err
+		}
+		user := defaultGitUsername
+		if u.User != nil && u.User.Username() != "" {
+			user = u.User.Username()
+		}
+
+		if key := os.Getenv(GitSSHKeyEnvVar); key != "" {
+			auth, keyErr := ssh.NewPublicKeysFromFile(user, key, os.Getenv(GitSSHPassphraseEnvVar))
+			if keyErr != nil {
+				return nil, errors.Wrapf(keyErr, "loading SSH key %s", key)
+			}
+			return auth, nil
+		}
+
+		auth, err := ssh.NewSSHAgentAuth(user)
+		if err != nil {
+			return nil, errors.Wrap(err, "connecting to SSH agent; set PULUMI_GIT_SSH_KEY to use a key file instead")
+		}
+		return auth, nil
+	}
+
+	u, err := url.Parse(rawurl)
+	if err != nil || u.Scheme != "https" {
+		return nil, err
+	}
+
+	if token := os.Getenv(GitTokenEnvVar); token != "" && isGitTokenHost(u.Hostname()) {
+		user := os.Getenv(GitUsernameEnvVar)
+		if user == "" {
+			user = defaultGitUsername
+		}
+		return &http.BasicAuth{Username: user, Password: token}, nil
+	}
+
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			return &http.BasicAuth{Username: u.User.Username(), Password: password}, nil
+		}
+	}
+
+	return gitCredentialHelperAuth(u), nil
+}
+
+// isGitTokenHost returns true if the host is one of those listed in PULUMI_GIT_TOKEN_HOST.
+func isGitTokenHost(host string) bool {
+	for _, h := range strings.Split(os.Getenv(GitTokenHostEnvVar), ",") {
+		if h = strings.TrimSpace(h); h != "" && strings.EqualFold(h, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// gitCredentialHelperAuth asks Git's configured credential helpers for credentials for the given URL. Git is never
+// allowed to prompt; if it is not installed or no helper has credentials, nil is returned.
+func gitCredentialHelperAuth(u *url.URL) transport.AuthMethod {
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		return nil
+	}
+
+	var input bytes.Buffer
+	input.WriteString("protocol=" + u.Scheme + "\n")
+	input.WriteString("host=" + u.Host + "\n")
+	if p := strings.TrimPrefix(u.Path, "/"); p != "" {
+		input.WriteString("path=" + p + "\n")
+	}
+	if u.User != nil && u.User.Username() != "" {
+		input.WriteString("username=" + u.User.Username() + "\n")
+	}
+	input.WriteString("\n")
+
+	cmd := exec.Command(gitPath, "credential", "fill")
+	cmd.Stdin = &input
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_ASKPASS=", "SSH_ASKPASS=")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+
+	user, password := parseGitCredentials(output)
+	if password == "" {
+		return nil
+	}
+	return &http.BasicAuth{Username: user, Password: password}
+}
+
+// parseGitCredentials extracts the username and password from the output of `git credential fill`.
+func parseGitCredentials(output []byte) (string, string) {
+	var user, password string
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		kv := strings.SplitN(scanner.Text(), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "username":
+			user = kv[1]
+		case "password":
+			password = kv[1]
+		}
+	}
+	return user, password
+}
@@ -0,0 +1,95 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitutil
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport/http"
+)
+
+func TestIsGitSSHURL(t *testing.T) {
+	assert.True(t, IsGitSSHURL("ssh://git@github.com/pulumi/templates.git"))
+	assert.True(t, IsGitSSHURL("git@github.com:pulumi/templates.git"))
+	assert.True(t, IsGitSSHURL("deploy@git.example.com:infra/templates"))
+	assert.False(t, IsGitSSHURL("https://github.com/pulumi/templates"))
+	assert.False(t, IsGitSSHURL("https://user@github.com/pulumi/templates"))
+	assert.False(t, IsGitSSHURL("aws-typescript"))
+	assert.False(t, IsGitSSHURL("/tmp/templates"))
+
+	assert.Equal(t, "ssh://git@github.com/pulumi/templates.git",
+		normalizeGitSSHURL("git@github.com:pulumi/templates.git"))
+	assert.Equal(t, "https://github.com/pulumi/templates", normalizeGitSSHURL("https://github.com/pulumi/templates"))
+}
+
+func TestGitAuthToken(t *testing.T) {
+	defer os.Setenv(GitTokenEnvVar, os.Getenv(GitTokenEnvVar))
+	defer os.Setenv(GitTokenHostEnvVar, os.Getenv(GitTokenHostEnvVar))
+	defer os.Setenv(GitUsernameEnvVar, os.Getenv(GitUsernameEnvVar))
+
+	assert.NoError(t, os.Setenv(GitTokenEnvVar, "s3cr3t"))
+	assert.NoError(t, os.Setenv(GitTokenHostEnvVar, "github.com, GitLab.example.com"))
+	assert.NoError(t, os.Setenv(GitUsernameEnvVar, ""))
+	auth, err := GitAuth("https://github.com/acme/templates.git")
+	assert.NoError(t, err)
+	assert.Equal(t, &http.BasicAuth{Username: "git", Password: "s3cr3t"}, auth)
+
+	assert.NoError(t, os.Setenv(GitUsernameEnvVar, "oauth2"))
+	auth, err = GitAuth("https://gitlab.example.com:8443/acme/templates.git")
+	assert.NoError(t, err)
+	assert.Equal(t, &http.BasicAuth{Username: "oauth2", Password: "s3cr3t"}, auth)
+
+	// The token is never sent to other hosts, including those whose names merely contain a listed one.
+	for _, rawurl := range []string{
+		"https://bitbucket.example.com/acme/templates.git",
+		"https://github.com.example.com/acme/templates.git",
+	} {
+		auth, err = GitAuth(rawurl)
+		assert.NoError(t, err)
+		assert.NotEqual(t, &http.BasicAuth{Username: "oauth2", Password: "s3cr3t"}, auth, rawurl)
+	}
+
+	// Nor, without PULUMI_GIT_TOKEN_HOST, to any host at all.
+	assert.NoError(t, os.Setenv(GitTokenHostEnvVar, ""))
+	auth, err = GitAuth("https://github.com/acme/templates.git")
+	assert.NoError(t, err)
+	assert.NotEqual(t, &http.BasicAuth{Username: "oauth2", Password: "s3cr3t"}, auth)
+
+	// Non-HTTPS URLs never carry a token.
+	assert.NoError(t, os.Setenv(GitTokenHostEnvVar, "github.com"))
+	auth, err = GitAuth("http://github.com/acme/templates.git")
+	assert.NoError(t, err)
+	assert.Nil(t, auth)
+}
+
+func TestGitAuthSSHKey(t *testing.T) {
+	defer os.Setenv(GitSSHKeyEnvVar, os.Getenv(GitSSHKeyEnvVar))
+
+	assert.NoError(t, os.Setenv(GitSSHKeyEnvVar, "/does/not/exist"))
+	_, err := GitAuth("git@github.com:acme/templates.git")
+	assert.Error(t, err)
+}
+
+func TestParseGitCredentials(t *testing.T) {
+	user, password := parseGitCredentials([]byte("protocol=https\nhost=github.com\nusername=joe\npassword=a=b\n"))
+	assert.Equal(t, "joe", user)
+	assert.Equal(t, "a=b", password)
+
+	user, password = parseGitCredentials(nil)
+	assert.Equal(t, "", user)
+	assert.Equal(t, "", password)
+}
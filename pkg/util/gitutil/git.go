@@ -28,6 +28,7 @@ import (
 	git "gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/config"
 	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
 	"gopkg.in/src-d/go-git.v4/storage/memory"
 )
 
@@ -101,8 +102,14 @@ func GetGitHubProjectForOriginByRepo(repo *git.Repository) (string, string, erro
 
 // GitCloneAndCheckoutCommit clones the Git repository and checkouts the specified commit.
 func GitCloneAndCheckoutCommit(url string, commit plumbing.Hash, path string) error {
+	auth, err := GitAuth(url)
+	if err != nil {
+		return err
+	}
+
 	repo, err := git.PlainClone(path, false, &git.CloneOptions{
-		URL: url,
+		URL:  url,
+		Auth: auth,
 	})
 	if err != nil {
 		return err
@@ -121,6 +128,21 @@ func GitCloneAndCheckoutCommit(url string, commit plumbing.Hash, path string) er
 
 // GitCloneOrPull clones or updates the specified referenceName (branch or tag) of a Git repository.
 func GitCloneOrPull(url string, referenceName plumbing.ReferenceName, path string, shallow bool) error {
+	auth, err := GitAuth(url)
+	if err != nil {
+		return err
+	}
+	return gitCloneOrPull(url, referenceName, path, shallow, auth)
+}
+
+// GitCloneOrPullPublic clones or updates the specified referenceName (branch or tag) of a public Git repository.  The
+// repository is always accessed anonymously, so no credentials are ever sent to it.
+func GitCloneOrPullPublic(url string, referenceName plumbing.ReferenceName, path string, shallow bool) error {
+	return gitCloneOrPull(url, referenceName, path, shallow, nil)
+}
+
+func gitCloneOrPull(url string, referenceName plumbing.ReferenceName, path string, shallow bool,
+	auth transport.AuthMethod) error {
 	// For shallow clones, use a depth of 1.
 	depth := 0
 	if shallow {
@@ -130,6 +152,7 @@ func GitCloneOrPull(url string, referenceName plumbing.ReferenceName, path strin
 	// Attempt to clone the repo.
 	_, cloneErr := git.PlainClone(path, false, &git.CloneOptions{
 		URL:           url,
+		Auth:          auth,
 		ReferenceName: referenceName,
 		SingleBranch:  true,
 		Depth:         depth,
@@ -149,6 +172,7 @@ func GitCloneOrPull(url string, referenceName plumbing.ReferenceName, path strin
 			}
 
 			if err = w.Pull(&git.PullOptions{
+				Auth:          auth,
 				ReferenceName: referenceName,
 				SingleBranch:  true,
 				Force:         true,
@@ -165,21 +189,27 @@ func GitCloneOrPull(url string, referenceName plumbing.ReferenceName, path strin
 
 // ParseGitRepoURL returns the URL to the Git repository and path from a raw URL.
 // For example, an input of "https://github.com/pulumi/templates/templates/javascript" returns
-// "https://github.com/pulumi/templates.git" and "templates/javascript".
+// "https://github.com/pulumi/templates.git" and "templates/javascript". SSH URLs, including scp-like
+// URLs such as "git@github.com:pulumi/templates/templates/javascript", are returned as ssh:// URLs.
 func ParseGitRepoURL(rawurl string) (string, string, error) {
-	u, err := url.Parse(rawurl)
+	u, err := url.Parse(normalizeGitSSHURL(rawurl))
 	if err != nil {
 		return "", "", err
 	}
 
-	if u.Scheme != "https" {
+	if u.Scheme != "https" && u.Scheme != "ssh" {
 		return "", "", errors.New("invalid URL scheme")
 	}
 
+	host := u.Host
+	if u.Scheme == "ssh" && u.User != nil {
+		host = u.User.Username() + "@" + host
+	}
+
 	path := strings.TrimPrefix(u.Path, "/")
 
 	// Special case Gists.
-	if u.Hostname() == "gist.github.com" {
+	if u.Scheme == "https" && u.Hostname() == "gist.github.com" {
 		// We currently accept Gist URLs in the form: https://gist.github.com/owner/id.
 		// We may want to consider supporting https://gist.github.com/id at some point,
 		// as well as arbitrary revisions, e.g. https://gist.github.com/owner/id/commit.
@@ -203,7 +233,7 @@ func ParseGitRepoURL(rawurl string) (string, string, error) {
 			id = id + ".git"
 		}
 
-		resultURL := u.Scheme + "://" + u.Host + "/" + id
+		resultURL := u.Scheme + "://" + host + "/" + id
 		return resultURL, "", nil
 	}
 
@@ -226,7 +256,7 @@ func ParseGitRepoURL(rawurl string) (string, string, error) {
 		repo = repo + ".git"
 	}
 
-	resultURL := u.Scheme + "://" + u.Host + "/" + owner + "/" + repo
+	resultURL := u.Scheme + "://" + host + "/" + owner + "/" + repo
 	resultPath := strings.TrimSuffix(strings.Join(paths[2:], "/"), "/")
 	return resultURL, resultPath, nil
 }
@@ -309,7 +339,12 @@ func GitListBranchesAndTags(url string) ([]plumbing.ReferenceName, error) {
 		return nil, err
 	}
 
-	refs, err := remote.List(&git.ListOptions{})
+	auth, err := GitAuth(url)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := remote.List(&git.ListOptions{Auth: auth})
 	if err != nil {
 		return nil, err
 	}
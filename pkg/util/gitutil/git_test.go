@@ -56,6 +56,13 @@ func TestParseGitRepoURL(t *testing.T) {
 	test(exp, "", pre)
 	test(exp, "", pre+"/")
 
+	// SSH.
+	exp = "ssh://git@github.com/pulumi/templates.git"
+	test(exp, "", "ssh://git@github.com/pulumi/templates.git")
+	test(exp, "templates/python", "ssh://git@github.com/pulumi/templates/templates/python")
+	test(exp, "", "git@github.com:pulumi/templates.git")
+	test(exp, "tree/master/templates", "git@github.com:pulumi/templates/tree/master/templates")
+
 	testError := func(rawurl string) {
 		_, _, err := ParseGitRepoURL(rawurl)
 		assert.Error(t, err)
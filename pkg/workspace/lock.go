@@ -0,0 +1,233 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/encoding"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// Lock pins the exact set of plugins a project was last successfully deployed with.  It is saved next to the project
+// file as Pulumi.lock, and is meant to be checked in so that everyone working on the project uses the same plugins.
+type Lock struct {
+	Plugins []PluginLock `json:"plugins" yaml:"plugins"` // the locked plugins, sorted by kind, name and version.
+}
+
+// PluginLock records a single locked plugin.
+type PluginLock struct {
+	Kind      PluginKind        `json:"kind" yaml:"kind"`                               // the kind of the plugin.
+	Name      string            `json:"name" yaml:"name"`                               // the simple name of the plugin.
+	Version   string            `json:"version" yaml:"version"`                         // the exact version of the plugin.
+	Checksums map[string]string `json:"checksums,omitempty" yaml:"checksums,omitempty"` // tarball SHA-256s by os-arch.
+}
+
+// NewLock creates a lock for the given plugins, typically those recorded in a snapshot's manifest.  Unversioned
+// plugins cannot be pinned and are skipped.  Each plugin's checksum for the current platform is that of the tarball
+// it was installed from, when one was recorded; checksums for other platforms are carried over from the existing lock,
+// if any, so that a project locked on one platform stays locked on the others.
+func NewLock(plugins []PluginInfo, existing *Lock) (*Lock, error) {
+	lock := &Lock{Plugins: []PluginLock{}}
+	seen := make(map[string]bool)
+	for _, plugin := range plugins {
+		if plugin.Version == nil {
+			continue
+		}
+		key := string(plugin.Kind) + "-" + plugin.Name + "-" + plugin.Version.String()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		checksums := make(map[string]string)
+		if prior, ok := existing.Find(plugin.Kind, plugin.Name, plugin.Version); ok {
+			for platform, checksum := range prior.Checksums {
+				checksums[platform] = checksum
+			}
+		}
+		checksum, err := plugin.TarballChecksum()
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading checksum of %s plugin %s", plugin.Kind, plugin)
+		} else if checksum != "" {
+			checksums[PluginPlatform()] = checksum
+		}
+		if len(checksums) == 0 {
+			checksums = nil
+		}
+
+		lock.Plugins = append(lock.Plugins, PluginLock{
+			Kind:      plugin.Kind,
+			Name:      plugin.Name,
+			Version:   plugin.Version.String(),
+			Checksums: checksums,
+		})
+	}
+
+	sort.Slice(lock.Plugins, func(i, j int) bool {
+		pi, pj := lock.Plugins[i], lock.Plugins[j]
+		if pi.Kind != pj.Kind {
+			return pi.Kind < pj.Kind
+		} else if pi.Name != pj.Name {
+			return pi.Name < pj.Name
+		}
+		return pi.Info().Version.LT(*pj.Info().Version)
+	})
+	return lock, nil
+}
+
+// LoadLock reads the lock file at the given path.  If there is no such file, it returns nil and no error.
+func LoadLock(path string) (*Lock, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var lock Lock
+	if err = encoding.YAML.Unmarshal(b, &lock); err != nil {
+		return nil, errors.Wrapf(err, "could not parse lock file %s", path)
+	}
+	for _, plugin := range lock.Plugins {
+		if !IsPluginKind(string(plugin.Kind)) {
+			return nil, errors.Errorf("lock file %s: unrecognized plugin kind %q", path, plugin.Kind)
+		}
+		if _, err = semver.Parse(plugin.Version); err != nil {
+			return nil, errors.Wrapf(err, "lock file %s: invalid version for plugin %s", path, plugin.Name)
+		}
+	}
+	return &lock, nil
+}
+
+// Save writes the lock to the given path.
+func (lock *Lock) Save(path string) error {
+	contract.Require(lock != nil, "lock")
+
+	b, err := encoding.YAML.Marshal(lock)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+// Equal returns true if both locks pin exactly the same plugins, with the same checksums.
+func (lock *Lock) Equal(other *Lock) bool {
+	if lock == nil || other == nil {
+		return lock == other
+	}
+	if len(lock.Plugins) != len(other.Plugins) {
+		return false
+	}
+	for i := range lock.Plugins {
+		p, o := lock.Plugins[i], other.Plugins[i]
+		if p.Kind != o.Kind || p.Name != o.Name || p.Version != o.Version || len(p.Checksums) != len(o.Checksums) {
+			return false
+		}
+		for platform, checksum := range p.Checksums {
+			if o.Checksums[platform] != checksum {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Find returns the locked plugin of the given kind, name and version, if any.  A nil version matches the newest
+// locked version of the plugin, just as an unversioned plugin request loads the newest installed version.
+func (lock *Lock) Find(kind PluginKind, name string, version *semver.Version) (PluginLock, bool) {
+	var match PluginLock
+	var found bool
+	if lock != nil {
+		for _, plugin := range lock.Plugins {
+			if plugin.Kind != kind || plugin.Name != name {
+				continue
+			}
+			locked := plugin.Info().Version
+			if version != nil && locked.EQ(*version) {
+				return plugin, true
+			} else if version == nil && (!found || match.Info().Version.LT(*locked)) {
+				match, found = plugin, true
+			}
+		}
+	}
+	return match, found
+}
+
+// PluginInfos returns the locked plugins as plugin infos, for installing or ensuring them.
+func (lock *Lock) PluginInfos() []PluginInfo {
+	var plugins []PluginInfo
+	if lock != nil {
+		for _, plugin := range lock.Plugins {
+			plugins = append(plugins, plugin.Info())
+		}
+	}
+	return plugins
+}
+
+// Info returns the plugin info for this locked plugin.
+func (plugin PluginLock) Info() PluginInfo {
+	version := semver.MustParse(plugin.Version)
+	return PluginInfo{Kind: plugin.Kind, Name: plugin.Name, Version: &version}
+}
+
+// Checksum returns the locked checksum of this plugin's release tarball for the current platform, or the empty string
+// if none has been locked.
+func (plugin PluginLock) Checksum() string {
+	return plugin.Checksums[PluginPlatform()]
+}
+
+// Verify checks that exactly this version of the plugin is installed and, if the lock has a checksum for the current
+// platform, that it was installed from a tarball matching that checksum.  It returns the path to the executable.
+func (plugin PluginLock) Verify() (string, error) {
+	info := plugin.Info()
+	path, err := info.FilePath()
+	if err != nil {
+		return "", err
+	}
+	if !HasPlugin(info) {
+		return "", errors.Errorf("%s plugin %s is locked at version %s, which is not installed; "+
+			"run `pulumi plugin install` to install it", plugin.Kind, plugin.Name, plugin.Version)
+	}
+
+	expected := plugin.Checksum()
+	if expected == "" {
+		return path, nil
+	}
+	checksum, err := info.TarballChecksum()
+	if err != nil {
+		return "", err
+	}
+	if checksum != expected {
+		if checksum == "" {
+			checksum = "no recorded checksum"
+		}
+		return "", errors.Errorf("%s plugin %s %s was not installed from the tarball locked in %s: expected %s, got %s; "+
+			"run `pulumi plugin install --reinstall` to reinstall it", plugin.Kind, plugin.Name, plugin.Version,
+			LockFile, expected, checksum)
+	}
+	return path, nil
+}
+
+// LockPath returns the path of the lock file for the project rooted at the given directory.
+func LockPath(root string) string {
+	return filepath.Join(root, LockFile)
+}
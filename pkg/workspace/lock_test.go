@@ -0,0 +1,93 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-lock")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Checksums recorded by an existing lock for other platforms are carried over, while those of versions that are
+	// no longer used are dropped.
+	other := "other-platform"
+	existing := &Lock{Plugins: []PluginLock{
+		{Kind: ResourcePlugin, Name: "aws", Version: "0.16.2", Checksums: map[string]string{other: "abc"}},
+		{Kind: ResourcePlugin, Name: "aws", Version: "0.9.0", Checksums: map[string]string{other: "def"}},
+	}}
+
+	aws, awsOld, nodejs := semver.MustParse("0.16.2"), semver.MustParse("0.10.0"), semver.MustParse("0.16.0")
+	lock, err := NewLock([]PluginInfo{
+		{Kind: ResourcePlugin, Name: "aws", Version: &aws},
+		{Kind: LanguagePlugin, Name: "nodejs", Version: &nodejs},
+		{Kind: ResourcePlugin, Name: "aws", Version: &aws},
+		{Kind: ResourcePlugin, Name: "aws", Version: &awsOld},
+		{Kind: ResourcePlugin, Name: "unversioned"},
+	}, existing)
+	assert.NoError(t, err)
+	assert.Equal(t, []PluginLock{
+		{Kind: LanguagePlugin, Name: "nodejs", Version: "0.16.0"},
+		{Kind: ResourcePlugin, Name: "aws", Version: "0.10.0"},
+		{Kind: ResourcePlugin, Name: "aws", Version: "0.16.2", Checksums: map[string]string{other: "abc"}},
+	}, lock.Plugins)
+
+	path := LockPath(dir)
+	missing, err := LoadLock(path)
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+	assert.False(t, lock.Equal(missing))
+	assert.False(t, lock.Equal(existing))
+
+	assert.NoError(t, lock.Save(path))
+	loaded, err := LoadLock(path)
+	assert.NoError(t, err)
+	assert.True(t, lock.Equal(loaded))
+	assert.Len(t, loaded.PluginInfos(), 3)
+
+	// Versions are matched exactly, and an unversioned lookup finds the newest locked version.
+	locked, ok := loaded.Find(ResourcePlugin, "aws", &awsOld)
+	assert.True(t, ok)
+	assert.Equal(t, "0.10.0", locked.Version)
+	locked, ok = loaded.Find(ResourcePlugin, "aws", nil)
+	assert.True(t, ok)
+	assert.Equal(t, "0.16.2", locked.Version)
+	_, ok = loaded.Find(ResourcePlugin, "aws", &nodejs)
+	assert.False(t, ok)
+	_, ok = loaded.Find(ResourcePlugin, "nodejs", nil)
+	assert.False(t, ok)
+}
+
+func TestLoadLockInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-lock")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := LockPath(dir)
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte("plugins:\n- kind: widget\n  name: aws\n  version: 1.0.0\n"), 0600))
+	_, err = LoadLock(path)
+	assert.Error(t, err)
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte("plugins:\n- kind: resource\n  name: aws\n  version: latest\n"), 0600))
+	_, err = LoadLock(path)
+	assert.Error(t, err)
+}
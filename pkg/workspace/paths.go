@@ -35,12 +35,15 @@ const (
 	ConfigDir      = "config"     // the name of the folder that holds local configuration information.
 	GitDir         = ".git"       // the name of the folder git uses to store information.
 	HistoryDir     = "history"    // the name of the directory that holds historical information for projects.
+	MetadataDir    = "metadata"   // the name of the directory that holds stack metadata for projects.
 	PluginDir      = "plugins"    // the name of the directory containing plugins.
 	StackDir       = "stacks"     // the name of the directory that holds stack information for projects.
 	TemplateDir    = "templates"  // the name of the directory containing templates.
 	WorkspaceDir   = "workspaces" // the name of the directory that holds workspace information for projects.
 
 	IgnoreFile        = ".pulumiignore"      // the name of the file that we use to control what to upload to the service.
+	LockFile          = "Pulumi.lock"        // the name of the file that pins a project's plugins to exact versions.
+	PluginUsageFile   = ".usage.json"        // the name of the file in the plugin directory recording plugin use.
 	ProjectFile       = "Pulumi"             // the base name of a project file.
 	RepoFile          = "settings.json"      // the name of the file that holds information specific to the entire repository.
 	WorkspaceFile     = "workspace.json"     // the name of the file that holds workspace information.
@@ -0,0 +1,276 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/httputil"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+)
+
+// PluginMirrorsEnvVar is a comma-separated list of plugin mirrors that takes precedence over workspace settings.
+const PluginMirrorsEnvVar = "PULUMI_PLUGIN_MIRRORS"
+
+// checksumSuffix is appended to a tarball's name to find its published SHA-256 checksum.
+const checksumSuffix = ".sha256"
+
+// GetPluginMirrors returns the plugin mirrors configured for the project containing dir.  Each mirror is either the
+// base URL of a web server or a local directory containing plugin tarballs, laid out flat as
+// `pulumi-<kind>-<name>-v<version>-<os>-<arch>.tar.gz`, each next to a `.sha256` file holding its checksum.  The
+// PULUMI_PLUGIN_MIRRORS environment variable takes precedence over the workspace's `pluginMirrors` setting.
+func GetPluginMirrors(dir string) []string {
+	if env := os.Getenv(PluginMirrorsEnvVar); env != "" {
+		var mirrors []string
+		for _, m := range strings.Split(env, ",") {
+			if m = strings.TrimSpace(m); m != "" {
+				mirrors = append(mirrors, m)
+			}
+		}
+		return mirrors
+	}
+
+	if dir == "" {
+		return nil
+	}
+	w, err := NewFrom(dir)
+	if err != nil {
+		// Not being in a project just means there are no workspace settings to consult.
+		logging.V(7).Infof("GetPluginMirrors(%s): no workspace settings: %v", dir, err)
+		return nil
+	}
+	return w.Settings().PluginMirrors
+}
+
+// TarballName returns the name of the tarball that a release of this plugin is published as for the current platform.
+func (info PluginInfo) TarballName() (string, error) {
+	if info.Version == nil {
+		return "", errors.Errorf("plugin %s has no version", info.Name)
+	}
+	switch runtime.GOOS {
+	case "darwin", "linux", "windows":
+	default:
+		return "", errors.Errorf("unsupported plugin OS: %s", runtime.GOOS)
+	}
+	if runtime.GOARCH != "amd64" {
+		return "", errors.Errorf("unsupported plugin architecture: %s", runtime.GOARCH)
+	}
+	return fmt.Sprintf("%s-v%s-%s.tar.gz", info.FilePrefix(), info.Version, PluginPlatform()), nil
+}
+
+// PluginPlatform returns the `<os>-<arch>` pair that identifies the plugin tarballs built for the current platform.
+func PluginPlatform() string {
+	return runtime.GOOS + "-" + runtime.GOARCH
+}
+
+// InstallFromMirrors installs this plugin from the first of the given mirrors that has it, verifying the tarball
+// before anything is extracted.  The tarball must match the given checksum or, if it is empty, the mirror's published
+// one.  It returns the location the plugin was installed from.
+func (info PluginInfo) InstallFromMirrors(mirrors []string, checksum string) (string, error) {
+	tarball, published, source, err := info.DownloadFromMirrors(mirrors)
+	if err != nil {
+		return "", err
+	}
+	if checksum == "" {
+		checksum = published
+	}
+	if err = info.InstallWithChecksum(tarball, checksum); err != nil {
+		return "", errors.Wrapf(err, "installing %s from %s", info, source)
+	}
+	return source, nil
+}
+
+// DownloadFromMirrors fetches this plugin's tarball and its published checksum from the first of the given mirrors
+// that has both.  The tarball has not been verified; its checksum and location are returned alongside it.
+func (info PluginInfo) DownloadFromMirrors(mirrors []string) (io.ReadCloser, string, string, error) {
+	if len(mirrors) == 0 {
+		return nil, "", "", errors.New("no plugin mirrors are configured")
+	}
+	name, err := info.TarballName()
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	var result error
+	for _, mirror := range mirrors {
+		source := mirrorLocation(mirror, name)
+		logging.V(5).Infof("DownloadFromMirrors(%s): trying %s", info, source)
+		tarball, checksum, err := downloadFromMirror(mirror, name)
+		if err == nil {
+			return tarball, checksum, source, nil
+		}
+		result = multierror.Append(result, errors.Wrapf(err, "downloading from %s", source))
+	}
+	return nil, "", "", errors.Wrapf(result, "could not download plugin %s from any mirror", info)
+}
+
+// InstallWithChecksum verifies that the given tarball matches the given hex-encoded SHA-256 checksum and, only if it
+// does, installs this plugin from it.  An empty checksum installs the tarball unverified.
+func (info PluginInfo) InstallWithChecksum(tarball io.ReadCloser, checksum string) error {
+	if checksum != "" {
+		verified, err := VerifyPluginTarball(tarball, checksum)
+		if err != nil {
+			return errors.Wrap(err, "verifying tarball")
+		}
+		tarball = verified
+	}
+	return info.Install(tarball)
+}
+
+// VerifyPluginTarball reads the whole tarball, checking that its SHA-256 digest matches the given hex-encoded
+// checksum.  On success it returns a reader over the verified contents; the tarball itself is always closed.
+func VerifyPluginTarball(tarball io.ReadCloser, checksum string) (io.ReadCloser, error) {
+	defer contract.IgnoreClose(tarball)
+
+	// Spool the tarball to disk while hashing it, so that nothing unverified ever reaches the extractor.
+	tmp, err := ioutil.TempFile("", "pulumi-plugin")
+	if err != nil {
+		return nil, err
+	}
+	cleanup := func() {
+		contract.IgnoreClose(tmp)
+		contract.IgnoreError(os.Remove(tmp.Name()))
+	}
+
+	hash := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(tmp, hash), tarball); err != nil {
+		cleanup()
+		return nil, errors.Wrap(err, "reading tarball")
+	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(actual, checksum) {
+		cleanup()
+		return nil, errors.Errorf("checksum mismatch: expected %s, got %s", checksum, actual)
+	}
+	if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+		cleanup()
+		return nil, err
+	}
+	return &tempFileReadCloser{File: tmp}, nil
+}
+
+// tempFileReadCloser removes its file once it is closed.
+type tempFileReadCloser struct {
+	*os.File
+}
+
+func (f *tempFileReadCloser) Close() error {
+	err := f.File.Close()
+	contract.IgnoreError(os.Remove(f.File.Name()))
+	return err
+}
+
+// downloadFromMirror fetches the named tarball and its published checksum from a single mirror.
+func downloadFromMirror(mirror, name string) (io.ReadCloser, string, error) {
+	sums, err := openFromMirror(mirror, name+checksumSuffix)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "fetching checksum")
+	}
+	checksum, err := readChecksum(sums)
+	contract.IgnoreClose(sums)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "reading checksum %s", name+checksumSuffix)
+	}
+
+	tarball, err := openFromMirror(mirror, name)
+	if err != nil {
+		return nil, "", err
+	}
+	return tarball, checksum, nil
+}
+
+// ReadTarballChecksum reads the published checksum of a local plugin tarball from the `.sha256` file next to it.  If
+// there is no such file, the empty string is returned.
+func ReadTarballChecksum(tarball string) (string, error) {
+	f, err := os.Open(tarball + checksumSuffix)
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	defer contract.IgnoreClose(f)
+
+	checksum, err := readChecksum(f)
+	if err != nil {
+		return "", errors.Wrapf(err, "reading checksum %s", f.Name())
+	}
+	return checksum, nil
+}
+
+// readChecksum parses a published checksum file.  Both a bare hex digest and `sha256sum` output are accepted.
+func readChecksum(r io.Reader) (string, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		checksum := fields[0]
+		if b, err := hex.DecodeString(checksum); err != nil || len(b) != sha256.Size {
+			return "", errors.Errorf("%q is not a SHA-256 checksum", checksum)
+		}
+		return checksum, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", errors.New("checksum file is empty")
+}
+
+// isMirrorURL returns true if the mirror should be fetched over HTTP rather than read from the local disk.
+func isMirrorURL(mirror string) bool {
+	u, err := url.Parse(mirror)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https")
+}
+
+// mirrorLocation returns the URL or path of the named file within a mirror.
+func mirrorLocation(mirror, name string) string {
+	if isMirrorURL(mirror) {
+		return strings.TrimSuffix(mirror, "/") + "/" + name
+	}
+	return filepath.Join(strings.TrimPrefix(mirror, "file://"), name)
+}
+
+// openFromMirror opens the named file within a mirror.
+func openFromMirror(mirror, name string) (io.ReadCloser, error) {
+	location := mirrorLocation(mirror, name)
+	if !isMirrorURL(mirror) {
+		return os.Open(location)
+	}
+
+	resp, err := httputil.GetWithRetry(location, http.DefaultClient)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		contract.IgnoreClose(resp.Body)
+		return nil, errors.Errorf("%s: %s", location, resp.Status)
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func makePluginTarball(t *testing.T) []byte {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	w := tar.NewWriter(gzw)
+	contents := []byte("#!/bin/sh\n")
+	assert.NoError(t, w.WriteHeader(&tar.Header{Name: "pulumi-resource-test", Mode: 0700, Size: int64(len(contents))}))
+	_, err := w.Write(contents)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	assert.NoError(t, gzw.Close())
+	return buf.Bytes()
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestGetPluginMirrors(t *testing.T) {
+	old := os.Getenv(PluginMirrorsEnvVar)
+	defer func() { assert.NoError(t, os.Setenv(PluginMirrorsEnvVar, old)) }()
+
+	assert.NoError(t, os.Setenv(PluginMirrorsEnvVar, "https://mirror.example.com/plugins, /opt/plugins,,"))
+	assert.Equal(t, []string{"https://mirror.example.com/plugins", "/opt/plugins"}, GetPluginMirrors(""))
+
+	assert.NoError(t, os.Setenv(PluginMirrorsEnvVar, ""))
+	assert.Empty(t, GetPluginMirrors(""))
+}
+
+func TestReadChecksum(t *testing.T) {
+	sum := strings.Repeat("ab", sha256.Size)
+
+	checksum, err := readChecksum(strings.NewReader(sum + "\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, sum, checksum)
+
+	checksum, err = readChecksum(strings.NewReader("\n" + sum + "  pulumi-resource-test-v1.0.0-linux-amd64.tar.gz\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, sum, checksum)
+
+	_, err = readChecksum(strings.NewReader("abcdef\n"))
+	assert.Error(t, err)
+	_, err = readChecksum(strings.NewReader(""))
+	assert.Error(t, err)
+}
+
+func TestVerifyPluginTarball(t *testing.T) {
+	tarball := makePluginTarball(t)
+
+	r, err := VerifyPluginTarball(ioutil.NopCloser(bytes.NewReader(tarball)), strings.ToUpper(sha256Hex(tarball)))
+	assert.NoError(t, err)
+	contents, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, tarball, contents)
+	assert.NoError(t, r.Close())
+
+	_, err = VerifyPluginTarball(ioutil.NopCloser(bytes.NewReader(tarball)), sha256Hex([]byte("tampered")))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch")
+}
+
+func TestDownloadFromMirrors(t *testing.T) {
+	version := semver.MustParse("1.0.0")
+	info := PluginInfo{Kind: ResourcePlugin, Name: "test", Version: &version}
+	name, err := info.TarballName()
+	if err != nil {
+		t.Skip(err)
+	}
+	tarball := makePluginTarball(t)
+
+	// One mirror is a directory whose tarball has been corrupted, another is missing the plugin entirely, and the
+	// third serves it correctly.
+	dir, err := ioutil.TempDir("", "pulumi-plugin-mirror")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), tarball[1:], 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name+".sha256"), []byte(sha256Hex(tarball)), 0600))
+
+	empty, err := ioutil.TempDir("", "pulumi-plugin-mirror")
+	assert.NoError(t, err)
+	defer os.RemoveAll(empty)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/plugins/" + name:
+			_, err := w.Write(tarball)
+			assert.NoError(t, err)
+		case "/plugins/" + name + ".sha256":
+			_, err := w.Write([]byte(sha256Hex(tarball) + "  " + name + "\n"))
+			assert.NoError(t, err)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	r, checksum, source, err := info.DownloadFromMirrors([]string{"file://" + empty, server.URL + "/plugins/", dir})
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL+"/plugins/"+name, source)
+	assert.Equal(t, sha256Hex(tarball), checksum)
+	contents, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, tarball, contents)
+	assert.NoError(t, r.Close())
+
+	// A tarball that does not match its mirror's checksum is never installed.
+	r, checksum, _, err = info.DownloadFromMirrors([]string{dir, empty})
+	assert.NoError(t, err)
+	err = info.InstallWithChecksum(r, checksum)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch")
+
+	_, _, _, err = info.DownloadFromMirrors([]string{empty})
+	assert.Error(t, err)
+
+	_, _, _, err = info.DownloadFromMirrors(nil)
+	assert.Error(t, err)
+}
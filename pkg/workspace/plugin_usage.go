@@ -0,0 +1,139 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/fsutil"
+)
+
+const (
+	// pluginUsageLockTimeout is how long to wait for another process to finish recording a plugin use.
+	pluginUsageLockTimeout = 10 * time.Second
+	// pluginUsageLockStale is how old the usage file's lock must be before it is assumed to have been abandoned.
+	pluginUsageLockStale = 30 * time.Second
+)
+
+// PluginUsage maps each installed plugin's directory name (see PluginInfo.Dir) to the last time it was loaded.  File
+// access times are unreliable on many filesystems, so the plugin host records each use explicitly.
+type PluginUsage map[string]time.Time
+
+// GetPluginUsage reads the recorded plugin usage.  If nothing has been recorded yet, the result is empty.
+func GetPluginUsage() (PluginUsage, error) {
+	path, err := pluginUsagePath()
+	if err != nil {
+		return nil, err
+	}
+	return readPluginUsage(path)
+}
+
+// RecordPluginUse notes that the given plugin was loaded at the given time.  Plugins without a version, which are
+// typically found on the $PATH rather than in the plugin cache, are not recorded.
+func RecordPluginUse(plugin PluginInfo, when time.Time) error {
+	if plugin.Version == nil {
+		return nil
+	}
+	path, err := pluginUsagePath()
+	if err != nil {
+		return err
+	}
+	return recordPluginUse(path, plugin, when)
+}
+
+// recordPluginUse updates the usage file at path.  The file is read, updated and replaced while holding a lock on it,
+// so that concurrent uses of different plugins do not overwrite each other's records.
+func recordPluginUse(path string, plugin PluginInfo, when time.Time) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	lock, err := fsutil.LockFile(filepath.Join(dir, "."+filepath.Base(path)+".lock"),
+		pluginUsageLockTimeout, pluginUsageLockStale)
+	if err != nil {
+		return errors.Wrap(err, "recording plugin use")
+	}
+	defer func() { contract.IgnoreError(lock.Unlock()) }()
+
+	usage, err := readPluginUsage(path)
+	if err != nil {
+		// A corrupt usage file only costs us some history; start afresh rather than failing.
+		usage = PluginUsage{}
+	}
+
+	key := plugin.Dir()
+	if last, has := usage[key]; has && !when.After(last) {
+		return nil
+	}
+	usage[key] = when.UTC()
+
+	// Write the new file alongside the old one and rename it into place so that readers, which do not take the lock,
+	// never see a partially written file.
+	b, err := json.MarshalIndent(usage, "", "    ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(dir, PluginUsageFile)
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(b)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		contract.IgnoreError(os.Remove(tmp.Name()))
+		return errors.Wrap(err, "recording plugin use")
+	}
+	return nil
+}
+
+// LastUsed returns when the given plugin was last used according to the usage records, if it has been recorded.
+func (usage PluginUsage) LastUsed(plugin PluginInfo) (time.Time, bool) {
+	t, has := usage[plugin.Dir()]
+	return t, has
+}
+
+func pluginUsagePath() (string, error) {
+	dir, err := GetPluginDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, PluginUsageFile), nil
+}
+
+func readPluginUsage(path string) (PluginUsage, error) {
+	usage := PluginUsage{}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return usage, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(b, &usage); err != nil {
+		return nil, errors.Wrapf(err, "could not parse %s", path)
+	}
+	return usage, nil
+}
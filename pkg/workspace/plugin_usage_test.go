@@ -0,0 +1,102 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordPluginUse(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-plugin-usage")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "plugins", PluginUsageFile)
+
+	usage, err := readPluginUsage(path)
+	assert.NoError(t, err)
+	assert.Empty(t, usage)
+
+	v1, v2 := semver.MustParse("1.0.0"), semver.MustParse("2.0.0")
+	aws1 := PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v1}
+	aws2 := PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v2}
+	earlier := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(24 * time.Hour)
+
+	assert.NoError(t, recordPluginUse(path, aws1, later))
+	assert.NoError(t, recordPluginUse(path, aws2, earlier))
+	// Uses recorded out of order never move the last use backwards.
+	assert.NoError(t, recordPluginUse(path, aws1, earlier))
+
+	usage, err = readPluginUsage(path)
+	assert.NoError(t, err)
+	last, has := usage.LastUsed(aws1)
+	assert.True(t, has)
+	assert.True(t, later.Equal(last))
+	last, has = usage.LastUsed(aws2)
+	assert.True(t, has)
+	assert.True(t, earlier.Equal(last))
+	_, has = usage.LastUsed(PluginInfo{Kind: ResourcePlugin, Name: "gcp", Version: &v1})
+	assert.False(t, has)
+
+	// A corrupt file is replaced rather than blocking further records.
+	assert.NoError(t, ioutil.WriteFile(path, []byte("{"), 0600))
+	_, err = readPluginUsage(path)
+	assert.Error(t, err)
+	assert.NoError(t, recordPluginUse(path, aws2, later))
+	usage, err = readPluginUsage(path)
+	assert.NoError(t, err)
+	assert.Len(t, usage, 1)
+}
+
+func TestRecordPluginUseConcurrently(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-plugin-usage")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, PluginUsageFile)
+
+	// Uses of different plugins recorded at the same time must not overwrite each other.
+	const count = 8
+	now := time.Now()
+	var wg sync.WaitGroup
+	errs := make([]error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v := semver.Version{Major: uint64(i)}
+			errs[i] = recordPluginUse(path, PluginInfo{Kind: ResourcePlugin, Name: "aws", Version: &v}, now)
+		}(i)
+	}
+	wg.Wait()
+	for _, recordErr := range errs {
+		assert.NoError(t, recordErr)
+	}
+
+	usage, err := readPluginUsage(path)
+	assert.NoError(t, err)
+	assert.Len(t, usage, count)
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+}
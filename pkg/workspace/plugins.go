@@ -17,6 +17,8 @@ package workspace
 import (
 	"archive/tar"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -26,6 +28,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/blang/semver"
@@ -33,6 +36,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/fsutil"
 	"github.com/pulumi/pulumi/pkg/util/logging"
 )
 
@@ -131,18 +135,102 @@ func (info *PluginInfo) SetFileMetadata(path string) error {
 }
 
 // Install installs a plugin's tarball into the cache.  It validates that plugin names are in the expected format.
+//
+// The tarball is extracted into a temporary directory, which is marked complete and then renamed into place while
+// holding a lock on the plugin, so neither concurrent installs nor interrupted ones leave behind a plugin that looks
+// installed but is not.  The completion marker records the tarball's checksum; see TarballChecksum.
 func (info PluginInfo) Install(tarball io.ReadCloser) error {
-	// Fetch the directory into which we will expand this tarball, and create it.
 	pluginDir, err := info.DirPath()
 	if err != nil {
+		contract.IgnoreClose(tarball)
 		return err
 	}
-	if err = os.MkdirAll(pluginDir, 0700); err != nil {
+	return installPlugin(pluginDir, tarball)
+}
+
+// installPlugin installs the given tarball into pluginDir.
+func installPlugin(pluginDir string, tarball io.ReadCloser) error {
+	defer contract.IgnoreClose(tarball)
+
+	// Extract next to the final directory, so that it can be renamed into place on the same filesystem.
+	parent, name := filepath.Dir(pluginDir), filepath.Base(pluginDir)
+	if err := os.MkdirAll(parent, 0700); err != nil {
+		return errors.Wrapf(err, "creating plugin root %s", parent)
+	}
+	removeAbandonedInstalls(parent, name)
+
+	tempDir, err := ioutil.TempDir(parent, pluginTempPrefix+name+"-")
+	if err != nil {
 		return errors.Wrapf(err, "creating plugin directory %s", pluginDir)
 	}
+	defer func() { contract.IgnoreError(os.RemoveAll(tempDir)) }()
 
-	// Unzip and untar the file as we go.
-	defer contract.IgnoreClose(tarball)
+	// Hash the tarball as it is extracted, reading past the end of the archive so that the whole file is covered.
+	hash := sha256.New()
+	contents := io.TeeReader(tarball, hash)
+	if err = extractPluginTarball(contents, tempDir); err != nil {
+		return err
+	}
+	if _, err = io.Copy(ioutil.Discard, contents); err != nil {
+		return errors.Wrapf(err, "reading tarball")
+	}
+	checksum := hex.EncodeToString(hash.Sum(nil)) + "\n"
+	if err = ioutil.WriteFile(filepath.Join(tempDir, pluginCompleteFile), []byte(checksum), 0600); err != nil {
+		return errors.Wrapf(err, "marking plugin complete")
+	}
+
+	// Now swap the new plugin in, moving aside anything (such as an older or partial copy) already there.
+	lock, err := fsutil.LockFile(filepath.Join(parent, "."+name+".lock"), pluginLockTimeout, pluginLockStale)
+	if err != nil {
+		return err
+	}
+	defer func() { contract.IgnoreError(lock.Unlock()) }()
+
+	if _, err = os.Stat(pluginDir); err == nil {
+		oldDir := tempDir + ".old"
+		if err = os.Rename(pluginDir, oldDir); err != nil {
+			return errors.Wrapf(err, "replacing plugin directory %s", pluginDir)
+		}
+		defer func() { contract.IgnoreError(os.RemoveAll(oldDir)) }()
+	}
+	if err = os.Rename(tempDir, pluginDir); err != nil {
+		return errors.Wrapf(err, "moving plugin into %s", pluginDir)
+	}
+	return nil
+}
+
+const (
+	// pluginCompleteFile is written into a plugin's directory once it has been completely installed.  It holds the
+	// SHA-256 checksum of the tarball the plugin was installed from.
+	pluginCompleteFile = ".pulumi-plugin-complete"
+	// pluginTempPrefix prefixes the directories that plugins are extracted into before they are moved into place.
+	pluginTempPrefix = ".tmp-"
+	// pluginLockTimeout is how long to wait for another process to finish installing the same plugin.
+	pluginLockTimeout = time.Minute
+	// pluginLockStale is how old a plugin's lock must be before it is assumed to have been abandoned.  The lock is
+	// only held while renaming directories, so this can be short.
+	pluginLockStale = 30 * time.Second
+	// abandonedInstallAge is how old an extraction directory must be before it is assumed to have been abandoned.
+	abandonedInstallAge = time.Hour
+)
+
+// removeAbandonedInstalls deletes old extraction directories for the named plugin that were left behind by installs
+// that were interrupted, e.g. by Ctrl-C.
+func removeAbandonedInstalls(parent, name string) {
+	matches, err := filepath.Glob(filepath.Join(parent, pluginTempPrefix+name+"-*"))
+	if err != nil {
+		return
+	}
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil && time.Since(info.ModTime()) > abandonedInstallAge {
+			logging.V(5).Infof("removing abandoned plugin install %s", match)
+			contract.IgnoreError(os.RemoveAll(match))
+		}
+	}
+}
+
+// extractPluginTarball unzips and untars the given tarball into dir.
+func extractPluginTarball(tarball io.Reader, dir string) error {
 	gzr, err := gzip.NewReader(tarball)
 	if err != nil {
 		return errors.Wrapf(err, "unzipping")
@@ -156,7 +244,7 @@ func (info PluginInfo) Install(tarball io.ReadCloser) error {
 			return errors.Wrapf(err, "untarring")
 		}
 
-		path := filepath.Join(pluginDir, header.Name)
+		path := filepath.Join(dir, header.Name)
 
 		switch header.Typeflag {
 		case tar.TypeDir:
@@ -168,13 +256,8 @@ func (info PluginInfo) Install(tarball io.ReadCloser) error {
 			}
 		case tar.TypeReg:
 			// Expand files into the target directory.
-			dst, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, os.FileMode(header.Mode))
-			if err != nil {
-				return errors.Wrapf(err, "opening file %s for untar", path)
-			}
-			defer contract.IgnoreClose(dst)
-			if _, err = io.Copy(dst, r); err != nil {
-				return errors.Wrapf(err, "untarring file %s", path)
+			if err = extractPluginFile(r, path, os.FileMode(header.Mode)); err != nil {
+				return err
 			}
 		default:
 			return errors.Errorf("unexpected plugin file type %s (%v)", header.Name, header.Typeflag)
@@ -183,6 +266,64 @@ func (info PluginInfo) Install(tarball io.ReadCloser) error {
 	return nil
 }
 
+// extractPluginFile writes the current tar entry to path.
+func extractPluginFile(r io.Reader, path string, mode os.FileMode) error {
+	dst, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, mode)
+	if err != nil {
+		return errors.Wrapf(err, "opening file %s for untar", path)
+	}
+	_, err = io.Copy(dst, r)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrapf(err, "untarring file %s", path)
+	}
+	return nil
+}
+
+// TarballChecksum returns the hex-encoded SHA-256 checksum of the tarball this plugin was installed from, as recorded
+// when it was installed.  If the plugin is not installed, or was installed without recording one, it returns the empty
+// string.
+func (info PluginInfo) TarballChecksum() (string, error) {
+	dir, err := info.DirPath()
+	if err != nil {
+		return "", err
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dir, pluginCompleteFile))
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// isPluginComplete returns true if the given plugin's directory was fully installed, i.e. it holds a completion marker.
+//
+// Plugins installed before completion markers were introduced have none.  The first time such a directory is seen, it
+// is migrated: if it holds the plugin's executable, it is assumed to be complete and an empty marker, which records no
+// checksum, is written into it; otherwise it is not complete, and the plugin must be reinstalled.
+func isPluginComplete(dir string, plugin PluginInfo) bool {
+	if strings.HasPrefix(filepath.Base(dir), pluginTempPrefix) {
+		return false
+	}
+	marker := filepath.Join(dir, pluginCompleteFile)
+	if _, err := os.Stat(marker); err == nil {
+		return true
+	}
+
+	info, err := os.Stat(filepath.Join(dir, plugin.File()))
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	logging.V(5).Infof("marking plugin %s, installed without a completion marker, complete", dir)
+	if err = ioutil.WriteFile(marker, nil, 0600); err != nil {
+		logging.V(5).Infof("failed to mark plugin %s complete: %v", dir, err)
+	}
+	return true
+}
+
 func (info PluginInfo) String() string {
 	var version string
 	if v := info.Version; v != nil {
@@ -213,16 +354,10 @@ func IsPluginKind(k string) bool {
 	}
 }
 
-// HasPlugin returns true if the given plugin exists.
+// HasPlugin returns true if the given plugin exists and was completely installed.
 func HasPlugin(plug PluginInfo) bool {
 	dir, err := plug.DirPath()
-	if err == nil {
-		_, err := os.Stat(dir)
-		if err == nil {
-			return true
-		}
-	}
-	return false
+	return err == nil && isPluginComplete(dir, plug)
 }
 
 // HasPluginGTE returns true if the given plugin exists at the given version number or greater.
@@ -263,6 +398,26 @@ func GetPlugins() ([]PluginInfo, error) {
 	if err != nil {
 		return nil, err
 	}
+	plugins, err := getPlugins(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prefer recorded use times over file access times, which many filesystems do not keep up to date.
+	usage, err := GetPluginUsage()
+	if err != nil {
+		logging.V(5).Infof("GetPlugins(): ignoring plugin usage records: %v", err)
+	}
+	for i, plugin := range plugins {
+		if last, has := usage.LastUsed(plugin); has && last.After(plugin.LastUsedTime) {
+			plugins[i].LastUsedTime = last
+		}
+	}
+	return plugins, nil
+}
+
+// getPlugins returns the completely installed plugins in the given directory.
+func getPlugins(dir string) ([]PluginInfo, error) {
 	files, err := ioutil.ReadDir(dir)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -274,14 +429,20 @@ func GetPlugins() ([]PluginInfo, error) {
 	// Now read the file infos and create the plugin infos.
 	var plugins []PluginInfo
 	for _, file := range files {
-		// Skip anything that doesn't look like a plugin.
+		// Skip anything that doesn't look like a plugin, or that was never completely installed.
 		if kind, name, version, ok := tryPlugin(file); ok {
 			plugin := PluginInfo{
 				Name:    name,
 				Kind:    kind,
 				Version: &version,
 			}
-			if err = plugin.SetFileMetadata(filepath.Join(dir, file.Name())); err != nil {
+			path := filepath.Join(dir, file.Name())
+			if !isPluginComplete(path, plugin) {
+				logging.V(5).Infof("skipping incompletely installed plugin %s", path)
+				continue
+			}
+
+			if err = plugin.SetFileMetadata(path); err != nil {
 				return nil, err
 			}
 			plugins = append(plugins, plugin)
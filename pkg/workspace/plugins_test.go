@@ -0,0 +1,207 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// interruptedReader fails after yielding the first n bytes of its contents, like a dropped download.
+type interruptedReader struct {
+	r io.Reader
+	n int
+}
+
+func (r *interruptedReader) Read(p []byte) (int, error) {
+	if r.n <= 0 {
+		return 0, errors.New("connection reset")
+	}
+	if len(p) > r.n {
+		p = p[:r.n]
+	}
+	n, err := r.r.Read(p)
+	r.n -= n
+	return n, err
+}
+
+func (r *interruptedReader) Close() error { return nil }
+
+// testPlugin is the plugin installed by the tarballs from makePluginTarball.
+var testPlugin = PluginInfo{Kind: ResourcePlugin, Name: "test"}
+
+func newPluginRoot(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "pulumi-plugins")
+	assert.NoError(t, err)
+	return dir
+}
+
+// assertNoInstallDebris checks that nothing but finished plugins is left in the plugin root.
+func assertNoInstallDebris(t *testing.T, root string) {
+	files, err := ioutil.ReadDir(root)
+	assert.NoError(t, err)
+	for _, file := range files {
+		_, _, _, ok := tryPlugin(file)
+		assert.True(t, ok, "unexpected file %s", file.Name())
+	}
+}
+
+func TestInstallPlugin(t *testing.T) {
+	root := newPluginRoot(t)
+	defer os.RemoveAll(root)
+	pluginDir := filepath.Join(root, "resource-test-v1.0.0")
+
+	tarball := makePluginTarball(t)
+	assert.NoError(t, installPlugin(pluginDir, ioutil.NopCloser(bytes.NewReader(tarball))))
+
+	contents, err := ioutil.ReadFile(filepath.Join(pluginDir, "pulumi-resource-test"))
+	assert.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\n", string(contents))
+	assert.True(t, isPluginComplete(pluginDir, testPlugin))
+	assertNoInstallDebris(t, root)
+
+	// The completion marker records the checksum of the whole tarball.
+	marker, err := ioutil.ReadFile(filepath.Join(pluginDir, pluginCompleteFile))
+	assert.NoError(t, err)
+	assert.Equal(t, sha256Hex(tarball)+"\n", string(marker))
+
+	plugins, err := getPlugins(root)
+	assert.NoError(t, err)
+	if assert.Len(t, plugins, 1) {
+		assert.Equal(t, "test", plugins[0].Name)
+		assert.Equal(t, "1.0.0", plugins[0].Version.String())
+	}
+
+	// Reinstalling replaces the existing plugin.
+	assert.NoError(t, installPlugin(pluginDir, ioutil.NopCloser(bytes.NewReader(tarball))))
+	assert.True(t, isPluginComplete(pluginDir, testPlugin))
+	assertNoInstallDebris(t, root)
+}
+
+func TestInstallPluginInterrupted(t *testing.T) {
+	root := newPluginRoot(t)
+	defer os.RemoveAll(root)
+	pluginDir := filepath.Join(root, "resource-test-v1.0.0")
+
+	// A download that fails partway through leaves nothing behind.
+	tarball := makePluginTarball(t)
+	err := installPlugin(pluginDir, &interruptedReader{r: bytes.NewReader(tarball), n: len(tarball) / 2})
+	assert.Error(t, err)
+	_, err = os.Stat(pluginDir)
+	assert.True(t, os.IsNotExist(err))
+	assertNoInstallDebris(t, root)
+
+	// A process killed midway leaves only an extraction directory, which is not treated as installed.
+	staging := filepath.Join(root, pluginTempPrefix+"resource-test-v1.0.0-123")
+	assert.NoError(t, os.Mkdir(staging, 0700))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(staging, "pulumi-resource-test"), []byte("#!"), 0700))
+	assert.False(t, isPluginComplete(staging, testPlugin))
+	plugins, err := getPlugins(root)
+	assert.NoError(t, err)
+	assert.Empty(t, plugins)
+
+	// A plugin directory without a completion marker or the plugin's executable, such as one left behind by an
+	// interrupted install from an older version, is not treated as installed...
+	assert.NoError(t, os.Mkdir(pluginDir, 0700))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(pluginDir, "README.md"), []byte("# test"), 0600))
+	assert.False(t, isPluginComplete(pluginDir, testPlugin))
+	plugins, err = getPlugins(root)
+	assert.NoError(t, err)
+	assert.Empty(t, plugins)
+
+	// ...and is replaced by a reinstall.
+	assert.NoError(t, installPlugin(pluginDir, ioutil.NopCloser(bytes.NewReader(tarball))))
+	plugins, err = getPlugins(root)
+	assert.NoError(t, err)
+	assert.Len(t, plugins, 1)
+	contents, err := ioutil.ReadFile(filepath.Join(pluginDir, "pulumi-resource-test"))
+	assert.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\n", string(contents))
+}
+
+func TestMigratePluginWithoutMarker(t *testing.T) {
+	root := newPluginRoot(t)
+	defer os.RemoveAll(root)
+	pluginDir := filepath.Join(root, "resource-test-v1.0.0")
+
+	// A plugin directory holding the plugin's executable but no completion marker, as installed by older versions, is
+	// treated as installed, and is marked complete without a checksum.
+	assert.NoError(t, os.Mkdir(pluginDir, 0700))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(pluginDir, testPlugin.File()), []byte("#!"), 0700))
+	plugins, err := getPlugins(root)
+	assert.NoError(t, err)
+	if assert.Len(t, plugins, 1) {
+		assert.Equal(t, "test", plugins[0].Name)
+	}
+	marker, err := ioutil.ReadFile(filepath.Join(pluginDir, pluginCompleteFile))
+	assert.NoError(t, err)
+	assert.Empty(t, marker)
+
+	// The migration happens only once: from then on, the marker alone decides.
+	assert.NoError(t, os.Remove(filepath.Join(pluginDir, testPlugin.File())))
+	assert.True(t, isPluginComplete(pluginDir, testPlugin))
+}
+
+func TestInstallPluginRemovesAbandonedInstalls(t *testing.T) {
+	root := newPluginRoot(t)
+	defer os.RemoveAll(root)
+	pluginDir := filepath.Join(root, "resource-test-v1.0.0")
+
+	abandoned := filepath.Join(root, pluginTempPrefix+"resource-test-v1.0.0-123")
+	inProgress := filepath.Join(root, pluginTempPrefix+"resource-test-v1.0.0-456")
+	assert.NoError(t, os.Mkdir(abandoned, 0700))
+	assert.NoError(t, os.Mkdir(inProgress, 0700))
+	old := time.Now().Add(-2 * abandonedInstallAge)
+	assert.NoError(t, os.Chtimes(abandoned, old, old))
+
+	assert.NoError(t, installPlugin(pluginDir, ioutil.NopCloser(bytes.NewReader(makePluginTarball(t)))))
+	_, err := os.Stat(abandoned)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(inProgress)
+	assert.NoError(t, err)
+}
+
+func TestInstallPluginConcurrently(t *testing.T) {
+	root := newPluginRoot(t)
+	defer os.RemoveAll(root)
+	pluginDir := filepath.Join(root, "resource-test-v1.0.0")
+
+	tarball := makePluginTarball(t)
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = installPlugin(pluginDir, ioutil.NopCloser(bytes.NewReader(tarball)))
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.True(t, isPluginComplete(pluginDir, testPlugin))
+	assertNoInstallDebris(t, root)
+}
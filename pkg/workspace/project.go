@@ -16,6 +16,7 @@ package workspace
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -32,12 +33,83 @@ import (
 // Analyzers is a list of analyzers to run on this project.
 type Analyzers []tokens.QName
 
+// PolicyConfig holds the settings to pass to each analyzer, keyed by the analyzer's name.
+type PolicyConfig map[tokens.QName]map[string]interface{}
+
+// Merge returns the settings in this config overlaid with those in other.  Settings for the same analyzer are merged
+// key by key, with the values in other taking precedence.
+func (pc PolicyConfig) Merge(other PolicyConfig) PolicyConfig {
+	merged := make(PolicyConfig)
+	for _, src := range []PolicyConfig{pc, other} {
+		for name, settings := range src {
+			if merged[name] == nil {
+				merged[name] = make(map[string]interface{})
+			}
+			for k, v := range settings {
+				merged[name][k] = normalizePolicyValue(v)
+			}
+		}
+	}
+	return merged
+}
+
+// normalizePolicyValue converts the map[interface{}]interface{} values produced by go-yaml into
+// map[string]interface{} values, so that policy settings can be turned into resource properties.
+func normalizePolicyValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{})
+		for k, e := range t {
+			m[fmt.Sprintf("%v", k)] = normalizePolicyValue(e)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{})
+		for k, e := range t {
+			m[k] = normalizePolicyValue(e)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(t))
+		for i, e := range t {
+			a[i] = normalizePolicyValue(e)
+		}
+		return a
+	default:
+		return v
+	}
+}
+
 // ProjectTemplate is a Pulumi project template manifest.
 // nolint: lll
 type ProjectTemplate struct {
 	Description string                                `json:"description,omitempty" yaml:"description,omitempty"` // an optional description of the template.
 	Quickstart  string                                `json:"quickstart,omitempty" yaml:"quickstart,omitempty"`   // optional text to be displayed after template creation.
 	Config      map[string]ProjectTemplateConfigValue `json:"config,omitempty" yaml:"config,omitempty"`           // optional template config.
+	Prompts     []ProjectTemplatePrompt               `json:"prompts,omitempty" yaml:"prompts,omitempty"`         // optional questions whose answers are used to render the template's files.
+	Files       []ProjectTemplateFiles                `json:"files,omitempty" yaml:"files,omitempty"`             // optional rules for including files based on answers.
+	Render      []string                              `json:"render,omitempty" yaml:"render,omitempty"`           // optional slash-separated globs of the files rendered as Go templates; a matching directory renders everything in it.
+}
+
+// ProjectTemplatePrompt is a typed question asked when creating a project from a template.  Its answer is available
+// to the template's rendered files as `{{ .<name> }}`.
+// nolint: lll
+type ProjectTemplatePrompt struct {
+	Name        string   `json:"name" yaml:"name"`                                   // the name of the answer.
+	Type        string   `json:"type,omitempty" yaml:"type,omitempty"`               // string (the default), integer, boolean or enum.
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"` // an optional description shown when asking.
+	Default     string   `json:"default,omitempty" yaml:"default,omitempty"`         // an optional default answer.
+	Enum        []string `json:"enum,omitempty" yaml:"enum,omitempty"`               // the allowed answers for an enum.
+	Pattern     string   `json:"pattern,omitempty" yaml:"pattern,omitempty"`         // an optional regexp that string answers must match.
+	Min         *int     `json:"min,omitempty" yaml:"min,omitempty"`                 // an optional minimum for integer answers.
+	Max         *int     `json:"max,omitempty" yaml:"max,omitempty"`                 // an optional maximum for integer answers.
+}
+
+// ProjectTemplateFiles includes the files matching any of its paths only when its condition holds.
+// nolint: lll
+type ProjectTemplateFiles struct {
+	Paths []string `json:"paths" yaml:"paths"` // slash-separated globs relative to the template; a matching directory includes everything in it.
+	When  string   `json:"when" yaml:"when"`   // a Go text/template, such as `{{ eq .language "go" }}`, that must render to "true".
 }
 
 // ProjectTemplateConfigValue is a config value included in the project template manifest.
@@ -66,7 +138,8 @@ type Project struct {
 	Website     *string `json:"website,omitempty" yaml:"website,omitempty"`         // an optional website for additional info.
 	License     *string `json:"license,omitempty" yaml:"license,omitempty"`         // an optional license governing this project's usage.
 
-	Analyzers *Analyzers `json:"analyzers,omitempty" yaml:"analyzers,omitempty"` // any analyzers enabled for this project.
+	Analyzers *Analyzers   `json:"analyzers,omitempty" yaml:"analyzers,omitempty"` // any analyzers enabled for this project.
+	Policy    PolicyConfig `json:"policy,omitempty" yaml:"policy,omitempty"`       // optional settings for this project's analyzers.
 
 	Context          string `json:"context,omitempty" yaml:"context,omitempty"`                   // an optional path (combined with the on disk location of Pulumi.yaml) to control the data uploaded to the service.
 	NoDefaultIgnores *bool  `json:"nodefaultignores,omitempty" yaml:"nodefaultignores,omitempty"` // true if we should only respect .pulumiignore when archiving
@@ -123,8 +196,9 @@ func (proj *Project) Save(path string) error {
 // ProjectStack holds stack specific information about a project.
 // nolint: lll
 type ProjectStack struct {
-	EncryptionSalt string     `json:"encryptionsalt,omitempty" yaml:"encryptionsalt,omitempty"` // base64 encoded encryption salt.
-	Config         config.Map `json:"config,omitempty" yaml:"config,omitempty"`                 // optional config.
+	EncryptionSalt string       `json:"encryptionsalt,omitempty" yaml:"encryptionsalt,omitempty"` // base64 encoded encryption salt.
+	Config         config.Map   `json:"config,omitempty" yaml:"config,omitempty"`                 // optional config.
+	Policy         PolicyConfig `json:"policy,omitempty" yaml:"policy,omitempty"`                 // optional analyzer settings, overriding the project's.
 }
 
 // Save writes a project definition to a file.
@@ -36,3 +36,42 @@ func TestProjectRuntimeInfoRoundtripYAML(t *testing.T) {
 	doTest(yaml.Marshal, yaml.Unmarshal)
 	doTest(json.Marshal, json.Unmarshal)
 }
+
+func TestPolicyConfigMerge(t *testing.T) {
+	var proj Project
+	err := yaml.Unmarshal([]byte(`
+name: test
+runtime: nodejs
+policy:
+  tags:
+    required: [owner, env]
+    options:
+      strict: false
+  cost:
+    limit: 100
+`), &proj)
+	assert.NoError(t, err)
+
+	var stack ProjectStack
+	err = yaml.Unmarshal([]byte(`
+policy:
+  tags:
+    options:
+      strict: true
+`), &stack)
+	assert.NoError(t, err)
+
+	merged := proj.Policy.Merge(stack.Policy)
+	assert.Equal(t, PolicyConfig{
+		"tags": {
+			"required": []interface{}{"owner", "env"},
+			"options":  map[string]interface{}{"strict": true},
+		},
+		"cost": {
+			"limit": 100,
+		},
+	}, merged)
+
+	// Merging nil configs is fine, too.
+	assert.Equal(t, PolicyConfig{}, PolicyConfig(nil).Merge(nil))
+}
@@ -17,11 +17,11 @@ package workspace
 // Settings defines workspace settings shared amongst many related projects.
 // nolint: lll
 type Settings struct {
-	Stack string `json:"stack,omitempty" yaml:"env,omitempty"` // an optional default stack to use.
+	Stack         string   `json:"stack,omitempty" yaml:"env,omitempty"`                   // an optional default stack to use.
+	PluginMirrors []string `json:"pluginMirrors,omitempty" yaml:"pluginMirrors,omitempty"` // plugin download locations.
 }
 
-// IsEmpty returns true when the settings object is logically empty (no selected stack and nothing in the deprecated
-// configuration bag).
+// IsEmpty returns true when the settings object is logically empty (no selected stack and no plugin mirrors).
 func (s *Settings) IsEmpty() bool {
-	return s.Stack == ""
+	return s.Stack == "" && len(s.PluginMirrors) == 0
 }
@@ -0,0 +1,280 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/encoding"
+)
+
+// The types of answer a template prompt may ask for.
+const (
+	PromptTypeString  = "string"
+	PromptTypeInteger = "integer"
+	PromptTypeBoolean = "boolean"
+	PromptTypeEnum    = "enum"
+)
+
+const (
+	// templateProjectKey and templateDescriptionKey are always available to templated files, alongside the answers.
+	templateProjectKey     = "Project"
+	templateDescriptionKey = "Description"
+)
+
+// promptNameRegexp matches names that can be used as fields in a Go template.
+var promptNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// TemplateAnswers maps the names of a template's prompts to their typed answers.
+type TemplateAnswers map[string]interface{}
+
+// PromptType returns the prompt's type, defaulting to string.
+func (p ProjectTemplatePrompt) PromptType() string {
+	if p.Type == "" {
+		return PromptTypeString
+	}
+	return p.Type
+}
+
+// Validate checks that the prompt is well formed, including its default answer.
+func (p ProjectTemplatePrompt) Validate() error {
+	if !promptNameRegexp.MatchString(p.Name) {
+		return errors.Errorf("prompt name %q must be a letter or underscore followed by letters, digits or underscores",
+			p.Name)
+	}
+	if p.Name == templateProjectKey || p.Name == templateDescriptionKey {
+		return errors.Errorf("prompt name %q is reserved", p.Name)
+	}
+
+	switch p.PromptType() {
+	case PromptTypeString:
+		if p.Pattern != "" {
+			if _, err := regexp.Compile(p.Pattern); err != nil {
+				return errors.Wrapf(err, "prompt %s has an invalid pattern", p.Name)
+			}
+		}
+	case PromptTypeInteger:
+		if p.Min != nil && p.Max != nil && *p.Min > *p.Max {
+			return errors.Errorf("prompt %s has a minimum greater than its maximum", p.Name)
+		}
+	case PromptTypeBoolean:
+	case PromptTypeEnum:
+		if len(p.Enum) == 0 {
+			return errors.Errorf("enum prompt %s has no values", p.Name)
+		}
+	default:
+		return errors.Errorf("prompt %s has unrecognized type %q; expected %s, %s, %s or %s", p.Name, p.Type,
+			PromptTypeString, PromptTypeInteger, PromptTypeBoolean, PromptTypeEnum)
+	}
+
+	if p.Default != "" {
+		if _, err := p.Parse(p.Default); err != nil {
+			return errors.Wrapf(err, "prompt %s has an invalid default", p.Name)
+		}
+	}
+	return nil
+}
+
+// Parse converts an answer to the prompt's type, returning an error if it is not a valid answer.
+func (p ProjectTemplatePrompt) Parse(answer string) (interface{}, error) {
+	switch p.PromptType() {
+	case PromptTypeInteger:
+		i, err := strconv.Atoi(answer)
+		if err != nil {
+			return nil, errors.Errorf("%q is not an integer", answer)
+		}
+		if p.Min != nil && i < *p.Min {
+			return nil, errors.Errorf("%d is less than the minimum of %d", i, *p.Min)
+		}
+		if p.Max != nil && i > *p.Max {
+			return nil, errors.Errorf("%d is greater than the maximum of %d", i, *p.Max)
+		}
+		return i, nil
+	case PromptTypeBoolean:
+		b, err := strconv.ParseBool(answer)
+		if err != nil {
+			return nil, errors.Errorf("%q is not true or false", answer)
+		}
+		return b, nil
+	case PromptTypeEnum:
+		for _, v := range p.Enum {
+			if answer == v {
+				return answer, nil
+			}
+		}
+		return nil, errors.Errorf("%q is not one of %s", answer, strings.Join(p.Enum, ", "))
+	default:
+		if p.Pattern != "" {
+			if matched, err := regexp.MatchString(p.Pattern, answer); err != nil || !matched {
+				return nil, errors.Errorf("%q does not match the pattern %s", answer, p.Pattern)
+			}
+		}
+		return answer, nil
+	}
+}
+
+// validateTemplateManifest checks a template's prompts and file rules.
+func validateTemplateManifest(manifest *ProjectTemplate) error {
+	names := make(map[string]bool)
+	for _, p := range manifest.Prompts {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+		if names[p.Name] {
+			return errors.Errorf("prompt %s is declared more than once", p.Name)
+		}
+		names[p.Name] = true
+	}
+	for _, rule := range manifest.Files {
+		if len(rule.Paths) == 0 {
+			return errors.New("file rules must list at least one path")
+		}
+		for _, pattern := range rule.Paths {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return errors.Wrapf(err, "invalid file rule path %q", pattern)
+			}
+		}
+		if _, err := template.New("when").Parse(rule.When); err != nil {
+			return errors.Wrapf(err, "invalid file rule condition %q", rule.When)
+		}
+	}
+	for _, pattern := range manifest.Render {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Wrapf(err, "invalid render path %q", pattern)
+		}
+	}
+	return nil
+}
+
+// LoadTemplateAnswers reads answers to a template's prompts from a YAML or JSON file mapping names to values.
+func LoadTemplateAnswers(path string) (map[string]string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]interface{}
+	if err = encoding.YAML.Unmarshal(b, &raw); err != nil {
+		return nil, errors.Wrapf(err, "could not parse answers file %s", path)
+	}
+	answers := make(map[string]string)
+	for k, v := range raw {
+		switch v.(type) {
+		case string, bool, int, int64, float64:
+			answers[k] = fmt.Sprint(v)
+		default:
+			return nil, errors.Errorf("answers file %s: the answer to %s must be a string, number or boolean", path, k)
+		}
+	}
+	return answers, nil
+}
+
+// ResolveAnswers answers each of the template's prompts in order, using the provided answer if there is one and
+// otherwise calling ask, which is expected to return an answer that Parse accepts.  Provided answers to prompts the
+// template does not have are an error, since they are most likely typos.
+func (template Template) ResolveAnswers(provided map[string]string,
+	ask func(prompt ProjectTemplatePrompt) (string, error)) (TemplateAnswers, error) {
+
+	answers := make(TemplateAnswers)
+	for _, p := range template.Prompts {
+		answer, has := provided[p.Name]
+		if !has {
+			a, err := ask(p)
+			if err != nil {
+				return nil, err
+			}
+			answer = a
+		}
+		v, err := p.Parse(answer)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid answer to %s", p.Name)
+		}
+		answers[p.Name] = v
+	}
+
+	for name := range provided {
+		if _, has := answers[name]; !has {
+			return nil, errors.Errorf("template %s has no prompt named %s", template.Name, name)
+		}
+	}
+	return answers, nil
+}
+
+// templateData returns the values available to templated files.
+func templateData(answers TemplateAnswers, projectName, projectDescription string) map[string]interface{} {
+	data := map[string]interface{}{
+		templateProjectKey:     projectName,
+		templateDescriptionKey: projectDescription,
+	}
+	for k, v := range answers {
+		data[k] = v
+	}
+	return data
+}
+
+// render executes text as a Go template over data.
+func render(name, text string, data map[string]interface{}) (string, error) {
+	t, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing template file %s", name)
+	}
+	var buf bytes.Buffer
+	if err = t.Execute(&buf, data); err != nil {
+		return "", errors.Wrapf(err, "rendering template file %s", name)
+	}
+	return buf.String(), nil
+}
+
+// includesFile returns true if the file at the given slash-separated path, relative to the template, should be
+// created.  A file is excluded if it, or any directory containing it, matches a rule whose condition does not hold.
+func (template Template) includesFile(rel string, data map[string]interface{}) (bool, error) {
+	for _, rule := range template.Files {
+		if !pathsMatch(rule.Paths, rel) {
+			continue
+		}
+		result, err := render("when", rule.When, data)
+		if err != nil {
+			return false, errors.Wrapf(err, "evaluating the condition for %s", rel)
+		}
+		include, err := strconv.ParseBool(strings.TrimSpace(result))
+		if err != nil {
+			return false, errors.Errorf("the condition for %s rendered %q, which is not true or false", rel, result)
+		}
+		if !include {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// pathsMatch returns true if any of the given globs match rel or one of its parent directories.
+func pathsMatch(patterns []string, rel string) bool {
+	for p := rel; p != "." && p != "/" && p != ""; p = path.Dir(p) {
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, p); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
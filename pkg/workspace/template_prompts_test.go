@@ -0,0 +1,236 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplatePromptParse(t *testing.T) {
+	one, ten := 1, 10
+	count := ProjectTemplatePrompt{Name: "count", Type: PromptTypeInteger, Min: &one, Max: &ten}
+	v, err := count.Parse("3")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+	for _, bad := range []string{"three", "0", "11", ""} {
+		_, err = count.Parse(bad)
+		assert.Error(t, err, bad)
+	}
+
+	region := ProjectTemplatePrompt{Name: "region", Type: PromptTypeEnum, Enum: []string{"us-east-1", "us-west-2"}}
+	v, err = region.Parse("us-west-2")
+	assert.NoError(t, err)
+	assert.Equal(t, "us-west-2", v)
+	_, err = region.Parse("eu-west-1")
+	assert.Error(t, err)
+
+	public := ProjectTemplatePrompt{Name: "public", Type: PromptTypeBoolean}
+	v, err = public.Parse("true")
+	assert.NoError(t, err)
+	assert.Equal(t, true, v)
+	_, err = public.Parse("maybe")
+	assert.Error(t, err)
+
+	team := ProjectTemplatePrompt{Name: "team", Pattern: "^[a-z]+$"}
+	v, err = team.Parse("platform")
+	assert.NoError(t, err)
+	assert.Equal(t, "platform", v)
+	_, err = team.Parse("Platform Team")
+	assert.Error(t, err)
+}
+
+func TestValidateTemplateManifest(t *testing.T) {
+	valid := &ProjectTemplate{
+		Prompts: []ProjectTemplatePrompt{
+			{Name: "region", Type: PromptTypeEnum, Enum: []string{"us-east-1"}, Default: "us-east-1"},
+			{Name: "instance_count", Type: PromptTypeInteger, Default: "2"},
+		},
+		Files: []ProjectTemplateFiles{{Paths: []string{"vpc/*"}, When: "{{ .instance_count }}"}},
+	}
+	assert.NoError(t, validateTemplateManifest(valid))
+
+	invalid := []ProjectTemplate{
+		{Prompts: []ProjectTemplatePrompt{{Name: "my-region"}}},
+		{Prompts: []ProjectTemplatePrompt{{Name: "Project"}}},
+		{Prompts: []ProjectTemplatePrompt{{Name: "region", Type: "list"}}},
+		{Prompts: []ProjectTemplatePrompt{{Name: "region", Type: PromptTypeEnum}}},
+		{Prompts: []ProjectTemplatePrompt{{Name: "count", Type: PromptTypeInteger, Default: "two"}}},
+		{Prompts: []ProjectTemplatePrompt{{Name: "region"}, {Name: "region"}}},
+		{Files: []ProjectTemplateFiles{{When: "true"}}},
+		{Files: []ProjectTemplateFiles{{Paths: []string{"["}, When: "true"}}},
+		{Files: []ProjectTemplateFiles{{Paths: []string{"*.go"}, When: "{{ if }}"}}},
+		{Render: []string{"["}},
+	}
+	for _, manifest := range invalid {
+		m := manifest
+		assert.Error(t, validateTemplateManifest(&m), "%+v", m)
+	}
+}
+
+func TestResolveAnswers(t *testing.T) {
+	template := Template{
+		Name: "test",
+		Prompts: []ProjectTemplatePrompt{
+			{Name: "region", Type: PromptTypeEnum, Enum: []string{"us-east-1", "us-west-2"}},
+			{Name: "count", Type: PromptTypeInteger, Default: "1"},
+		},
+	}
+
+	var asked []string
+	answers, err := template.ResolveAnswers(map[string]string{"region": "us-west-2"},
+		func(p ProjectTemplatePrompt) (string, error) {
+			asked = append(asked, p.Name)
+			return "3", nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"count"}, asked)
+	assert.Equal(t, TemplateAnswers{"region": "us-west-2", "count": 3}, answers)
+
+	noAsk := func(p ProjectTemplatePrompt) (string, error) { return p.Default, nil }
+	_, err = template.ResolveAnswers(map[string]string{"region": "mars-north-1"}, noAsk)
+	assert.Error(t, err)
+	_, err = template.ResolveAnswers(map[string]string{"region": "us-east-1", "regoin": "us-east-1"}, noAsk)
+	assert.Error(t, err)
+}
+
+func TestLoadTemplateAnswers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-answers")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "answers.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("region: us-west-2\ncount: 3\npublic: true\n"), 0600))
+	answers, err := LoadTemplateAnswers(path)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"region": "us-west-2", "count": "3", "public": "true"}, answers)
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte("zones: [a, b]\n"), 0600))
+	_, err = LoadTemplateAnswers(path)
+	assert.Error(t, err)
+}
+
+func writeTemplateFiles(t *testing.T, dir string, files map[string]string) {
+	for name, contents := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+	}
+}
+
+func TestCopyTemplateFilesWithPrompts(t *testing.T) {
+	src, err := ioutil.TempDir("", "pulumi-template")
+	assert.NoError(t, err)
+	defer os.RemoveAll(src)
+	writeTemplateFiles(t, src, map[string]string{
+		"Pulumi.yaml": `name: ${PROJECT}
+runtime: go
+description: ${DESCRIPTION}
+template:
+  prompts:
+  - name: region
+    type: enum
+    enum: [us-east-1, us-west-2]
+  - name: count
+    type: integer
+    default: "1"
+  - name: vpc
+    type: boolean
+    default: "false"
+  files:
+  - paths: [vpc]
+    when: "{{ .vpc }}"
+  - paths: ["*.md"]
+    when: '{{ ne .region "us-east-1" }}'
+  render: [main.go, "*.md"]
+`,
+		"main.go":    "// {{ .Project }}: {{ .Description }}\nconst region = \"{{ .region }}\"\nconst count = {{ .count }}\n",
+		"vpc/vpc.go": "package vpc\n",
+		"README.md":  "# ${PROJECT} in {{ .region }}\n",
+		"index.js":   "// ${PROJECT}\nconst s = `{{ .NotAnAnswer }}`;\n",
+	})
+
+	template, err := LoadTemplate(src)
+	assert.NoError(t, err)
+	assert.Len(t, template.Prompts, 3)
+
+	dest, err := ioutil.TempDir("", "pulumi-project")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dest)
+
+	answers := TemplateAnswers{"region": "us-east-1", "count": 3, "vpc": false}
+	assert.NoError(t, template.CopyTemplateFiles(dest, false, "proj", "my project", answers))
+
+	main, err := ioutil.ReadFile(filepath.Join(dest, "main.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, "// proj: my project\nconst region = \"us-east-1\"\nconst count = 3\n", string(main))
+
+	// Files that the template does not ask to render are copied with only the usual substitutions, even when they
+	// contain text that looks like template syntax.
+	index, err := ioutil.ReadFile(filepath.Join(dest, "index.js"))
+	assert.NoError(t, err)
+	assert.Equal(t, "// proj\nconst s = `{{ .NotAnAnswer }}`;\n", string(index))
+
+	// Excluded files and directories are not created.
+	_, err = os.Stat(filepath.Join(dest, "vpc"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dest, "README.md"))
+	assert.True(t, os.IsNotExist(err))
+
+	// Pulumi.yaml only has the usual substitutions, so its conditions survive intact.
+	proj, err := ioutil.ReadFile(filepath.Join(dest, "Pulumi.yaml"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(proj), `when: "{{ .vpc }}"`)
+	assert.Contains(t, string(proj), "name: proj\n")
+
+	// Different answers include them.
+	dest2, err := ioutil.TempDir("", "pulumi-project")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dest2)
+	answers = TemplateAnswers{"region": "us-west-2", "count": 1, "vpc": true}
+	assert.NoError(t, template.CopyTemplateFiles(dest2, false, "proj", "", answers))
+	readme, err := ioutil.ReadFile(filepath.Join(dest2, "README.md"))
+	assert.NoError(t, err)
+	assert.Equal(t, "# proj in us-west-2\n", string(readme))
+	_, err = os.Stat(filepath.Join(dest2, "vpc", "vpc.go"))
+	assert.NoError(t, err)
+}
+
+func TestCopyTemplateFilesWithoutPrompts(t *testing.T) {
+	src, err := ioutil.TempDir("", "pulumi-template")
+	assert.NoError(t, err)
+	defer os.RemoveAll(src)
+	// Templates without prompts may contain text that looks like Go template syntax; it must be left alone.
+	writeTemplateFiles(t, src, map[string]string{
+		"Pulumi.yaml": "name: ${PROJECT}\nruntime: nodejs\ndescription: ${DESCRIPTION}\n",
+		"index.js":    "// ${PROJECT}\nconst s = `{{ .NotAnAnswer }}`;\n",
+	})
+
+	template, err := LoadTemplate(src)
+	assert.NoError(t, err)
+
+	dest, err := ioutil.TempDir("", "pulumi-project")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dest)
+	assert.NoError(t, template.CopyTemplateFiles(dest, false, "proj", "desc", nil))
+
+	index, err := ioutil.ReadFile(filepath.Join(dest, "index.js"))
+	assert.NoError(t, err)
+	assert.Equal(t, "// proj\nconst s = `{{ .NotAnAnswer }}`;\n", string(index))
+}
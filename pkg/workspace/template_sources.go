@@ -0,0 +1,369 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/gitutil"
+	"github.com/pulumi/pulumi/pkg/util/httputil"
+)
+
+// TemplateRegistryEnvVar names a Git repository or local directory of templates that replaces the public Pulumi
+// templates, both when choosing a template interactively and when looking one up by name.
+const TemplateRegistryEnvVar = "PULUMI_TEMPLATE_REGISTRY"
+
+// templateArchiveExtensions are the archive formats templates can be retrieved from.
+var templateArchiveExtensions = []string{".zip", ".tar.gz", ".tgz", ".tar"}
+
+// IsTemplateArchive returns true if templateNameOrURL names a template archive, either a local file or an HTTP(S)
+// URL, based on its extension.
+func IsTemplateArchive(templateNameOrURL string) bool {
+	lower := strings.ToLower(templateNameOrURL)
+	for _, ext := range templateArchiveExtensions {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTemplatePath returns true if templateNameOrURL looks like a path on the local file system, to a directory or an
+// archive, rather than the name of a template or a URL. Template names never contain a path separator, so any value
+// that does is treated as a path; a bare name can be referred to as a path by prefixing it with "./".
+func IsTemplatePath(templateNameOrURL string) bool {
+	if templateNameOrURL == "" || IsTemplateURL(templateNameOrURL) || isHTTPURL(templateNameOrURL) {
+		return false
+	}
+	return filepath.IsAbs(templateNameOrURL) ||
+		IsTemplateArchive(templateNameOrURL) ||
+		strings.HasPrefix(templateNameOrURL, ".") ||
+		strings.ContainsRune(templateNameOrURL, '/') ||
+		strings.ContainsRune(templateNameOrURL, filepath.Separator)
+}
+
+func isHTTPURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
+// retrieveLocalTemplates returns the "template repository" in a local directory. The directory is used in place and
+// is never deleted.
+func retrieveLocalTemplates(path string) (TemplateRepository, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return TemplateRepository{}, err
+	}
+
+	info, err := os.Stat(abs)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return TemplateRepository{}, errors.Errorf("template directory %s does not exist", path)
+		}
+		return TemplateRepository{}, err
+	}
+	if !info.IsDir() {
+		return TemplateRepository{}, errors.Errorf("%s is neither a directory nor a template archive", path)
+	}
+
+	return TemplateRepository{
+		Root:         abs,
+		SubDirectory: abs,
+		ShouldDelete: false,
+	}, nil
+}
+
+// retrieveArchiveTemplates extracts the template archive at the specified path or URL into a temporary directory.
+func retrieveArchiveTemplates(source string, offline bool) (TemplateRepository, error) {
+	if isHTTPURL(source) && offline {
+		return TemplateRepository{}, errors.Errorf("cannot use %s offline", source)
+	}
+
+	temp, err := ioutil.TempDir("", "pulumi-template-")
+	if err != nil {
+		return TemplateRepository{}, err
+	}
+	repo := TemplateRepository{Root: temp, SubDirectory: temp, ShouldDelete: true}
+
+	archive := source
+	if isHTTPURL(source) {
+		// Archives are downloaded next to, not into, the extraction directory so they aren't mistaken for content.
+		if archive, err = downloadTemplateArchive(source, temp+".download"); err != nil {
+			contract.IgnoreError(repo.Delete())
+			return TemplateRepository{}, err
+		}
+		defer func() {
+			contract.IgnoreError(os.Remove(archive))
+		}()
+	}
+
+	if err = extractTemplateArchive(archive, strings.ToLower(source), temp); err != nil {
+		contract.IgnoreError(repo.Delete())
+		return TemplateRepository{}, errors.Wrapf(err, "extracting template archive %s", source)
+	}
+
+	// Archives of a repository usually wrap everything in a single top-level directory; look inside it.
+	infos, err := ioutil.ReadDir(temp)
+	if err != nil {
+		contract.IgnoreError(repo.Delete())
+		return TemplateRepository{}, err
+	}
+	if len(infos) == 1 && infos[0].IsDir() {
+		repo.SubDirectory = filepath.Join(temp, infos[0].Name())
+	}
+
+	return repo, nil
+}
+
+// downloadTemplateArchive downloads the archive at url to path and returns path.
+func downloadTemplateArchive(url string, path string) (string, error) {
+	resp, err := httputil.GetWithRetry(url, http.DefaultClient)
+	if err != nil {
+		return "", errors.Wrapf(err, "downloading %s", url)
+	}
+	defer contract.IgnoreClose(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("downloading %s: %s", url, resp.Status)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err = io.Copy(f, resp.Body); err != nil {
+		contract.IgnoreClose(f)
+		contract.IgnoreError(os.Remove(path))
+		return "", errors.Wrapf(err, "downloading %s", url)
+	}
+	if err = f.Close(); err != nil {
+		contract.IgnoreError(os.Remove(path))
+		return "", err
+	}
+	return path, nil
+}
+
+// extractTemplateArchive extracts the archive at path into dir. name is used to determine the archive's format.
+func extractTemplateArchive(path string, name string, dir string) error {
+	if strings.HasSuffix(name, ".zip") {
+		return extractTemplateZip(path, dir)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(f)
+
+	var r io.Reader = f
+	if !strings.HasSuffix(name, ".tar") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer contract.IgnoreClose(gz)
+		r = gz
+	}
+	return extractTemplateTar(r, dir)
+}
+
+func extractTemplateZip(path string, dir string) error {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(zr)
+
+	for _, file := range zr.File {
+		dest, err := templateArchiveEntryPath(dir, file.Name)
+		if err != nil {
+			return err
+		}
+
+		mode := file.Mode()
+		switch {
+		case mode.IsDir():
+			if err = os.MkdirAll(dest, 0700); err != nil {
+				return err
+			}
+		case mode.IsRegular():
+			src, err := file.Open()
+			if err != nil {
+				return err
+			}
+			err = writeTemplateArchiveFile(dest, src, mode.Perm())
+			contract.IgnoreClose(src)
+			if err != nil {
+				return err
+			}
+		default:
+			return errors.Errorf("%s: unsupported file type in template archive", file.Name)
+		}
+	}
+	return nil
+}
+
+func extractTemplateTar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		// Skip the global headers GitHub and `git archive` add to tarballs.
+		if header.Typeflag == tar.TypeXGlobalHeader {
+			continue
+		}
+
+		dest, err := templateArchiveEntryPath(dir, header.Name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(dest, 0700); err != nil {
+				return err
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			if err = writeTemplateArchiveFile(dest, tr, os.FileMode(header.Mode).Perm()); err != nil {
+				return err
+			}
+		default:
+			return errors.Errorf("%s: unsupported file type in template archive", header.Name)
+		}
+	}
+}
+
+// templateArchiveEntryPath returns the path an archive entry extracts to, refusing entries that would land outside
+// of dir.
+func templateArchiveEntryPath(dir string, name string) (string, error) {
+	rel := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("%s: template archive entry is outside of the archive", name)
+	}
+	return filepath.Join(dir, rel), nil
+}
+
+func writeTemplateArchiveFile(dest string, src io.Reader, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0700); err != nil {
+		return err
+	}
+
+	// Never create files that their owner can't read and write.
+	f, err := os.OpenFile(dest, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm|0600)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, src); err != nil {
+		contract.IgnoreClose(f)
+		return err
+	}
+	return f.Close()
+}
+
+// retrieveRegistryTemplates retrieves the "template repository" for the template registry named by
+// PULUMI_TEMPLATE_REGISTRY. A local registry is used in place; a Git registry is cloned into a cache directory next
+// to the Pulumi templates and kept up to date like them.
+func retrieveRegistryTemplates(registry string, templateName string, offline bool) (TemplateRepository, error) {
+	if !IsTemplateURL(registry) {
+		repo, err := retrieveLocalTemplates(registry)
+		if err != nil {
+			return TemplateRepository{}, errors.Wrapf(err, "reading %s", TemplateRegistryEnvVar)
+		}
+		return findTemplate(repo, templateName)
+	}
+
+	url, urlPath, err := gitutil.ParseGitRepoURL(registry)
+	if err != nil {
+		return TemplateRepository{}, errors.Wrapf(err, "parsing %s", TemplateRegistryEnvVar)
+	}
+
+	templateDir, err := getTemplateRegistryDir(registry)
+	if err != nil {
+		return TemplateRepository{}, err
+	}
+	if err = os.MkdirAll(templateDir, 0700); err != nil {
+		return TemplateRepository{}, err
+	}
+
+	// Offline, the sub directory can't be told apart from a branch name without asking the remote, so only the
+	// simple forms of registry URL are usable.
+	ref, subDirectory := plumbing.HEAD, urlPath
+	if !offline {
+		var commit plumbing.Hash
+		ref, commit, subDirectory, err = gitutil.GetGitReferenceNameOrHashAndSubDirectory(url, urlPath)
+		if err != nil {
+			return TemplateRepository{}, err
+		}
+		if ref == "" {
+			return TemplateRepository{}, errors.Errorf(
+				"%s must refer to a branch or tag, not commit %s", TemplateRegistryEnvVar, commit)
+		}
+		if err = gitutil.GitCloneOrPull(url, ref, templateDir, false /*shallow*/); err != nil {
+			return TemplateRepository{}, errors.Wrapf(err, "updating template registry %s", registry)
+		}
+	}
+
+	return findTemplate(TemplateRepository{
+		Root:         templateDir,
+		SubDirectory: filepath.Join(templateDir, filepath.FromSlash(subDirectory)),
+		ShouldDelete: false,
+	}, templateName)
+}
+
+// getTemplateRegistryDir returns the directory a Git template registry is cached in. Each registry gets its own
+// directory so that switching between registries never mixes up their templates.
+func getTemplateRegistryDir(registry string) (string, error) {
+	templateDir, err := GetTemplateDir()
+	if err != nil {
+		return "", err
+	}
+	return templateDir + "-" + sha1HexString(registry)[:12], nil
+}
+
+// findTemplate narrows repo down to the named template, if any.
+func findTemplate(repo TemplateRepository, templateName string) (TemplateRepository, error) {
+	if templateName == "" {
+		return repo, nil
+	}
+
+	subDir := filepath.Join(repo.SubDirectory, templateName)
+
+	// Provide a nicer error message when the template can't be found (dir doesn't exist).
+	_, err := os.Stat(subDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return TemplateRepository{}, newTemplateNotFoundError(repo.SubDirectory, templateName)
+		}
+		contract.IgnoreError(err)
+	}
+
+	repo.SubDirectory = subDir
+	return repo, nil
+}
@@ -0,0 +1,211 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestTemplate(t *testing.T, dir string, name string) {
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, name), 0700))
+	err := ioutil.WriteFile(filepath.Join(dir, name, "Pulumi.yaml"),
+		[]byte("name: "+name+"\nruntime: nodejs\ndescription: "+name+" template\n"), 0600)
+	assert.NoError(t, err)
+}
+
+func templateNames(t *testing.T, repo TemplateRepository) []string {
+	templates, err := repo.Templates()
+	assert.NoError(t, err)
+	var names []string
+	for _, template := range templates {
+		names = append(names, template.Name)
+	}
+	return names
+}
+
+func TestIsTemplatePath(t *testing.T) {
+	assert.True(t, IsTemplatePath("./aws-typescript"))
+	assert.True(t, IsTemplatePath("../templates"))
+	assert.True(t, IsTemplatePath("templates/aws-typescript"))
+	assert.True(t, IsTemplatePath(filepath.Join(os.TempDir(), "templates")))
+	assert.False(t, IsTemplatePath("aws-typescript"))
+	assert.False(t, IsTemplatePath(""))
+	assert.False(t, IsTemplatePath("https://github.com/pulumi/templates/aws-typescript"))
+	assert.False(t, IsTemplatePath("git@github.com:pulumi/templates.git"))
+	assert.True(t, IsTemplatePath("templates.zip"))
+	assert.False(t, IsTemplatePath("https://example.com/templates.zip"))
+
+	assert.True(t, IsTemplateArchive("templates.zip"))
+	assert.True(t, IsTemplateArchive("https://example.com/templates.TAR.GZ"))
+	assert.True(t, IsTemplateArchive("templates.tgz"))
+	assert.False(t, IsTemplateArchive("aws-typescript"))
+
+	assert.True(t, IsTemplateURL("git@github.com:pulumi/templates.git"))
+	assert.True(t, IsTemplateURL("ssh://git@github.com/pulumi/templates.git"))
+	assert.False(t, IsTemplateURL("./templates"))
+}
+
+func TestRetrieveLocalTemplates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-templates-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	writeTestTemplate(t, dir, "first")
+	writeTestTemplate(t, dir, "second")
+
+	repo, err := RetrieveTemplates(dir, false)
+	assert.NoError(t, err)
+	assert.False(t, repo.ShouldDelete)
+	assert.Equal(t, []string{"first", "second"}, templateNames(t, repo))
+
+	repo, err = RetrieveTemplates(filepath.Join(dir, "second"), false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"second"}, templateNames(t, repo))
+
+	// Deleting a local repository must leave it alone.
+	assert.NoError(t, repo.Delete())
+	_, err = os.Stat(filepath.Join(dir, "second", "Pulumi.yaml"))
+	assert.NoError(t, err)
+
+	_, err = RetrieveTemplates(filepath.Join(dir, "missing"), false)
+	assert.Error(t, err)
+	_, err = RetrieveTemplates(filepath.Join(dir, "second", "Pulumi.yaml"), false)
+	assert.Error(t, err)
+}
+
+func TestRetrieveArchiveTemplates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-templates-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"templates-master/first/Pulumi.yaml":  "name: first\nruntime: nodejs\n",
+		"templates-master/first/index.js":     "// first\n",
+		"templates-master/second/Pulumi.yaml": "name: second\nruntime: python\n",
+	}
+
+	// A zip archive with a single top-level directory.
+	zipPath := filepath.Join(dir, "templates.zip")
+	f, err := os.Create(zipPath)
+	assert.NoError(t, err)
+	zw := zip.NewWriter(f)
+	for name, contents := range files {
+		w, createErr := zw.Create(name)
+		assert.NoError(t, createErr)
+		_, createErr = w.Write([]byte(contents))
+		assert.NoError(t, createErr)
+	}
+	assert.NoError(t, zw.Close())
+	assert.NoError(t, f.Close())
+
+	repo, err := RetrieveTemplates(zipPath, false)
+	assert.NoError(t, err)
+	assert.True(t, repo.ShouldDelete)
+	assert.Equal(t, []string{"first", "second"}, templateNames(t, repo))
+	assert.NoError(t, repo.Delete())
+	_, err = os.Stat(repo.Root)
+	assert.True(t, os.IsNotExist(err))
+
+	// A gzipped tarball.
+	tgzPath := filepath.Join(dir, "templates.tar.gz")
+	writeTestTarball(t, tgzPath, files)
+
+	repo, err = RetrieveTemplates(tgzPath, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, templateNames(t, repo))
+	contents, err := ioutil.ReadFile(filepath.Join(repo.SubDirectory, "first", "index.js"))
+	assert.NoError(t, err)
+	assert.Equal(t, "// first\n", string(contents))
+	assert.NoError(t, repo.Delete())
+}
+
+func TestRetrieveArchiveTemplatesRejectsTraversal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-templates-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	tgzPath := filepath.Join(dir, "evil.tgz")
+	writeTestTarball(t, tgzPath, map[string]string{"../../escaped": "boom"})
+
+	_, err = RetrieveTemplates(tgzPath, false)
+	assert.Error(t, err)
+	_, err = os.Stat(filepath.Join(dir, "..", "escaped"))
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = templateArchiveEntryPath(dir, "/etc/passwd")
+	assert.Error(t, err)
+	p, err := templateArchiveEntryPath(dir, "a/../b")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "b"), p)
+}
+
+func TestRetrieveRegistryTemplates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-templates-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	writeTestTemplate(t, dir, "internal-service")
+
+	defer os.Setenv(TemplateRegistryEnvVar, os.Getenv(TemplateRegistryEnvVar))
+	assert.NoError(t, os.Setenv(TemplateRegistryEnvVar, dir))
+
+	repo, err := RetrieveTemplates("", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"internal-service"}, templateNames(t, repo))
+
+	repo, err = RetrieveTemplates("Internal-Service", true)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "internal-service"), repo.SubDirectory)
+
+	_, err = RetrieveTemplates("aws-typescript", true)
+	assert.Error(t, err)
+}
+
+func TestGetTemplateRegistryDir(t *testing.T) {
+	a, err := getTemplateRegistryDir("https://github.com/acme/templates")
+	assert.NoError(t, err)
+	b, err := getTemplateRegistryDir("git@github.com:acme/templates.git")
+	assert.NoError(t, err)
+	templateDir, err := GetTemplateDir()
+	assert.NoError(t, err)
+	assert.NotEqual(t, a, b)
+	assert.NotEqual(t, templateDir, a)
+}
+
+func writeTestTarball(t *testing.T, path string, files map[string]string) {
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, contents := range files {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(contents)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err = tw.Write([]byte(contents))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gz.Close())
+	assert.NoError(t, f.Close())
+}
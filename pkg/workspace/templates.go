@@ -119,6 +119,9 @@ type Template struct {
 	Description string                                // Description of the template.
 	Quickstart  string                                // Optional text to be displayed after template creation.
 	Config      map[string]ProjectTemplateConfigValue // Optional template config.
+	Prompts     []ProjectTemplatePrompt               // Optional questions whose answers render the files.
+	Files       []ProjectTemplateFiles                // Optional rules for including files based on answers.
+	Render      []string                              // Optional globs of the files rendered as Go templates.
 
 	ProjectName        string // Name of the project.
 	ProjectDescription string // Optional description of the project.
@@ -145,15 +148,21 @@ func cleanupLegacyTemplateDir() error {
 	return nil
 }
 
-// IsTemplateURL returns true if templateNameOrURL starts with "https://".
+// IsTemplateURL returns true if templateNameOrURL starts with "https://" or is a Git SSH URL.
 func IsTemplateURL(templateNameOrURL string) bool {
-	return strings.HasPrefix(templateNameOrURL, "https://")
+	return strings.HasPrefix(templateNameOrURL, "https://") || gitutil.IsGitSSHURL(templateNameOrURL)
 }
 
-// RetrieveTemplates retrieves a "template repository" based on the specified name or URL.
+// RetrieveTemplates retrieves a "template repository" based on the specified name, URL, or path. Besides the names
+// of Pulumi templates, it accepts Git URLs, local directories, and .zip or .tar.gz archives given as a path or URL.
 func RetrieveTemplates(templateNameOrURL string, offline bool) (TemplateRepository, error) {
-	if IsTemplateURL(templateNameOrURL) {
+	switch {
+	case IsTemplateArchive(templateNameOrURL) && !gitutil.IsGitSSHURL(templateNameOrURL):
+		return retrieveArchiveTemplates(templateNameOrURL, offline)
+	case IsTemplateURL(templateNameOrURL):
 		return retrieveURLTemplates(templateNameOrURL, offline)
+	case IsTemplatePath(templateNameOrURL):
+		return retrieveLocalTemplates(templateNameOrURL)
 	}
 	return retrievePulumiTemplates(templateNameOrURL, offline)
 }
@@ -186,10 +195,14 @@ func retrieveURLTemplates(rawurl string, offline bool) (TemplateRepository, erro
 
 // retrievePulumiTemplates retrieves the "template repository" for Pulumi templates.
 // Instead of retrieving to a temporary directory, the Pulumi templates are managed from
-// ~/.pulumi/templates.
+// ~/.pulumi/templates. If PULUMI_TEMPLATE_REGISTRY is set, its templates are used instead.
 func retrievePulumiTemplates(templateName string, offline bool) (TemplateRepository, error) {
 	templateName = strings.ToLower(templateName)
 
+	if registry := os.Getenv(TemplateRegistryEnvVar); registry != "" {
+		return retrieveRegistryTemplates(registry, templateName, offline)
+	}
+
 	// Cleanup the template directory.
 	if err := cleanupLegacyTemplateDir(); err != nil {
 		return TemplateRepository{}, err
@@ -207,32 +220,18 @@ func retrievePulumiTemplates(templateName string, offline bool) (TemplateReposit
 	}
 
 	if !offline {
-		// Clone or update the pulumi/templates repo.
-		err := gitutil.GitCloneOrPull(pulumiTemplateGitRepository, plumbing.HEAD, templateDir, false /*shallow*/)
+		// Clone or update the pulumi/templates repo.  It is public, so it is never sent credentials.
+		err := gitutil.GitCloneOrPullPublic(pulumiTemplateGitRepository, plumbing.HEAD, templateDir, false /*shallow*/)
 		if err != nil {
 			return TemplateRepository{}, err
 		}
 	}
 
-	subDir := templateDir
-	if templateName != "" {
-		subDir = filepath.Join(subDir, templateName)
-
-		// Provide a nicer error message when the template can't be found (dir doesn't exist).
-		_, err := os.Stat(subDir)
-		if err != nil {
-			if os.IsNotExist(err) {
-				return TemplateRepository{}, newTemplateNotFoundError(templateDir, templateName)
-			}
-			contract.IgnoreError(err)
-		}
-	}
-
-	return TemplateRepository{
+	return findTemplate(TemplateRepository{
 		Root:         templateDir,
-		SubDirectory: subDir,
+		SubDirectory: templateDir,
 		ShouldDelete: false,
-	}, nil
+	}, templateName)
 }
 
 // RetrieveTemplate downloads the repo to path and returns the full path on disk.
@@ -296,6 +295,12 @@ func LoadTemplate(path string) (Template, error) {
 		template.Description = proj.Template.Description
 		template.Quickstart = proj.Template.Quickstart
 		template.Config = proj.Template.Config
+		template.Prompts = proj.Template.Prompts
+		template.Files = proj.Template.Files
+		template.Render = proj.Template.Render
+		if err = validateTemplateManifest(proj.Template); err != nil {
+			return Template{}, errors.Wrapf(err, "template %s", template.Name)
+		}
 	}
 	if proj.Description != nil {
 		template.ProjectDescription = *proj.Description
@@ -305,7 +310,8 @@ func LoadTemplate(path string) (Template, error) {
 }
 
 // CopyTemplateFilesDryRun does a dry run of copying a template to a destination directory,
-// to ensure it won't overwrite any files.
+// to ensure it won't overwrite any files.  Since it runs before the template's prompts have been
+// answered, it conservatively checks every file, including those a file rule may later exclude.
 func (template Template) CopyTemplateFilesDryRun(destDir string) error {
 	var existing []string
 	if err := walkFiles(template.Dir, destDir, func(info os.FileInfo, source string, dest string) error {
@@ -323,11 +329,25 @@ func (template Template) CopyTemplateFilesDryRun(destDir string) error {
 	return nil
 }
 
-// CopyTemplateFiles does the actual copy operation to a destination directory.
+// CopyTemplateFiles does the actual copy operation to a destination directory.  If the template has prompts, the
+// answers decide which files are included.  In every text file, ${PROJECT} and ${DESCRIPTION} are replaced; those that
+// match the template's `render` globs, other than Pulumi.yaml, are then also rendered as a Go text/template with the
+// answers, `.Project` and `.Description` available to it.  Other files are left alone, so that text which merely
+// looks like template syntax is copied as is.
 func (template Template) CopyTemplateFiles(
-	destDir string, force bool, projectName string, projectDescription string) error {
+	destDir string, force bool, projectName string, projectDescription string, answers TemplateAnswers) error {
 
+	data := templateData(answers, projectName, projectDescription)
 	return walkFiles(template.Dir, destDir, func(info os.FileInfo, source string, dest string) error {
+		rel, err := filepath.Rel(template.Dir, source)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if include, includeErr := template.includesFile(rel, data); includeErr != nil || !include {
+			return includeErr
+		}
+
 		if info.IsDir() {
 			// Create the destination directory.
 			return os.Mkdir(dest, 0700)
@@ -343,6 +363,11 @@ func (template Template) CopyTemplateFiles(
 		result := b
 		if !isBinary(b) {
 			transformed := transform(string(b), projectName, projectDescription)
+			if rel != "Pulumi.yaml" && pathsMatch(template.Render, rel) {
+				if transformed, err = render(rel, transformed, data); err != nil {
+					return err
+				}
+			}
 			result = []byte(transformed)
 		}
 
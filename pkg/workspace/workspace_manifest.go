@@ -0,0 +1,284 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/encoding"
+	"github.com/pulumi/pulumi/pkg/graph"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/fsutil"
+)
+
+// WorkspaceManifestFile is the base name of the manifest that lists the projects in a multi-project repository. It
+// is deliberately not of the form Pulumi.<name>.yaml, which is reserved for stack settings.
+const WorkspaceManifestFile = "PulumiWorkspace"
+
+// WorkspaceManifest lists the Pulumi projects in a repository and the dependencies between them, so that their
+// stacks can be deployed together in dependency order.
+type WorkspaceManifest struct {
+	Projects []*WorkspaceProject `json:"projects" yaml:"projects"` // the projects in the workspace.
+
+	Dir string `json:"-" yaml:"-"` // the directory containing the manifest.
+}
+
+// WorkspaceProject is a single project within a workspace manifest.
+// nolint: lll
+type WorkspaceProject struct {
+	Path      string   `json:"path" yaml:"path"`                               // the project's directory, relative to the manifest.
+	Stack     string   `json:"stack,omitempty" yaml:"stack,omitempty"`         // an optional stack to use instead of the project's selected stack.
+	DependsOn []string `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"` // the names of projects whose stacks must be deployed first.
+
+	Name string `json:"-" yaml:"-"` // the project's name, read from its project file.
+	Dir  string `json:"-" yaml:"-"` // the project's absolute directory.
+}
+
+// DetectWorkspaceManifestPath locates the closest workspace manifest from the given path, searching "upwards" in the
+// directory hierarchy.  If no manifest is found, an empty path is returned.
+func DetectWorkspaceManifestPath(path string) (string, error) {
+	return fsutil.WalkUp(path, isWorkspaceManifest, nil)
+}
+
+func isWorkspaceManifest(path string) bool {
+	return isMarkupFile(path, WorkspaceManifestFile)
+}
+
+// DetectWorkspaceManifest loads the closest workspace manifest from the current working directory, or an error if
+// not found.
+func DetectWorkspaceManifest() (*WorkspaceManifest, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := DetectWorkspaceManifestPath(cwd)
+	if err != nil {
+		return nil, err
+	} else if path == "" {
+		return nil, errors.Errorf("no %s.yaml found in the current working directory or its parents",
+			WorkspaceManifestFile)
+	}
+
+	return LoadWorkspaceManifest(path)
+}
+
+// LoadWorkspaceManifest reads a workspace manifest, along with the project file of each project it lists, and
+// validates the dependencies between them.
+func LoadWorkspaceManifest(path string) (*WorkspaceManifest, error) {
+	contract.Require(path != "", "path")
+
+	m, err := marshallerForPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest WorkspaceManifest
+	if err = m.Unmarshal(b, &manifest); err != nil {
+		return nil, errors.Wrapf(err, "reading %s", path)
+	}
+	if manifest.Dir, err = filepath.Abs(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+
+	if err = manifest.load(); err != nil {
+		return nil, errors.Wrapf(err, "reading %s", path)
+	}
+	return &manifest, nil
+}
+
+// load fills in the name and directory of each project and validates the manifest.
+func (m *WorkspaceManifest) load() error {
+	if len(m.Projects) == 0 {
+		return errors.New("no projects listed")
+	}
+
+	byName := make(map[string]*WorkspaceProject)
+	for _, p := range m.Projects {
+		if p == nil || p.Path == "" {
+			return errors.New("every project must have a path")
+		}
+
+		p.Dir = filepath.Join(m.Dir, filepath.FromSlash(p.Path))
+		projPath := findProjectFile(p.Dir)
+		if projPath == "" {
+			return errors.Errorf("no Pulumi project found in %s", p.Path)
+		}
+
+		proj, err := LoadProject(projPath)
+		if err != nil {
+			return errors.Wrapf(err, "loading project %s", p.Path)
+		}
+		p.Name = proj.Name.String()
+
+		if other, has := byName[p.Name]; has {
+			return errors.Errorf("projects %s and %s are both named %s", other.Path, p.Path, p.Name)
+		}
+		byName[p.Name] = p
+	}
+
+	for _, p := range m.Projects {
+		for _, dep := range p.DependsOn {
+			if _, has := byName[dep]; !has {
+				return errors.Errorf("project %s depends on unknown project %s", p.Name, dep)
+			}
+		}
+	}
+
+	_, err := m.Sort()
+	return err
+}
+
+// findProjectFile returns the path of the project file in dir, or an empty path if there is none.
+func findProjectFile(dir string) string {
+	for _, ext := range encoding.Exts {
+		if path := filepath.Join(dir, ProjectFile+ext); isProject(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// Find returns the project with the given name, or nil if there is none.
+func (m *WorkspaceManifest) Find(name string) *WorkspaceProject {
+	for _, p := range m.Projects {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// Dependents returns the names of the projects that depend directly on the named project, sorted by name.
+func (m *WorkspaceManifest) Dependents(name string) []string {
+	var dependents []string
+	for _, p := range m.Projects {
+		for _, dep := range p.DependsOn {
+			if dep == name {
+				dependents = append(dependents, p.Name)
+				break
+			}
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
+
+// Sort returns the manifest's projects in dependency order: every project comes after all of the projects it depends
+// on. Projects that don't depend on each other keep the order they are listed in.
+func (m *WorkspaceManifest) Sort() ([]*WorkspaceProject, error) {
+	vertices := make(map[string]*workspaceProjectVertex)
+	var roots []graph.Edge
+	for _, p := range m.Projects {
+		v := &workspaceProjectVertex{project: p}
+		vertices[p.Name] = v
+		roots = append(roots, &workspaceProjectEdge{to: v})
+	}
+	for _, p := range m.Projects {
+		from := vertices[p.Name]
+		for _, dep := range p.DependsOn {
+			to := vertices[dep]
+			edge := &workspaceProjectEdge{from: from, to: to}
+			from.outs = append(from.outs, edge)
+			to.ins = append(to.ins, edge)
+		}
+	}
+
+	sorted, err := graph.Topsort(&workspaceProjectGraph{roots: roots})
+	if err != nil {
+		return nil, errors.New("the dependencies between projects form a cycle")
+	}
+
+	projects := make([]*WorkspaceProject, len(sorted))
+	for i, v := range sorted {
+		projects[i] = v.Data().(*WorkspaceProject)
+	}
+	return projects, nil
+}
+
+// The types below implement the interfaces in pkg/graph for the dependencies between workspace projects. An edge
+// leads from a project to a project it depends on.
+
+type workspaceProjectGraph struct {
+	roots []graph.Edge
+}
+
+func (g *workspaceProjectGraph) Roots() []graph.Edge {
+	return g.roots
+}
+
+type workspaceProjectVertex struct {
+	project *WorkspaceProject
+	ins     []graph.Edge
+	outs    []graph.Edge
+}
+
+func (v *workspaceProjectVertex) Data() interface{} {
+	return v.project
+}
+
+func (v *workspaceProjectVertex) Label() string {
+	return v.project.Name
+}
+
+func (v *workspaceProjectVertex) Ins() []graph.Edge {
+	return v.ins
+}
+
+func (v *workspaceProjectVertex) Outs() []graph.Edge {
+	return v.outs
+}
+
+func (v *workspaceProjectVertex) Color() string {
+	return ""
+}
+
+type workspaceProjectEdge struct {
+	from *workspaceProjectVertex
+	to   *workspaceProjectVertex
+}
+
+func (e *workspaceProjectEdge) Data() interface{} {
+	return nil
+}
+
+func (e *workspaceProjectEdge) Label() string {
+	return ""
+}
+
+func (e *workspaceProjectEdge) To() graph.Vertex {
+	return e.to
+}
+
+func (e *workspaceProjectEdge) From() graph.Vertex {
+	if e.from == nil {
+		return nil
+	}
+	return e.from
+}
+
+func (e *workspaceProjectEdge) Color() string {
+	return ""
+}
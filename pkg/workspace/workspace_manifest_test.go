@@ -0,0 +1,118 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTestWorkspace creates a workspace manifest with the given contents, along with a project for each name in
+// projects, in a directory of the same name. Each project is named after the last element of its directory.
+func writeTestWorkspace(t *testing.T, manifest string, projects ...string) string {
+	dir, err := ioutil.TempDir("", "pulumi-workspace-test")
+	assert.NoError(t, err)
+	for _, name := range projects {
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, name), 0700))
+		err = ioutil.WriteFile(filepath.Join(dir, name, "Pulumi.yaml"),
+			[]byte("name: "+filepath.Base(name)+"-project\nruntime: nodejs\n"), 0600)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "PulumiWorkspace.yaml"), []byte(manifest), 0600))
+	return dir
+}
+
+func projectNames(projects []*WorkspaceProject) []string {
+	var names []string
+	for _, p := range projects {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func TestLoadWorkspaceManifest(t *testing.T) {
+	dir := writeTestWorkspace(t, `projects:
+  - path: app
+    stack: prod
+    dependsOn: [database-project, network-project]
+  - path: database
+    dependsOn: [network-project]
+  - path: network
+  - path: docs
+`, "app", "database", "network", "docs")
+	defer os.RemoveAll(dir)
+
+	// The manifest is found from any directory beneath it.
+	path, err := DetectWorkspaceManifestPath(filepath.Join(dir, "app"))
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "PulumiWorkspace.yaml"), path)
+
+	m, err := LoadWorkspaceManifest(path)
+	assert.NoError(t, err)
+	assert.Len(t, m.Projects, 4)
+	app := m.Find("app-project")
+	if assert.NotNil(t, app) {
+		assert.Equal(t, filepath.Join(dir, "app"), app.Dir)
+		assert.Equal(t, "prod", app.Stack)
+	}
+	assert.Nil(t, m.Find("app"))
+
+	sorted, err := m.Sort()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"network-project", "database-project", "app-project", "docs-project"},
+		projectNames(sorted))
+
+	assert.Equal(t, []string{"app-project", "database-project"}, m.Dependents("network-project"))
+	assert.Empty(t, m.Dependents("app-project"))
+}
+
+func TestLoadWorkspaceManifestErrors(t *testing.T) {
+	test := func(manifest string, projects ...string) error {
+		dir := writeTestWorkspace(t, manifest, projects...)
+		defer os.RemoveAll(dir)
+		_, err := LoadWorkspaceManifest(filepath.Join(dir, "PulumiWorkspace.yaml"))
+		return err
+	}
+
+	// No projects.
+	assert.Error(t, test("projects: []\n"))
+
+	// Missing project.
+	assert.Error(t, test("projects:\n  - path: missing\n"))
+
+	// Unknown dependency.
+	assert.Error(t, test("projects:\n  - path: a\n    dependsOn: [b-project]\n", "a"))
+
+	// Two projects with the same name.
+	err := test("projects:\n  - path: a\n  - path: nested/a\n", "a", "nested/a")
+	assert.Error(t, err)
+
+	// Cycles.
+	err = test(`projects:
+  - path: a
+    dependsOn: [b-project]
+  - path: b
+    dependsOn: [c-project]
+  - path: c
+    dependsOn: [a-project]
+`, "a", "b", "c")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "cycle")
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+// buildCacheDir is the name of the directory, within the Pulumi bookkeeping directory, in which built programs are
+// cached.
+const buildCacheDir = "go-build"
+
+// keptBuilds is the number of most recently built or used binaries of each project that are kept in the build cache.
+// More than one is kept so that a binary is not removed from under a concurrent run of an older version of the program.
+const keptBuilds = 3
+
+// minGoMinorVersion is the earliest minor version of Go 1 whose toolchain supports `go list -deps`, which is needed to
+// find the program's dependencies.
+const minGoMinorVersion = 11
+
+// buildError is returned when a program fails to compile, or the installed toolchain cannot compile it.  Its output is
+// the compiler's output, which is reported to the engine as a diagnostic.
+type buildError struct {
+	output string
+}
+
+func (err *buildError) Error() string {
+	return "failed to build the Go program"
+}
+
+// getBuildCacheDir returns the directory in which built programs are cached.
+func getBuildCacheDir() (string, error) {
+	u, err := user.Current()
+	if u == nil || err != nil {
+		return "", errors.Wrap(err, "getting user home directory")
+	}
+	return filepath.Join(u.HomeDir, workspace.BookkeepingDir, buildCacheDir), nil
+}
+
+// buildProgram compiles the Go program in the given directory into the given build cache directory, returning the
+// path to the resulting binary.  Binaries are keyed by a hash of the program's sources, those of its dependencies,
+// and the toolchain, so a program is only rebuilt when one of them changes; when it is, all but the most recently
+// built or used binaries of the same project are removed.
+func buildProgram(project string, dir string, cacheDir string) (string, error) {
+	hash, err := hashProgramSources(dir)
+	if err != nil {
+		if _, ok := err.(*buildError); ok {
+			return "", err
+		}
+		return "", errors.Wrap(err, "hashing program sources")
+	}
+
+	prefix := sanitizeBinaryName(project) + "-"
+	suffix := ""
+	if runtime.GOOS == "windows" {
+		suffix = ".exe"
+	}
+	binary := filepath.Join(cacheDir, prefix+hash[:16]+suffix)
+	if info, statErr := os.Stat(binary); statErr == nil && !info.IsDir() {
+		logging.V(5).Infof("using cached build of %s: %s", project, binary)
+		now := time.Now()
+		contract.IgnoreError(os.Chtimes(binary, now, now))
+		return binary, nil
+	}
+
+	if err = os.MkdirAll(cacheDir, 0700); err != nil {
+		return "", errors.Wrap(err, "creating build cache directory")
+	}
+
+	// Build into a temporary file and then rename it into place, so that a concurrent or interrupted build never
+	// leaves a partial binary where a later run would find it.
+	tmp, err := ioutil.TempFile(cacheDir, prefix+"build-")
+	if err != nil {
+		return "", errors.Wrap(err, "creating temporary build output")
+	}
+	contract.IgnoreClose(tmp)
+	defer func() { contract.IgnoreError(os.Remove(tmp.Name())) }()
+
+	logging.V(5).Infof("building %s from %s", project, dir)
+	var output bytes.Buffer
+	cmd := exec.Command("go", "build", "-o", tmp.Name(), ".")
+	cmd.Dir = dir
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err = cmd.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return "", &buildError{output: strings.TrimSpace(output.String())}
+		}
+		return "", errors.Wrap(err, "running 'go build'")
+	}
+	if err = os.Rename(tmp.Name(), binary); err != nil {
+		return "", errors.Wrap(err, "moving built program into the build cache")
+	}
+
+	pruneBuilds(cacheDir, prefix, suffix, keptBuilds)
+	return binary, nil
+}
+
+// pruneBuilds removes all but the given number of most recently modified binaries with the given prefix and suffix
+// from the build cache.  Only names with exactly the shape of those built by buildProgram are considered, so that
+// binaries of projects whose names merely begin with this one's are left alone.
+func pruneBuilds(cacheDir, prefix, suffix string, keep int) {
+	paths, err := filepath.Glob(filepath.Join(cacheDir, prefix+strings.Repeat("?", 16)+suffix))
+	if err != nil {
+		return
+	}
+
+	var builds []os.FileInfo
+	for _, path := range paths {
+		key := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), prefix), suffix)
+		if _, hexErr := hex.DecodeString(key); hexErr != nil {
+			continue
+		}
+		if info, statErr := os.Stat(path); statErr == nil && !info.IsDir() {
+			builds = append(builds, info)
+		}
+	}
+	if len(builds) <= keep {
+		return
+	}
+
+	sort.Slice(builds, func(i, j int) bool {
+		return builds[i].ModTime().After(builds[j].ModTime())
+	})
+	for _, info := range builds[keep:] {
+		logging.V(5).Infof("removing old build %s", info.Name())
+		contract.IgnoreError(os.Remove(filepath.Join(cacheDir, info.Name())))
+	}
+}
+
+// goEnvInputs are the `go env` variables that affect the output of `go build`.  Only these are hashed, rather than
+// all of `go env`, because some variables, such as GOGCCFLAGS, differ from one invocation to the next.
+var goEnvInputs = []string{
+	"GOROOT", "GOPATH", "GO111MODULE", "GOFLAGS", "GOOS", "GOARCH", "GOARM", "GO386", "GOAMD64", "GOMIPS",
+	"CGO_ENABLED", "CC", "CXX", "CGO_CFLAGS", "CGO_CPPFLAGS", "CGO_CXXFLAGS", "CGO_LDFLAGS",
+}
+
+// listedPackage is the subset of a package's `go list -json` description that names its build inputs.
+type listedPackage struct {
+	Dir      string
+	Standard bool
+	Module   *struct {
+		GoMod string
+	}
+	GoFiles, CgoFiles, CFiles, CXXFiles, HFiles, SFiles, SysoFiles, EmbedFiles []string
+}
+
+// hashProgramSources computes a hash of everything that determines the output of building the program in the given
+// directory: the version and configuration of the Go toolchain, and the source files of the program and of every
+// package it depends on, wherever they live, as reported by `go list -deps`.  The standard library is covered by the
+// toolchain version.  If the toolchain is too old to list the program's packages, or cannot list them, a buildError
+// with the reason is returned.
+func hashProgramSources(dir string) (string, error) {
+	h := sha256.New()
+	for _, args := range [][]string{{"version"}, append([]string{"env"}, goEnvInputs...)} {
+		out, err := runGo(dir, args...)
+		if err != nil {
+			return "", err
+		}
+		if args[0] == "version" {
+			if err = checkGoVersion(string(out)); err != nil {
+				return "", err
+			}
+		}
+		_, _ = h.Write(out)
+	}
+
+	out, err := runGo(dir, "list", "-e", "-deps", "-json", ".")
+	if err != nil {
+		return "", err
+	}
+	modules := make(map[string]bool)
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var pkg listedPackage
+		if err = dec.Decode(&pkg); err == io.EOF {
+			break
+		} else if err != nil {
+			return "", errors.Wrap(err, "decoding 'go list' output")
+		}
+		if pkg.Standard {
+			continue
+		}
+
+		if pkg.Module != nil && pkg.Module.GoMod != "" && !modules[pkg.Module.GoMod] {
+			modules[pkg.Module.GoMod] = true
+			if err = hashFile(h, pkg.Module.GoMod); err != nil {
+				return "", err
+			}
+		}
+		for _, files := range [][]string{
+			pkg.GoFiles, pkg.CgoFiles, pkg.CFiles, pkg.CXXFiles, pkg.HFiles, pkg.SFiles, pkg.SysoFiles, pkg.EmbedFiles,
+		} {
+			for _, name := range files {
+				if err = hashFile(h, filepath.Join(pkg.Dir, name)); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// goVersionRegexp matches the minor version of a release of Go 1 in the output of `go version`.
+var goVersionRegexp = regexp.MustCompile(`\bgo1\.(\d+)`)
+
+// checkGoVersion returns a buildError if the output of `go version` names a release of Go that is too old to build
+// programs.  Development versions, which do not name a release, are assumed to be recent enough.
+func checkGoVersion(version string) error {
+	match := goVersionRegexp.FindStringSubmatch(version)
+	if match == nil {
+		return nil
+	}
+	if minor, err := strconv.Atoi(match[1]); err == nil && minor < minGoMinorVersion {
+		return &buildError{output: fmt.Sprintf("Go 1.%d or later is required to build Go programs, but found %s",
+			minGoMinorVersion, strings.TrimSpace(version))}
+	}
+	return nil
+}
+
+// hashFile writes the path and contents of the given file to the given hash.
+func hashFile(h io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(f)
+
+	_, _ = io.WriteString(h, filepath.ToSlash(path)+"\x00")
+	if _, err = io.Copy(h, f); err != nil {
+		return err
+	}
+	_, _ = io.WriteString(h, "\x00")
+	return nil
+}
+
+// runGo runs the go tool with the given arguments in the given directory and returns its standard output.  If the
+// tool fails, its error output is returned as a buildError.
+func runGo(dir string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return nil, &buildError{output: strings.TrimSpace(stderr.String())}
+		}
+		return nil, errors.Wrapf(err, "running 'go %s'", args[0])
+	}
+	return stdout.Bytes(), nil
+}
+
+// sanitizeBinaryName replaces any characters in a project name that are not safe to use in a file name or glob.
+func sanitizeBinaryName(project string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|', '[', ']':
+			return '_'
+		}
+		return r
+	}, project)
+}
@@ -0,0 +1,151 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeProgramFile(t *testing.T, dir, name, contents string) {
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0600))
+}
+
+func TestHashProgramSources(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("the go toolchain is not available")
+	}
+
+	dir, err := ioutil.TempDir("", "pulumi-go-hash")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeProgramFile(t, dir, "go.mod", "module example.com/prog\n\ngo 1.12\n")
+	writeProgramFile(t, dir, "main.go", "package main\n\nimport _ \"example.com/prog/dep\"\n\nfunc main() {}\n")
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "dep"), 0700))
+	writeProgramFile(t, dir, filepath.Join("dep", "dep.go"), "package dep\n")
+	hash, err := hashProgramSources(dir)
+	assert.NoError(t, err)
+
+	// Tests, non-Go files and packages the program does not import do not affect the hash.
+	writeProgramFile(t, dir, "main_test.go", "package main\n")
+	writeProgramFile(t, dir, "README.md", "# readme\n")
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "unused"), 0700))
+	writeProgramFile(t, dir, filepath.Join("unused", "unused.go"), "package unused\n")
+	same, err := hashProgramSources(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, same)
+
+	// The sources of the packages it depends on and its module file do.
+	writeProgramFile(t, dir, filepath.Join("dep", "dep.go"), "package dep\n\nvar X = 1\n")
+	withDep, err := hashProgramSources(dir)
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, withDep)
+
+	writeProgramFile(t, dir, "go.mod", "module example.com/prog\n\ngo 1.13\n")
+	withMod, err := hashProgramSources(dir)
+	assert.NoError(t, err)
+	assert.NotEqual(t, withDep, withMod)
+
+	// A program the toolchain cannot list is reported like a build failure.
+	assert.NoError(t, os.Remove(filepath.Join(dir, "go.mod")))
+	_, err = hashProgramSources(dir)
+	assert.IsType(t, &buildError{}, err)
+}
+
+func TestBuildProgram(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("the go toolchain is not available")
+	}
+
+	dir, err := ioutil.TempDir("", "pulumi-go-program")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cacheDir, err := ioutil.TempDir("", "pulumi-go-cache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(cacheDir)
+
+	writeProgramFile(t, dir, "go.mod", "module example.com/prog\n\ngo 1.12\n")
+	writeProgramFile(t, dir, "main.go", "package main\n\nfunc main() {}\n")
+	first, err := buildProgram("prog", dir, cacheDir)
+	assert.NoError(t, err)
+	assert.FileExists(t, first)
+
+	// An unchanged program is not rebuilt, and a changed one is built alongside the earlier build.
+	again, err := buildProgram("prog", dir, cacheDir)
+	assert.NoError(t, err)
+	assert.Equal(t, first, again)
+
+	writeProgramFile(t, dir, "main.go", "package main\n\nfunc main() { println() }\n")
+	second, err := buildProgram("prog", dir, cacheDir)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, second)
+	assert.FileExists(t, first)
+
+	// Compiler errors are reported with the compiler's output.
+	writeProgramFile(t, dir, "main.go", "package main\n\nfunc main() { undefinedFunction() }\n")
+	_, err = buildProgram("prog", dir, cacheDir)
+	if assert.IsType(t, &buildError{}, err) {
+		assert.Contains(t, err.(*buildError).output, "undefinedFunction")
+	}
+	files, err := ioutil.ReadDir(cacheDir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+}
+
+func TestPruneBuilds(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "pulumi-go-cache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(cacheDir)
+
+	// Write four builds of "prog", each more recent than the last, and files that merely resemble them.
+	now := time.Now()
+	var builds []string
+	for i, key := range []string{"0000000000000000", "1111111111111111", "2222222222222222", "3333333333333333"} {
+		path := filepath.Join(cacheDir, "prog-"+key)
+		writeProgramFile(t, cacheDir, filepath.Base(path), "")
+		modTime := now.Add(time.Duration(i-4) * time.Minute)
+		assert.NoError(t, os.Chtimes(path, modTime, modTime))
+		builds = append(builds, path)
+	}
+	writeProgramFile(t, cacheDir, "prog-other-0000000000000000", "")
+	writeProgramFile(t, cacheDir, "prog-zzzzzzzzzzzzzzzz", "")
+
+	pruneBuilds(cacheDir, "prog-", "", 2)
+	for i, path := range builds {
+		_, err = os.Stat(path)
+		assert.Equal(t, i < 2, os.IsNotExist(err), path)
+	}
+	assert.FileExists(t, filepath.Join(cacheDir, "prog-other-0000000000000000"))
+	assert.FileExists(t, filepath.Join(cacheDir, "prog-zzzzzzzzzzzzzzzz"))
+}
+
+func TestCheckGoVersion(t *testing.T) {
+	assert.NoError(t, checkGoVersion("go version go1.11 linux/amd64\n"))
+	assert.NoError(t, checkGoVersion("go version go1.12.7 darwin/amd64\n"))
+	assert.NoError(t, checkGoVersion("go version devel +6a7c1a6 Tue Jun 4 20:00:00 2019 +0000 linux/amd64\n"))
+
+	err := checkGoVersion("go version go1.10.8 linux/amd64\n")
+	if assert.IsType(t, &buildError{}, err) {
+		assert.Contains(t, err.(*buildError).output, "Go 1.11 or later is required")
+		assert.Contains(t, err.(*buildError).output, "go1.10.8")
+	}
+}
@@ -22,13 +22,17 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"syscall"
 
 	pbempty "github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/resource/provider"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/logging"
 	"github.com/pulumi/pulumi/pkg/util/rpcutil"
 	"github.com/pulumi/pulumi/pkg/version"
@@ -39,7 +43,9 @@ import (
 // Launches the language host, which in turn fires up an RPC server implementing the LanguageRuntimeServer endpoint.
 func main() {
 	var tracing string
+	var binary string
 	flag.StringVar(&tracing, "tracing", "", "Emit tracing to a Zipkin-compatible tracing endpoint")
+	flag.StringVar(&binary, "binary", "", "A prebuilt program binary to run, instead of building the program")
 
 	flag.Parse()
 	args := flag.Args()
@@ -55,7 +61,7 @@ func main() {
 	// Fire up a gRPC server, letting the kernel choose a free port.
 	port, done, err := rpcutil.Serve(0, nil, []func(*grpc.Server) error{
 		func(srv *grpc.Server) error {
-			host := newLanguageHost(engineAddress, tracing)
+			host := newLanguageHost(engineAddress, tracing, binary)
 			pulumirpc.RegisterLanguageRuntimeServer(srv, host)
 			return nil
 		},
@@ -77,24 +83,40 @@ func main() {
 type goLanguageHost struct {
 	engineAddress string
 	tracing       string
+	binary        string // a prebuilt program to run, if the project's `binary` runtime option is set.
 }
 
-func newLanguageHost(engineAddress, tracing string) pulumirpc.LanguageRuntimeServer {
+func newLanguageHost(engineAddress, tracing, binary string) pulumirpc.LanguageRuntimeServer {
 	return &goLanguageHost{
 		engineAddress: engineAddress,
 		tracing:       tracing,
+		binary:        binary,
 	}
 }
 
 // GetRequiredPlugins computes the complete set of anticipated plugins required by a program.
 func (host *goLanguageHost) GetRequiredPlugins(ctx context.Context,
 	req *pulumirpc.GetRequiredPluginsRequest) (*pulumirpc.GetRequiredPluginsResponse, error) {
-	return &pulumirpc.GetRequiredPluginsResponse{}, nil
+	// Go programs depend on the SDKs of the providers they use, so the required plugins are read from the
+	// requirements of the program's module.
+	plugins, err := getPluginsFromModule(req.GetPwd())
+	if err != nil {
+		logging.V(3).Infof("one or more errors while discovering plugins: %s", err)
+	}
+	return &pulumirpc.GetRequiredPluginsResponse{
+		Plugins: plugins,
+	}, nil
 }
 
 // findProgram attempts to find the needed program in various locations on the
-// filesystem, eventually resorting to searching in $PATH.
+// filesystem, eventually resorting to searching in $PATH.  Paths are used as given.
 func findProgram(program string) (string, error) {
+	if isPath(program) {
+		if fileInfo, err := os.Stat(program); err == nil && !fileInfo.Mode().IsDir() {
+			return filepath.Abs(program)
+		}
+		return "", errors.Errorf("unable to find program: %s", program)
+	}
 
 	// look in the same directory
 	cwd, err := os.Getwd()
@@ -126,6 +148,11 @@ func findProgram(program string) (string, error) {
 	return "", errors.Errorf("unable to find program: %s", program)
 }
 
+// isPath returns true if the given program name is a path, rather than a bare name to be searched for.
+func isPath(program string) bool {
+	return strings.ContainsAny(program, "/"+string(filepath.Separator))
+}
+
 // RPC endpoint for LanguageRuntimeServer::Run
 func (host *goLanguageHost) Run(ctx context.Context, req *pulumirpc.RunRequest) (*pulumirpc.RunResponse, error) {
 	// Create the environment we'll use to run the process.  This is how we pass the RunInfo to the actual
@@ -135,11 +162,25 @@ func (host *goLanguageHost) Run(ctx context.Context, req *pulumirpc.RunRequest)
 		return nil, errors.Wrap(err, "failed to prepare environment")
 	}
 
-	// The program to execute is simply the name of the project.  This ensures good Go toolability, whereby
-	// you can simply run `go install .` to build a Pulumi program prior to running it, among other benefits.
-	program, err := findProgram(req.GetProject())
-	if err != nil {
-		return nil, errors.Wrap(err, "problem executing program (could not run language executor)")
+	// Unless the project names a prebuilt binary, build the program from source, so that what runs is always the
+	// code on disk.  Builds are cached, so an unchanged program is not rebuilt.
+	var program string
+	if host.binary != "" {
+		// A relative path to the binary is relative to the program's directory.
+		binary := host.binary
+		if isPath(binary) && !filepath.IsAbs(binary) {
+			binary = filepath.Join(req.GetPwd(), binary)
+		}
+		if program, err = findProgram(binary); err != nil {
+			return nil, errors.Wrap(err, "problem executing program (could not run language executor)")
+		}
+	} else if program, err = host.buildProgram(req); err != nil {
+		if buildErr, ok := err.(*buildError); ok {
+			// Report the compiler's output to the engine, so that it is displayed like any other error.
+			host.logError(ctx, buildErr.Error()+":\n"+buildErr.output)
+			return &pulumirpc.RunResponse{Error: buildErr.Error()}, nil
+		}
+		return nil, errors.Wrap(err, "problem executing program (could not build program)")
 	}
 
 	logging.V(5).Infoln("language host launching process: %s", program)
@@ -171,6 +212,30 @@ func (host *goLanguageHost) Run(ctx context.Context, req *pulumirpc.RunRequest)
 	return &pulumirpc.RunResponse{Error: errResult}, nil
 }
 
+// buildProgram builds the program for the given request into the build cache.
+func (host *goLanguageHost) buildProgram(req *pulumirpc.RunRequest) (string, error) {
+	cacheDir, err := getBuildCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return buildProgram(req.GetProject(), req.GetPwd(), cacheDir)
+}
+
+// logError reports an error diagnostic to the engine.  Failure to do so is logged, but otherwise ignored, as the
+// error is also returned to the engine as the result of the run.
+func (host *goLanguageHost) logError(ctx context.Context, msg string) {
+	engine, err := provider.NewHostClient(host.engineAddress)
+	if err != nil {
+		logging.V(5).Infof("could not connect to the engine to report an error: %v", err)
+		return
+	}
+	defer contract.IgnoreClose(engine)
+
+	if err = engine.Log(ctx, diag.Error, "", msg); err != nil {
+		logging.V(5).Infof("could not report an error to the engine: %v", err)
+	}
+}
+
 // constructEnv constructs an environment for a Go progam by enumerating all of the optional and non-optional
 // arguments present in a RunRequest.
 func (host *goLanguageHost) constructEnv(req *pulumirpc.RunRequest) ([]string, error) {
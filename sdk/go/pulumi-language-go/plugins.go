@@ -0,0 +1,153 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+// providerModuleRegexp matches the module paths of Pulumi resource provider SDKs, e.g. `github.com/pulumi/pulumi-aws`
+// or `github.com/pulumi/pulumi-aws/sdk/v2`, capturing the name of the provider.
+var providerModuleRegexp = regexp.MustCompile(`^github\.com/pulumi/pulumi-([a-z0-9][a-z0-9-]*?)(/sdk)?(/v[0-9]+)?$`)
+
+// nonProviderModules are modules matching providerModuleRegexp that are not provider SDKs.
+var nonProviderModules = map[string]bool{
+	"terraform":        true,
+	"terraform-bridge": true,
+}
+
+// pseudoVersionRegexp matches Go module pseudo-versions, e.g. `v0.0.0-20180101000000-0123456789ab`, which refer to
+// commits rather than releases and so have no corresponding plugin release.
+var pseudoVersionRegexp = regexp.MustCompile(`-([0-9]+\.)?[0-9]{14}-[0-9a-f]{12}$`)
+
+// getPluginsFromModule returns the resource provider plugins required by the Go module containing the given
+// directory, based on the provider SDK modules listed in its go.mod file.  If the directory is not part of a module,
+// no plugins are returned.
+func getPluginsFromModule(dir string) ([]*pulumirpc.PluginDependency, error) {
+	gomod, err := findGoMod(dir)
+	if err != nil || gomod == "" {
+		return nil, err
+	}
+
+	f, err := os.Open(gomod)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s", gomod)
+	}
+	defer contract.IgnoreClose(f)
+
+	requires, err := parseGoModRequires(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", gomod)
+	}
+
+	var plugins []*pulumirpc.PluginDependency
+	seen := make(map[string]bool)
+	for _, req := range requires {
+		match := providerModuleRegexp.FindStringSubmatch(req.path)
+		if match == nil || nonProviderModules[match[1]] || seen[match[1]] {
+			continue
+		}
+		seen[match[1]] = true
+
+		version := strings.TrimSuffix(req.version, "+incompatible")
+		if pseudoVersionRegexp.MatchString(version) {
+			version = ""
+		}
+		plugins = append(plugins, &pulumirpc.PluginDependency{
+			Name:    match[1],
+			Kind:    "resource",
+			Version: version,
+		})
+	}
+	return plugins, nil
+}
+
+// findGoMod returns the path to the go.mod file of the module containing the given directory, or the empty string if
+// there is none.
+func findGoMod(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		gomod := filepath.Join(dir, "go.mod")
+		if info, err := os.Stat(gomod); err == nil && !info.IsDir() {
+			return gomod, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// goModRequire is a single requirement from a go.mod file.
+type goModRequire struct {
+	path    string
+	version string
+}
+
+// parseGoModRequires returns the requirements listed in a go.mod file, both in single-line `require` directives and
+// in `require ( ... )` blocks.
+func parseGoModRequires(r io.Reader) ([]goModRequire, error) {
+	var requires []goModRequire
+	inBlock := false
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case inBlock && fields[0] == ")":
+			inBlock = false
+			continue
+		case inBlock:
+			// Each line of the block is a requirement.
+		case fields[0] == "require" && len(fields) == 2 && fields[1] == "(":
+			inBlock = true
+			continue
+		case fields[0] == "require":
+			fields = fields[1:]
+		default:
+			continue
+		}
+
+		if len(fields) != 2 {
+			return nil, errors.Errorf("malformed requirement: %s", strings.TrimSpace(line))
+		}
+		requires = append(requires, goModRequire{
+			path:    strings.Trim(fields[0], `"`),
+			version: fields[1],
+		})
+	}
+	return requires, scanner.Err()
+}
@@ -0,0 +1,92 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+func TestParseGoModRequires(t *testing.T) {
+	requires, err := parseGoModRequires(strings.NewReader(`module example.com/prog
+
+go 1.12
+
+require github.com/pulumi/pulumi v0.16.0 // the SDK
+
+require (
+	// providers
+	github.com/pulumi/pulumi-aws v0.16.2
+	"github.com/pulumi/pulumi-random" v0.2.0 // indirect
+)
+
+replace github.com/pulumi/pulumi-aws => ../pulumi-aws
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []goModRequire{
+		{path: "github.com/pulumi/pulumi", version: "v0.16.0"},
+		{path: "github.com/pulumi/pulumi-aws", version: "v0.16.2"},
+		{path: "github.com/pulumi/pulumi-random", version: "v0.2.0"},
+	}, requires)
+
+	_, err = parseGoModRequires(strings.NewReader("require github.com/pulumi/pulumi-aws\n"))
+	assert.Error(t, err)
+}
+
+func TestGetPluginsFromModule(t *testing.T) {
+	root, err := ioutil.TempDir("", "pulumi-go-plugins")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	// The program lives in a subdirectory of the module.
+	dir := filepath.Join(root, "infra")
+	assert.NoError(t, os.Mkdir(dir, 0700))
+	writeProgramFile(t, root, "go.mod", `module example.com/prog
+
+require (
+	github.com/pulumi/pulumi v0.16.0
+	github.com/pulumi/pulumi-aws v0.16.2
+	github.com/pulumi/pulumi-azure/sdk/v2 v2.1.0
+	github.com/pulumi/pulumi-kubernetes v0.0.0-20181010203040-0123456789ab
+	github.com/pulumi/pulumi-terraform v0.15.0
+	github.com/pulumi/pulumi-gcp v1.0.0+incompatible
+	github.com/pkg/errors v0.8.0
+)
+`)
+
+	plugins, err := getPluginsFromModule(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []*pulumirpc.PluginDependency{
+		{Name: "aws", Kind: "resource", Version: "v0.16.2"},
+		{Name: "azure", Kind: "resource", Version: "v2.1.0"},
+		{Name: "kubernetes", Kind: "resource"},
+		{Name: "gcp", Kind: "resource", Version: "v1.0.0"},
+	}, plugins)
+
+	// A directory outside of any module requires no plugins.
+	outside, err := ioutil.TempDir("", "pulumi-go-plugins")
+	assert.NoError(t, err)
+	defer os.RemoveAll(outside)
+	plugins, err = getPluginsFromModule(outside)
+	assert.NoError(t, err)
+	assert.Empty(t, plugins)
+}
@@ -55,6 +55,28 @@ function deserialize_pulumirpc_AnalyzeResponse(buffer_arg) {
   return analyzer_pb.AnalyzeResponse.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_pulumirpc_AnalyzeStackRequest(arg) {
+  if (!(arg instanceof analyzer_pb.AnalyzeStackRequest)) {
+    throw new Error('Expected argument of type pulumirpc.AnalyzeStackRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_AnalyzeStackRequest(buffer_arg) {
+  return analyzer_pb.AnalyzeStackRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_ConfigureAnalyzerRequest(arg) {
+  if (!(arg instanceof analyzer_pb.ConfigureAnalyzerRequest)) {
+    throw new Error('Expected argument of type pulumirpc.ConfigureAnalyzerRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_ConfigureAnalyzerRequest(buffer_arg) {
+  return analyzer_pb.ConfigureAnalyzerRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_pulumirpc_PluginInfo(arg) {
   if (!(arg instanceof plugin_pb.PluginInfo)) {
     throw new Error('Expected argument of type pulumirpc.PluginInfo');
@@ -82,6 +104,21 @@ var AnalyzerService = exports.AnalyzerService = {
     responseSerialize: serialize_pulumirpc_AnalyzeResponse,
     responseDeserialize: deserialize_pulumirpc_AnalyzeResponse,
   },
+  // AnalyzeStack analyzes all of the resources in a stack at once, after the program has registered all of them,
+  // and returns any errors that it finds.  This allows analyzers to check invariants that span multiple resources.
+  // Before an update changes anything, the engine runs the program as a preview and analyzes the resources that
+  // it proposes, so that mandatory failures block the update before any step executes.
+  analyzeStack: {
+    path: '/pulumirpc.Analyzer/AnalyzeStack',
+    requestStream: false,
+    responseStream: false,
+    requestType: analyzer_pb.AnalyzeStackRequest,
+    responseType: analyzer_pb.AnalyzeResponse,
+    requestSerialize: serialize_pulumirpc_AnalyzeStackRequest,
+    requestDeserialize: deserialize_pulumirpc_AnalyzeStackRequest,
+    responseSerialize: serialize_pulumirpc_AnalyzeResponse,
+    responseDeserialize: deserialize_pulumirpc_AnalyzeResponse,
+  },
   // GetPluginInfo returns generic information about this plugin, like its version.
   getPluginInfo: {
     path: '/pulumirpc.Analyzer/GetPluginInfo',
@@ -94,6 +131,19 @@ var AnalyzerService = exports.AnalyzerService = {
     responseSerialize: serialize_pulumirpc_PluginInfo,
     responseDeserialize: deserialize_pulumirpc_PluginInfo,
   },
+  // Configure passes the analyzer its settings from the `policy` section of the project and stack files.  It is
+  // called once, before any resources are analyzed.
+  configure: {
+    path: '/pulumirpc.Analyzer/Configure',
+    requestStream: false,
+    responseStream: false,
+    requestType: analyzer_pb.ConfigureAnalyzerRequest,
+    responseType: google_protobuf_empty_pb.Empty,
+    requestSerialize: serialize_pulumirpc_ConfigureAnalyzerRequest,
+    requestDeserialize: deserialize_pulumirpc_ConfigureAnalyzerRequest,
+    responseSerialize: serialize_google_protobuf_Empty,
+    responseDeserialize: deserialize_google_protobuf_Empty,
+  },
 };
 
 exports.AnalyzerClient = grpc.makeGenericClientConstructor(AnalyzerService);
@@ -121,6 +121,28 @@ function deserialize_pulumirpc_DiffResponse(buffer_arg) {
   return provider_pb.DiffResponse.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_pulumirpc_GetLogsRequest(arg) {
+  if (!(arg instanceof provider_pb.GetLogsRequest)) {
+    throw new Error('Expected argument of type pulumirpc.GetLogsRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_GetLogsRequest(buffer_arg) {
+  return provider_pb.GetLogsRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_GetLogsResponse(arg) {
+  if (!(arg instanceof provider_pb.GetLogsResponse)) {
+    throw new Error('Expected argument of type pulumirpc.GetLogsResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_GetLogsResponse(buffer_arg) {
+  return provider_pb.GetLogsResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_pulumirpc_InvokeRequest(arg) {
   if (!(arg instanceof provider_pb.InvokeRequest)) {
     throw new Error('Expected argument of type pulumirpc.InvokeRequest');
@@ -328,6 +350,19 @@ var ResourceProviderService = exports.ResourceProviderService = {
     responseSerialize: serialize_pulumirpc_PluginInfo,
     responseDeserialize: deserialize_pulumirpc_PluginInfo,
   },
+  // GetLogs returns the log entries emitted by a resource managed by this provider.  This is optional: providers
+  // that do not serve logs for a resource's type return UNIMPLEMENTED.
+  getLogs: {
+    path: '/pulumirpc.ResourceProvider/GetLogs',
+    requestStream: false,
+    responseStream: false,
+    requestType: provider_pb.GetLogsRequest,
+    responseType: provider_pb.GetLogsResponse,
+    requestSerialize: serialize_pulumirpc_GetLogsRequest,
+    requestDeserialize: deserialize_pulumirpc_GetLogsRequest,
+    responseSerialize: serialize_pulumirpc_GetLogsResponse,
+    responseDeserialize: deserialize_pulumirpc_GetLogsResponse,
+  },
 };
 
 exports.ResourceProviderClient = grpc.makeGenericClientConstructor(ResourceProviderService);
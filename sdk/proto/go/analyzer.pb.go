@@ -25,9 +25,77 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// EnforcementLevel indicates how the engine should treat a failure reported by an analyzer.
+type EnforcementLevel int32
+
+const (
+	EnforcementLevel_MANDATORY EnforcementLevel = 0
+	EnforcementLevel_ADVISORY  EnforcementLevel = 1
+)
+
+var EnforcementLevel_name = map[int32]string{
+	0: "MANDATORY",
+	1: "ADVISORY",
+}
+var EnforcementLevel_value = map[string]int32{
+	"MANDATORY": 0,
+	"ADVISORY":  1,
+}
+
+func (x EnforcementLevel) String() string {
+	return proto.EnumName(EnforcementLevel_name, int32(x))
+}
+func (EnforcementLevel) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_analyzer_6177c291369f6761, []int{0}
+}
+
+type ConfigureAnalyzerRequest struct {
+	Config               *_struct.Struct `protobuf:"bytes,1,opt,name=config" json:"config,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ConfigureAnalyzerRequest) Reset()         { *m = ConfigureAnalyzerRequest{} }
+func (m *ConfigureAnalyzerRequest) String() string { return proto.CompactTextString(m) }
+func (*ConfigureAnalyzerRequest) ProtoMessage()    {}
+func (*ConfigureAnalyzerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_analyzer_6177c291369f6761, []int{0}
+}
+func (m *ConfigureAnalyzerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigureAnalyzerRequest.Unmarshal(m, b)
+}
+func (m *ConfigureAnalyzerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigureAnalyzerRequest.Marshal(b, m, deterministic)
+}
+func (dst *ConfigureAnalyzerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigureAnalyzerRequest.Merge(dst, src)
+}
+func (m *ConfigureAnalyzerRequest) XXX_Size() int {
+	return xxx_messageInfo_ConfigureAnalyzerRequest.Size(m)
+}
+func (m *ConfigureAnalyzerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigureAnalyzerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigureAnalyzerRequest proto.InternalMessageInfo
+
+func (m *ConfigureAnalyzerRequest) GetConfig() *_struct.Struct {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
 type AnalyzeRequest struct {
 	Type                 string          `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
 	Properties           *_struct.Struct `protobuf:"bytes,2,opt,name=properties" json:"properties,omitempty"`
+	Urn                  string          `protobuf:"bytes,3,opt,name=urn" json:"urn,omitempty"`
+	Parent               string          `protobuf:"bytes,4,opt,name=parent" json:"parent,omitempty"`
+	Dependencies         []string        `protobuf:"bytes,5,rep,name=dependencies" json:"dependencies,omitempty"`
+	Provider             string          `protobuf:"bytes,6,opt,name=provider" json:"provider,omitempty"`
+	Custom               bool            `protobuf:"varint,7,opt,name=custom" json:"custom,omitempty"`
+	OldProperties        *_struct.Struct `protobuf:"bytes,8,opt,name=oldProperties" json:"oldProperties,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -37,7 +105,7 @@ func (m *AnalyzeRequest) Reset()         { *m = AnalyzeRequest{} }
 func (m *AnalyzeRequest) String() string { return proto.CompactTextString(m) }
 func (*AnalyzeRequest) ProtoMessage()    {}
 func (*AnalyzeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_analyzer_4d94ca02b59f2385, []int{0}
+	return fileDescriptor_analyzer_6177c291369f6761, []int{1}
 }
 func (m *AnalyzeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnalyzeRequest.Unmarshal(m, b)
@@ -71,6 +139,181 @@ func (m *AnalyzeRequest) GetProperties() *_struct.Struct {
 	return nil
 }
 
+func (m *AnalyzeRequest) GetUrn() string {
+	if m != nil {
+		return m.Urn
+	}
+	return ""
+}
+
+func (m *AnalyzeRequest) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *AnalyzeRequest) GetDependencies() []string {
+	if m != nil {
+		return m.Dependencies
+	}
+	return nil
+}
+
+func (m *AnalyzeRequest) GetProvider() string {
+	if m != nil {
+		return m.Provider
+	}
+	return ""
+}
+
+func (m *AnalyzeRequest) GetCustom() bool {
+	if m != nil {
+		return m.Custom
+	}
+	return false
+}
+
+func (m *AnalyzeRequest) GetOldProperties() *_struct.Struct {
+	if m != nil {
+		return m.OldProperties
+	}
+	return nil
+}
+
+// AnalyzerResource describes a single resource in the proposed state of a stack.
+type AnalyzerResource struct {
+	Type                 string          `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Properties           *_struct.Struct `protobuf:"bytes,2,opt,name=properties" json:"properties,omitempty"`
+	Urn                  string          `protobuf:"bytes,3,opt,name=urn" json:"urn,omitempty"`
+	Parent               string          `protobuf:"bytes,4,opt,name=parent" json:"parent,omitempty"`
+	Dependencies         []string        `protobuf:"bytes,5,rep,name=dependencies" json:"dependencies,omitempty"`
+	Provider             string          `protobuf:"bytes,6,opt,name=provider" json:"provider,omitempty"`
+	Custom               bool            `protobuf:"varint,7,opt,name=custom" json:"custom,omitempty"`
+	OldProperties        *_struct.Struct `protobuf:"bytes,8,opt,name=oldProperties" json:"oldProperties,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *AnalyzerResource) Reset()         { *m = AnalyzerResource{} }
+func (m *AnalyzerResource) String() string { return proto.CompactTextString(m) }
+func (*AnalyzerResource) ProtoMessage()    {}
+func (*AnalyzerResource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_analyzer_6177c291369f6761, []int{2}
+}
+func (m *AnalyzerResource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AnalyzerResource.Unmarshal(m, b)
+}
+func (m *AnalyzerResource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AnalyzerResource.Marshal(b, m, deterministic)
+}
+func (dst *AnalyzerResource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnalyzerResource.Merge(dst, src)
+}
+func (m *AnalyzerResource) XXX_Size() int {
+	return xxx_messageInfo_AnalyzerResource.Size(m)
+}
+func (m *AnalyzerResource) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnalyzerResource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnalyzerResource proto.InternalMessageInfo
+
+func (m *AnalyzerResource) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *AnalyzerResource) GetProperties() *_struct.Struct {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *AnalyzerResource) GetUrn() string {
+	if m != nil {
+		return m.Urn
+	}
+	return ""
+}
+
+func (m *AnalyzerResource) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *AnalyzerResource) GetDependencies() []string {
+	if m != nil {
+		return m.Dependencies
+	}
+	return nil
+}
+
+func (m *AnalyzerResource) GetProvider() string {
+	if m != nil {
+		return m.Provider
+	}
+	return ""
+}
+
+func (m *AnalyzerResource) GetCustom() bool {
+	if m != nil {
+		return m.Custom
+	}
+	return false
+}
+
+func (m *AnalyzerResource) GetOldProperties() *_struct.Struct {
+	if m != nil {
+		return m.OldProperties
+	}
+	return nil
+}
+
+type AnalyzeStackRequest struct {
+	Resources            []*AnalyzerResource `protobuf:"bytes,1,rep,name=resources" json:"resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *AnalyzeStackRequest) Reset()         { *m = AnalyzeStackRequest{} }
+func (m *AnalyzeStackRequest) String() string { return proto.CompactTextString(m) }
+func (*AnalyzeStackRequest) ProtoMessage()    {}
+func (*AnalyzeStackRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_analyzer_6177c291369f6761, []int{3}
+}
+func (m *AnalyzeStackRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AnalyzeStackRequest.Unmarshal(m, b)
+}
+func (m *AnalyzeStackRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AnalyzeStackRequest.Marshal(b, m, deterministic)
+}
+func (dst *AnalyzeStackRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnalyzeStackRequest.Merge(dst, src)
+}
+func (m *AnalyzeStackRequest) XXX_Size() int {
+	return xxx_messageInfo_AnalyzeStackRequest.Size(m)
+}
+func (m *AnalyzeStackRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnalyzeStackRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnalyzeStackRequest proto.InternalMessageInfo
+
+func (m *AnalyzeStackRequest) GetResources() []*AnalyzerResource {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
 type AnalyzeResponse struct {
 	Failures             []*AnalyzeFailure `protobuf:"bytes,1,rep,name=failures" json:"failures,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
@@ -82,7 +325,7 @@ func (m *AnalyzeResponse) Reset()         { *m = AnalyzeResponse{} }
 func (m *AnalyzeResponse) String() string { return proto.CompactTextString(m) }
 func (*AnalyzeResponse) ProtoMessage()    {}
 func (*AnalyzeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_analyzer_4d94ca02b59f2385, []int{1}
+	return fileDescriptor_analyzer_6177c291369f6761, []int{4}
 }
 func (m *AnalyzeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnalyzeResponse.Unmarshal(m, b)
@@ -110,18 +353,20 @@ func (m *AnalyzeResponse) GetFailures() []*AnalyzeFailure {
 }
 
 type AnalyzeFailure struct {
-	Property             string   `protobuf:"bytes,1,opt,name=property" json:"property,omitempty"`
-	Reason               string   `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Property             string           `protobuf:"bytes,1,opt,name=property" json:"property,omitempty"`
+	Reason               string           `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+	EnforcementLevel     EnforcementLevel `protobuf:"varint,3,opt,name=enforcementLevel,enum=pulumirpc.EnforcementLevel" json:"enforcementLevel,omitempty"`
+	Urn                  string           `protobuf:"bytes,4,opt,name=urn" json:"urn,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *AnalyzeFailure) Reset()         { *m = AnalyzeFailure{} }
 func (m *AnalyzeFailure) String() string { return proto.CompactTextString(m) }
 func (*AnalyzeFailure) ProtoMessage()    {}
 func (*AnalyzeFailure) Descriptor() ([]byte, []int) {
-	return fileDescriptor_analyzer_4d94ca02b59f2385, []int{2}
+	return fileDescriptor_analyzer_6177c291369f6761, []int{5}
 }
 func (m *AnalyzeFailure) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnalyzeFailure.Unmarshal(m, b)
@@ -155,10 +400,28 @@ func (m *AnalyzeFailure) GetReason() string {
 	return ""
 }
 
+func (m *AnalyzeFailure) GetEnforcementLevel() EnforcementLevel {
+	if m != nil {
+		return m.EnforcementLevel
+	}
+	return EnforcementLevel_MANDATORY
+}
+
+func (m *AnalyzeFailure) GetUrn() string {
+	if m != nil {
+		return m.Urn
+	}
+	return ""
+}
+
 func init() {
+	proto.RegisterType((*ConfigureAnalyzerRequest)(nil), "pulumirpc.ConfigureAnalyzerRequest")
 	proto.RegisterType((*AnalyzeRequest)(nil), "pulumirpc.AnalyzeRequest")
+	proto.RegisterType((*AnalyzerResource)(nil), "pulumirpc.AnalyzerResource")
+	proto.RegisterType((*AnalyzeStackRequest)(nil), "pulumirpc.AnalyzeStackRequest")
 	proto.RegisterType((*AnalyzeResponse)(nil), "pulumirpc.AnalyzeResponse")
 	proto.RegisterType((*AnalyzeFailure)(nil), "pulumirpc.AnalyzeFailure")
+	proto.RegisterEnum("pulumirpc.EnforcementLevel", EnforcementLevel_name, EnforcementLevel_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -174,8 +437,16 @@ const _ = grpc.SupportPackageIsVersion4
 type AnalyzerClient interface {
 	// Analyze analyzes a single resource object, and returns any errors that it finds.
 	Analyze(ctx context.Context, in *AnalyzeRequest, opts ...grpc.CallOption) (*AnalyzeResponse, error)
+	// AnalyzeStack analyzes all of the resources in a stack at once, after the program has registered all of them,
+	// and returns any errors that it finds.  This allows analyzers to check invariants that span multiple resources.
+	// Before an update changes anything, the engine runs the program as a preview and analyzes the resources that
+	// it proposes, so that mandatory failures block the update before any step executes.
+	AnalyzeStack(ctx context.Context, in *AnalyzeStackRequest, opts ...grpc.CallOption) (*AnalyzeResponse, error)
 	// GetPluginInfo returns generic information about this plugin, like its version.
 	GetPluginInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PluginInfo, error)
+	// Configure passes the analyzer its settings from the `policy` section of the project and stack files.  It is
+	// called once, before any resources are analyzed.
+	Configure(ctx context.Context, in *ConfigureAnalyzerRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
 type analyzerClient struct {
@@ -195,6 +466,15 @@ func (c *analyzerClient) Analyze(ctx context.Context, in *AnalyzeRequest, opts .
 	return out, nil
 }
 
+func (c *analyzerClient) AnalyzeStack(ctx context.Context, in *AnalyzeStackRequest, opts ...grpc.CallOption) (*AnalyzeResponse, error) {
+	out := new(AnalyzeResponse)
+	err := grpc.Invoke(ctx, "/pulumirpc.Analyzer/AnalyzeStack", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *analyzerClient) GetPluginInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PluginInfo, error) {
 	out := new(PluginInfo)
 	err := grpc.Invoke(ctx, "/pulumirpc.Analyzer/GetPluginInfo", in, out, c.cc, opts...)
@@ -204,13 +484,30 @@ func (c *analyzerClient) GetPluginInfo(ctx context.Context, in *empty.Empty, opt
 	return out, nil
 }
 
+func (c *analyzerClient) Configure(ctx context.Context, in *ConfigureAnalyzerRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := grpc.Invoke(ctx, "/pulumirpc.Analyzer/Configure", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Analyzer service
 
 type AnalyzerServer interface {
 	// Analyze analyzes a single resource object, and returns any errors that it finds.
 	Analyze(context.Context, *AnalyzeRequest) (*AnalyzeResponse, error)
+	// AnalyzeStack analyzes all of the resources in a stack at once, after the program has registered all of them,
+	// and returns any errors that it finds.  This allows analyzers to check invariants that span multiple resources.
+	// Before an update changes anything, the engine runs the program as a preview and analyzes the resources that
+	// it proposes, so that mandatory failures block the update before any step executes.
+	AnalyzeStack(context.Context, *AnalyzeStackRequest) (*AnalyzeResponse, error)
 	// GetPluginInfo returns generic information about this plugin, like its version.
 	GetPluginInfo(context.Context, *empty.Empty) (*PluginInfo, error)
+	// Configure passes the analyzer its settings from the `policy` section of the project and stack files.  It is
+	// called once, before any resources are analyzed.
+	Configure(context.Context, *ConfigureAnalyzerRequest) (*empty.Empty, error)
 }
 
 func RegisterAnalyzerServer(s *grpc.Server, srv AnalyzerServer) {
@@ -235,6 +532,24 @@ func _Analyzer_Analyze_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Analyzer_AnalyzeStack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeStackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServer).AnalyzeStack(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.Analyzer/AnalyzeStack",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServer).AnalyzeStack(ctx, req.(*AnalyzeStackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Analyzer_GetPluginInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
@@ -253,6 +568,24 @@ func _Analyzer_GetPluginInfo_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Analyzer_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigureAnalyzerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServer).Configure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.Analyzer/Configure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServer).Configure(ctx, req.(*ConfigureAnalyzerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Analyzer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pulumirpc.Analyzer",
 	HandlerType: (*AnalyzerServer)(nil),
@@ -261,35 +594,59 @@ var _Analyzer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Analyze",
 			Handler:    _Analyzer_Analyze_Handler,
 		},
+		{
+			MethodName: "AnalyzeStack",
+			Handler:    _Analyzer_AnalyzeStack_Handler,
+		},
 		{
 			MethodName: "GetPluginInfo",
 			Handler:    _Analyzer_GetPluginInfo_Handler,
 		},
+		{
+			MethodName: "Configure",
+			Handler:    _Analyzer_Configure_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "analyzer.proto",
 }
 
-func init() { proto.RegisterFile("analyzer.proto", fileDescriptor_analyzer_4d94ca02b59f2385) }
-
-var fileDescriptor_analyzer_4d94ca02b59f2385 = []byte{
-	// 285 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x6c, 0x91, 0x4f, 0x4b, 0xc3, 0x40,
-	0x10, 0xc5, 0x1b, 0x95, 0x9a, 0x8c, 0x5a, 0x61, 0xc0, 0x5a, 0x57, 0x0f, 0x25, 0x27, 0x4f, 0x5b,
-	0xa8, 0x88, 0x57, 0x15, 0xff, 0xde, 0x24, 0x9e, 0x3d, 0xa4, 0x65, 0x12, 0x02, 0x69, 0x76, 0xdd,
-	0x3f, 0x87, 0xf8, 0x29, 0xfc, 0xc8, 0xc6, 0xdd, 0x35, 0x16, 0xed, 0x6d, 0x86, 0xf7, 0xf8, 0xcd,
-	0x9b, 0x19, 0x18, 0xe5, 0x4d, 0x5e, 0xb7, 0x1f, 0xa4, 0xb8, 0x54, 0xc2, 0x08, 0x4c, 0xa4, 0xad,
-	0xed, 0xaa, 0x52, 0x72, 0xc9, 0xf6, 0x65, 0x6d, 0xcb, 0xaa, 0xf1, 0x02, 0x3b, 0x2d, 0x85, 0x28,
-	0x6b, 0x9a, 0xb9, 0x6e, 0x61, 0x8b, 0x19, 0xad, 0xa4, 0x69, 0x83, 0x78, 0xf6, 0x57, 0xd4, 0x46,
-	0xd9, 0xa5, 0xf1, 0x6a, 0xfa, 0x06, 0xa3, 0x1b, 0x3f, 0x25, 0xa3, 0x77, 0x4b, 0xda, 0x20, 0xc2,
-	0x8e, 0x69, 0x25, 0x4d, 0xa2, 0x69, 0x74, 0x9e, 0x64, 0xae, 0xc6, 0x2b, 0x80, 0xce, 0x2e, 0x49,
-	0x99, 0x8a, 0xf4, 0x64, 0xab, 0x53, 0xf6, 0xe6, 0xc7, 0xdc, 0x83, 0xf9, 0x0f, 0x98, 0xbf, 0x3a,
-	0x70, 0xb6, 0x66, 0x4d, 0x9f, 0xe0, 0xb0, 0xc7, 0x6b, 0x29, 0x1a, 0x4d, 0x78, 0x09, 0x71, 0x91,
-	0x57, 0xb5, 0x55, 0x1d, 0x29, 0x9a, 0x6e, 0x77, 0xa4, 0x13, 0xde, 0x2f, 0xc6, 0x83, 0xfb, 0xc1,
-	0x3b, 0xb2, 0xde, 0x9a, 0xde, 0xf5, 0x41, 0x83, 0x86, 0x0c, 0xe2, 0x30, 0xa9, 0x0d, 0x61, 0xfb,
-	0x1e, 0xc7, 0x30, 0x54, 0x94, 0x6b, 0xd1, 0xb8, 0xb0, 0x49, 0x16, 0xba, 0xf9, 0x67, 0x04, 0x71,
-	0xc0, 0x28, 0xbc, 0x85, 0xdd, 0x50, 0xe3, 0x86, 0x08, 0xe1, 0x1e, 0x8c, 0x6d, 0x92, 0xfc, 0x2e,
-	0xe9, 0x00, 0xaf, 0xe1, 0xe0, 0x91, 0xcc, 0x8b, 0xfb, 0xc6, 0x73, 0x53, 0x08, 0x1c, 0xff, 0x3b,
-	0xcb, 0xfd, 0xf7, 0x33, 0xd8, 0xd1, 0x1a, 0xe6, 0xd7, 0x9e, 0x0e, 0x16, 0x43, 0x67, 0xbc, 0xf8,
-	0x0a, 0x00, 0x00, 0xff, 0xff, 0xff, 0x6d, 0x9c, 0x46, 0xee, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("analyzer.proto", fileDescriptor_analyzer_6177c291369f6761) }
+
+var fileDescriptor_analyzer_6177c291369f6761 = []byte{
+	// 532 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xec, 0x54, 0x4f, 0x6f, 0xd3, 0x30,
+	0x1c, 0x6d, 0xda, 0xd1, 0x25, 0xbf, 0xb5, 0xa5, 0x32, 0x62, 0x84, 0x0c, 0xa1, 0x2a, 0x5c, 0x2a,
+	0x0e, 0xa9, 0x54, 0x84, 0x10, 0x07, 0x24, 0x0a, 0x1b, 0x63, 0x30, 0xa0, 0x72, 0x11, 0x12, 0xc7,
+	0x2c, 0xfd, 0xb5, 0x8a, 0x48, 0x6d, 0xe3, 0xd8, 0x93, 0xca, 0x87, 0x01, 0xce, 0x7c, 0x4a, 0x14,
+	0xd7, 0x4d, 0xff, 0x6d, 0xe3, 0x0b, 0x70, 0xf3, 0xf3, 0x7b, 0x7e, 0x76, 0xde, 0x73, 0x0c, 0xad,
+	0x98, 0xc5, 0xd9, 0xfc, 0x07, 0xca, 0x48, 0x48, 0xae, 0x38, 0xf1, 0x84, 0xce, 0xf4, 0x2c, 0x95,
+	0x22, 0x09, 0x1a, 0x22, 0xd3, 0xd3, 0x94, 0x2d, 0x88, 0xe0, 0x68, 0xca, 0xf9, 0x34, 0xc3, 0x9e,
+	0x41, 0x17, 0x7a, 0xd2, 0xc3, 0x99, 0x50, 0x73, 0x4b, 0x3e, 0xd8, 0x26, 0x73, 0x25, 0x75, 0xa2,
+	0x16, 0x6c, 0xf8, 0x1e, 0xfc, 0xd7, 0x9c, 0x4d, 0xd2, 0xa9, 0x96, 0x38, 0xb0, 0xdb, 0x51, 0xfc,
+	0xae, 0x31, 0x57, 0xa4, 0x07, 0xf5, 0xc4, 0x70, 0xbe, 0xd3, 0x71, 0xba, 0x07, 0xfd, 0x7b, 0xd1,
+	0xc2, 0x2a, 0x5a, 0x5a, 0x45, 0x23, 0x63, 0x45, 0xad, 0x2c, 0xfc, 0x59, 0x85, 0x96, 0x35, 0x59,
+	0x7a, 0x10, 0xd8, 0x53, 0x73, 0x81, 0xc6, 0xc1, 0xa3, 0x66, 0x4c, 0x9e, 0x01, 0x08, 0xc9, 0x05,
+	0x4a, 0x95, 0x62, 0xee, 0x57, 0x6f, 0xf6, 0x5e, 0x93, 0x92, 0x36, 0xd4, 0xb4, 0x64, 0x7e, 0xcd,
+	0x78, 0x15, 0x43, 0x72, 0x08, 0x75, 0x11, 0x4b, 0x64, 0xca, 0xdf, 0x33, 0x93, 0x16, 0x91, 0x10,
+	0x1a, 0x63, 0x14, 0xc8, 0xc6, 0xc8, 0x92, 0x62, 0x93, 0x5b, 0x9d, 0x5a, 0xd7, 0xa3, 0x1b, 0x73,
+	0x24, 0x00, 0x57, 0x48, 0x7e, 0x99, 0x8e, 0x51, 0xfa, 0x75, 0xb3, 0xba, 0xc4, 0x85, 0x6f, 0xa2,
+	0x73, 0xc5, 0x67, 0xfe, 0x7e, 0xc7, 0xe9, 0xba, 0xd4, 0x22, 0xf2, 0x02, 0x9a, 0x3c, 0x1b, 0x0f,
+	0x57, 0xa7, 0x77, 0x6f, 0x3e, 0xfd, 0xa6, 0x3a, 0xfc, 0x5d, 0x85, 0xf6, 0x2a, 0xe5, 0x9c, 0x6b,
+	0x99, 0xe0, 0xff, 0x88, 0x36, 0x22, 0x1a, 0xc2, 0x1d, 0x9b, 0xd0, 0x48, 0xc5, 0xc9, 0xb7, 0xe5,
+	0x3d, 0x7a, 0x0e, 0x9e, 0xb4, 0x81, 0xe5, 0xbe, 0xd3, 0xa9, 0x75, 0x0f, 0xfa, 0x47, 0x51, 0xf9,
+	0x3f, 0x44, 0xdb, 0xa1, 0xd2, 0x95, 0x3a, 0x7c, 0x0b, 0xb7, 0xcb, 0x4b, 0x99, 0x0b, 0xce, 0x72,
+	0x24, 0x4f, 0xc1, 0x9d, 0xc4, 0x69, 0xa6, 0x65, 0x69, 0x76, 0x7f, 0xd7, 0xec, 0xcd, 0x42, 0x41,
+	0x4b, 0x69, 0xf8, 0xcb, 0x81, 0xd6, 0x26, 0x69, 0x13, 0x2a, 0x0e, 0x3f, 0xb7, 0x05, 0x96, 0xb8,
+	0x48, 0x48, 0x62, 0x9c, 0x73, 0x66, 0x0a, 0xf4, 0xa8, 0x45, 0xe4, 0x14, 0xda, 0xc8, 0x26, 0x5c,
+	0x26, 0x38, 0x43, 0xa6, 0xce, 0xf1, 0x12, 0x33, 0x53, 0x58, 0x6b, 0xe3, 0x93, 0x4e, 0xb6, 0x24,
+	0x74, 0x67, 0xd1, 0xb2, 0xec, 0xbd, 0xb2, 0xec, 0xc7, 0x3d, 0x68, 0x6f, 0xaf, 0x23, 0x4d, 0xf0,
+	0x3e, 0x0c, 0x3e, 0x1e, 0x0f, 0x3e, 0x7f, 0xa2, 0x5f, 0xdb, 0x15, 0xd2, 0x00, 0x77, 0x70, 0xfc,
+	0xe5, 0x6c, 0x54, 0x20, 0xa7, 0xff, 0xa7, 0x0a, 0xee, 0x32, 0x3c, 0xf2, 0x0a, 0xf6, 0xed, 0x98,
+	0x5c, 0x91, 0x87, 0xad, 0x22, 0x08, 0xae, 0xa2, 0x16, 0xc1, 0x86, 0x15, 0x72, 0x0e, 0x8d, 0xf5,
+	0xfe, 0xc8, 0xc3, 0x5d, 0xf5, 0x7a, 0xb1, 0xff, 0x70, 0x7b, 0x09, 0xcd, 0x53, 0x54, 0x43, 0xf3,
+	0xd8, 0x9d, 0xb1, 0x09, 0x27, 0x87, 0x3b, 0xd7, 0xe8, 0xa4, 0x78, 0xeb, 0x82, 0xbb, 0x6b, 0x36,
+	0x2b, 0x79, 0x58, 0x21, 0xef, 0xc0, 0x2b, 0x1f, 0x38, 0xf2, 0x68, 0x4d, 0x75, 0xdd, 0xb3, 0x17,
+	0x5c, 0xb3, 0x45, 0x58, 0xb9, 0xa8, 0x9b, 0x99, 0x27, 0x7f, 0x07, 0x00, 0xf2, 0xb0, 0xb3, 0xe3,
+	0x99, 0x05, 0x00, 0x00,
 }
@@ -48,7 +48,7 @@ func (x DiffResponse_DiffChanges) String() string {
 	return proto.EnumName(DiffResponse_DiffChanges_name, int32(x))
 }
 func (DiffResponse_DiffChanges) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{8, 0}
+	return fileDescriptor_provider_6f9555814963b6b8, []int{8, 0}
 }
 
 type ConfigureRequest struct {
@@ -62,7 +62,7 @@ func (m *ConfigureRequest) Reset()         { *m = ConfigureRequest{} }
 func (m *ConfigureRequest) String() string { return proto.CompactTextString(m) }
 func (*ConfigureRequest) ProtoMessage()    {}
 func (*ConfigureRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{0}
+	return fileDescriptor_provider_6f9555814963b6b8, []int{0}
 }
 func (m *ConfigureRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigureRequest.Unmarshal(m, b)
@@ -101,7 +101,7 @@ func (m *ConfigureErrorMissingKeys) Reset()         { *m = ConfigureErrorMissing
 func (m *ConfigureErrorMissingKeys) String() string { return proto.CompactTextString(m) }
 func (*ConfigureErrorMissingKeys) ProtoMessage()    {}
 func (*ConfigureErrorMissingKeys) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{1}
+	return fileDescriptor_provider_6f9555814963b6b8, []int{1}
 }
 func (m *ConfigureErrorMissingKeys) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigureErrorMissingKeys.Unmarshal(m, b)
@@ -140,7 +140,7 @@ func (m *ConfigureErrorMissingKeys_MissingKey) Reset()         { *m = ConfigureE
 func (m *ConfigureErrorMissingKeys_MissingKey) String() string { return proto.CompactTextString(m) }
 func (*ConfigureErrorMissingKeys_MissingKey) ProtoMessage()    {}
 func (*ConfigureErrorMissingKeys_MissingKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{1, 0}
+	return fileDescriptor_provider_6f9555814963b6b8, []int{1, 0}
 }
 func (m *ConfigureErrorMissingKeys_MissingKey) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigureErrorMissingKeys_MissingKey.Unmarshal(m, b)
@@ -187,7 +187,7 @@ func (m *InvokeRequest) Reset()         { *m = InvokeRequest{} }
 func (m *InvokeRequest) String() string { return proto.CompactTextString(m) }
 func (*InvokeRequest) ProtoMessage()    {}
 func (*InvokeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{2}
+	return fileDescriptor_provider_6f9555814963b6b8, []int{2}
 }
 func (m *InvokeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InvokeRequest.Unmarshal(m, b)
@@ -240,7 +240,7 @@ func (m *InvokeResponse) Reset()         { *m = InvokeResponse{} }
 func (m *InvokeResponse) String() string { return proto.CompactTextString(m) }
 func (*InvokeResponse) ProtoMessage()    {}
 func (*InvokeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{3}
+	return fileDescriptor_provider_6f9555814963b6b8, []int{3}
 }
 func (m *InvokeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InvokeResponse.Unmarshal(m, b)
@@ -287,7 +287,7 @@ func (m *CheckRequest) Reset()         { *m = CheckRequest{} }
 func (m *CheckRequest) String() string { return proto.CompactTextString(m) }
 func (*CheckRequest) ProtoMessage()    {}
 func (*CheckRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{4}
+	return fileDescriptor_provider_6f9555814963b6b8, []int{4}
 }
 func (m *CheckRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckRequest.Unmarshal(m, b)
@@ -340,7 +340,7 @@ func (m *CheckResponse) Reset()         { *m = CheckResponse{} }
 func (m *CheckResponse) String() string { return proto.CompactTextString(m) }
 func (*CheckResponse) ProtoMessage()    {}
 func (*CheckResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{5}
+	return fileDescriptor_provider_6f9555814963b6b8, []int{5}
 }
 func (m *CheckResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckResponse.Unmarshal(m, b)
@@ -386,7 +386,7 @@ func (m *CheckFailure) Reset()         { *m = CheckFailure{} }
 func (m *CheckFailure) String() string { return proto.CompactTextString(m) }
 func (*CheckFailure) ProtoMessage()    {}
 func (*CheckFailure) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{6}
+	return fileDescriptor_provider_6f9555814963b6b8, []int{6}
 }
 func (m *CheckFailure) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckFailure.Unmarshal(m, b)
@@ -434,7 +434,7 @@ func (m *DiffRequest) Reset()         { *m = DiffRequest{} }
 func (m *DiffRequest) String() string { return proto.CompactTextString(m) }
 func (*DiffRequest) ProtoMessage()    {}
 func (*DiffRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{7}
+	return fileDescriptor_provider_6f9555814963b6b8, []int{7}
 }
 func (m *DiffRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DiffRequest.Unmarshal(m, b)
@@ -496,7 +496,7 @@ func (m *DiffResponse) Reset()         { *m = DiffResponse{} }
 func (m *DiffResponse) String() string { return proto.CompactTextString(m) }
 func (*DiffResponse) ProtoMessage()    {}
 func (*DiffResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{8}
+	return fileDescriptor_provider_6f9555814963b6b8, []int{8}
 }
 func (m *DiffResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DiffResponse.Unmarshal(m, b)
@@ -556,7 +556,7 @@ func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
 func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateRequest) ProtoMessage()    {}
 func (*CreateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{9}
+	return fileDescriptor_provider_6f9555814963b6b8, []int{9}
 }
 func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
@@ -602,7 +602,7 @@ func (m *CreateResponse) Reset()         { *m = CreateResponse{} }
 func (m *CreateResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateResponse) ProtoMessage()    {}
 func (*CreateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{10}
+	return fileDescriptor_provider_6f9555814963b6b8, []int{10}
 }
 func (m *CreateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateResponse.Unmarshal(m, b)
@@ -649,7 +649,7 @@ func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
 func (m *ReadRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadRequest) ProtoMessage()    {}
 func (*ReadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{11}
+	return fileDescriptor_provider_6f9555814963b6b8, []int{11}
 }
 func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadRequest.Unmarshal(m, b)
@@ -702,7 +702,7 @@ func (m *ReadResponse) Reset()         { *m = ReadResponse{} }
 func (m *ReadResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse) ProtoMessage()    {}
 func (*ReadResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{12}
+	return fileDescriptor_provider_6f9555814963b6b8, []int{12}
 }
 func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadResponse.Unmarshal(m, b)
@@ -750,7 +750,7 @@ func (m *UpdateRequest) Reset()         { *m = UpdateRequest{} }
 func (m *UpdateRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateRequest) ProtoMessage()    {}
 func (*UpdateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{13}
+	return fileDescriptor_provider_6f9555814963b6b8, []int{13}
 }
 func (m *UpdateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateRequest.Unmarshal(m, b)
@@ -809,7 +809,7 @@ func (m *UpdateResponse) Reset()         { *m = UpdateResponse{} }
 func (m *UpdateResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateResponse) ProtoMessage()    {}
 func (*UpdateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{14}
+	return fileDescriptor_provider_6f9555814963b6b8, []int{14}
 }
 func (m *UpdateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateResponse.Unmarshal(m, b)
@@ -849,7 +849,7 @@ func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
 func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRequest) ProtoMessage()    {}
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{15}
+	return fileDescriptor_provider_6f9555814963b6b8, []int{15}
 }
 func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
@@ -890,6 +890,169 @@ func (m *DeleteRequest) GetProperties() *_struct.Struct {
 	return nil
 }
 
+type GetLogsRequest struct {
+	Id                   string          `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Urn                  string          `protobuf:"bytes,2,opt,name=urn" json:"urn,omitempty"`
+	Properties           *_struct.Struct `protobuf:"bytes,3,opt,name=properties" json:"properties,omitempty"`
+	StartTime            int64           `protobuf:"varint,4,opt,name=startTime" json:"startTime,omitempty"`
+	EndTime              int64           `protobuf:"varint,5,opt,name=endTime" json:"endTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetLogsRequest) Reset()         { *m = GetLogsRequest{} }
+func (m *GetLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLogsRequest) ProtoMessage()    {}
+func (*GetLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_provider_6f9555814963b6b8, []int{16}
+}
+func (m *GetLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLogsRequest.Unmarshal(m, b)
+}
+func (m *GetLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLogsRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogsRequest.Merge(dst, src)
+}
+func (m *GetLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLogsRequest.Size(m)
+}
+func (m *GetLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLogsRequest proto.InternalMessageInfo
+
+func (m *GetLogsRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *GetLogsRequest) GetUrn() string {
+	if m != nil {
+		return m.Urn
+	}
+	return ""
+}
+
+func (m *GetLogsRequest) GetProperties() *_struct.Struct {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *GetLogsRequest) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *GetLogsRequest) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+type GetLogsResponse struct {
+	Entries              []*LogEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GetLogsResponse) Reset()         { *m = GetLogsResponse{} }
+func (m *GetLogsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetLogsResponse) ProtoMessage()    {}
+func (*GetLogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_provider_6f9555814963b6b8, []int{17}
+}
+func (m *GetLogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLogsResponse.Unmarshal(m, b)
+}
+func (m *GetLogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLogsResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetLogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLogsResponse.Merge(dst, src)
+}
+func (m *GetLogsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetLogsResponse.Size(m)
+}
+func (m *GetLogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLogsResponse proto.InternalMessageInfo
+
+func (m *GetLogsResponse) GetEntries() []*LogEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+// LogEntry is a single log entry emitted by a resource.
+type LogEntry struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Timestamp            int64    `protobuf:"varint,2,opt,name=timestamp" json:"timestamp,omitempty"`
+	Message              string   `protobuf:"bytes,3,opt,name=message" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogEntry) Reset()         { *m = LogEntry{} }
+func (m *LogEntry) String() string { return proto.CompactTextString(m) }
+func (*LogEntry) ProtoMessage()    {}
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_provider_6f9555814963b6b8, []int{18}
+}
+func (m *LogEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogEntry.Unmarshal(m, b)
+}
+func (m *LogEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogEntry.Marshal(b, m, deterministic)
+}
+func (dst *LogEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogEntry.Merge(dst, src)
+}
+func (m *LogEntry) XXX_Size() int {
+	return xxx_messageInfo_LogEntry.Size(m)
+}
+func (m *LogEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogEntry proto.InternalMessageInfo
+
+func (m *LogEntry) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *LogEntry) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *LogEntry) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
 // ErrorResourceInitFailed is sent as a Detail `ResourceProvider.{Create, Update}` fail because a
 // resource was created successfully, but failed to initialize.
 type ErrorResourceInitFailed struct {
@@ -905,7 +1068,7 @@ func (m *ErrorResourceInitFailed) Reset()         { *m = ErrorResourceInitFailed
 func (m *ErrorResourceInitFailed) String() string { return proto.CompactTextString(m) }
 func (*ErrorResourceInitFailed) ProtoMessage()    {}
 func (*ErrorResourceInitFailed) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_5951afc12b1894bc, []int{16}
+	return fileDescriptor_provider_6f9555814963b6b8, []int{19}
 }
 func (m *ErrorResourceInitFailed) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ErrorResourceInitFailed.Unmarshal(m, b)
@@ -965,6 +1128,9 @@ func init() {
 	proto.RegisterType((*UpdateRequest)(nil), "pulumirpc.UpdateRequest")
 	proto.RegisterType((*UpdateResponse)(nil), "pulumirpc.UpdateResponse")
 	proto.RegisterType((*DeleteRequest)(nil), "pulumirpc.DeleteRequest")
+	proto.RegisterType((*GetLogsRequest)(nil), "pulumirpc.GetLogsRequest")
+	proto.RegisterType((*GetLogsResponse)(nil), "pulumirpc.GetLogsResponse")
+	proto.RegisterType((*LogEntry)(nil), "pulumirpc.LogEntry")
 	proto.RegisterType((*ErrorResourceInitFailed)(nil), "pulumirpc.ErrorResourceInitFailed")
 	proto.RegisterEnum("pulumirpc.DiffResponse_DiffChanges", DiffResponse_DiffChanges_name, DiffResponse_DiffChanges_value)
 }
@@ -1006,6 +1172,9 @@ type ResourceProviderClient interface {
 	Cancel(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
 	// GetPluginInfo returns generic information about this plugin, like its version.
 	GetPluginInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PluginInfo, error)
+	// GetLogs returns the log entries emitted by a resource managed by this provider.  This is optional: providers
+	// that do not serve logs for a resource's type return UNIMPLEMENTED.
+	GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (*GetLogsResponse, error)
 }
 
 type resourceProviderClient struct {
@@ -1106,6 +1275,15 @@ func (c *resourceProviderClient) GetPluginInfo(ctx context.Context, in *empty.Em
 	return out, nil
 }
 
+func (c *resourceProviderClient) GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (*GetLogsResponse, error) {
+	out := new(GetLogsResponse)
+	err := grpc.Invoke(ctx, "/pulumirpc.ResourceProvider/GetLogs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ResourceProvider service
 
 type ResourceProviderServer interface {
@@ -1135,6 +1313,9 @@ type ResourceProviderServer interface {
 	Cancel(context.Context, *empty.Empty) (*empty.Empty, error)
 	// GetPluginInfo returns generic information about this plugin, like its version.
 	GetPluginInfo(context.Context, *empty.Empty) (*PluginInfo, error)
+	// GetLogs returns the log entries emitted by a resource managed by this provider.  This is optional: providers
+	// that do not serve logs for a resource's type return UNIMPLEMENTED.
+	GetLogs(context.Context, *GetLogsRequest) (*GetLogsResponse, error)
 }
 
 func RegisterResourceProviderServer(s *grpc.Server, srv ResourceProviderServer) {
@@ -1321,6 +1502,24 @@ func _ResourceProvider_GetPluginInfo_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ResourceProvider_GetLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceProviderServer).GetLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.ResourceProvider/GetLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceProviderServer).GetLogs(ctx, req.(*GetLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ResourceProvider_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pulumirpc.ResourceProvider",
 	HandlerType: (*ResourceProviderServer)(nil),
@@ -1365,69 +1564,80 @@ var _ResourceProvider_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetPluginInfo",
 			Handler:    _ResourceProvider_GetPluginInfo_Handler,
 		},
+		{
+			MethodName: "GetLogs",
+			Handler:    _ResourceProvider_GetLogs_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "provider.proto",
 }
 
-func init() { proto.RegisterFile("provider.proto", fileDescriptor_provider_5951afc12b1894bc) }
-
-var fileDescriptor_provider_5951afc12b1894bc = []byte{
-	// 889 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xc4, 0x56, 0xdd, 0x8e, 0xdb, 0x44,
-	0x14, 0x5e, 0x27, 0xd9, 0x74, 0x73, 0xf2, 0xa3, 0x68, 0x80, 0xdd, 0x34, 0xe5, 0xa2, 0x32, 0x37,
-	0x15, 0x48, 0x0e, 0x4a, 0x2f, 0x80, 0xaa, 0x15, 0x28, 0xdd, 0x2c, 0x44, 0x55, 0xb3, 0xc5, 0x55,
-	0x59, 0xc1, 0x0d, 0xf2, 0xda, 0x93, 0xac, 0x89, 0x63, 0x9b, 0xf1, 0x38, 0x28, 0x88, 0x17, 0x58,
-	0xf1, 0x06, 0x3c, 0x06, 0xcf, 0xc6, 0x03, 0x30, 0x9e, 0x1f, 0xc7, 0x93, 0x6c, 0xb2, 0x61, 0xb5,
-	0xa2, 0x77, 0x73, 0x7c, 0x7e, 0xbe, 0xf3, 0x9d, 0x73, 0xe6, 0x8c, 0xa1, 0x15, 0x93, 0x68, 0xe1,
-	0x7b, 0x98, 0x58, 0xec, 0x40, 0x23, 0x54, 0x8b, 0xd3, 0x20, 0x9d, 0xfb, 0x24, 0x76, 0xbb, 0x8d,
-	0x38, 0x48, 0xa7, 0x7e, 0x28, 0x14, 0xdd, 0x47, 0xd3, 0x28, 0x9a, 0x06, 0xb8, 0xc7, 0xa5, 0xcb,
-	0x74, 0xd2, 0xc3, 0xf3, 0x98, 0x2e, 0xa5, 0xf2, 0xe3, 0x75, 0x65, 0x42, 0x49, 0xea, 0x52, 0xa1,
-	0x35, 0xff, 0x32, 0xa0, 0xfd, 0x32, 0x0a, 0x27, 0xfe, 0x34, 0x25, 0xd8, 0xc6, 0xbf, 0xa6, 0x38,
-	0xa1, 0xe8, 0x3b, 0xa8, 0x2d, 0x1c, 0xe2, 0x3b, 0x97, 0x01, 0x4e, 0x3a, 0xc6, 0xe3, 0xf2, 0x93,
-	0x7a, 0xff, 0x53, 0x2b, 0x07, 0xb7, 0xd6, 0xed, 0xad, 0x1f, 0x94, 0xf1, 0x30, 0xa4, 0x64, 0x69,
-	0xaf, 0x9c, 0xbb, 0xcf, 0xa1, 0xa5, 0x2b, 0x51, 0x1b, 0xca, 0x33, 0xbc, 0x64, 0x51, 0x8d, 0x27,
-	0x35, 0x3b, 0x3b, 0xa2, 0x0f, 0xe1, 0x70, 0xe1, 0x04, 0x29, 0xee, 0x94, 0xf8, 0x37, 0x21, 0x3c,
-	0x2b, 0x7d, 0x69, 0x98, 0x7f, 0x1b, 0xf0, 0x30, 0x07, 0x1b, 0x12, 0x12, 0x91, 0xd7, 0x7e, 0x92,
-	0xf8, 0xe1, 0xf4, 0x15, 0x5e, 0x26, 0xe8, 0x7b, 0xa8, 0xcf, 0x57, 0xa2, 0xcc, 0xb3, 0x77, 0x53,
-	0x9e, 0xeb, 0xae, 0xd6, 0xea, 0x6c, 0x17, 0x63, 0x74, 0x07, 0x00, 0x2b, 0x15, 0x42, 0x50, 0x09,
-	0x9d, 0x39, 0x96, 0xb9, 0xf2, 0x33, 0x7a, 0x0c, 0x75, 0x0f, 0x27, 0x2e, 0xf1, 0x63, 0xea, 0x47,
-	0xa1, 0x4c, 0xb9, 0xf8, 0xc9, 0xfc, 0x05, 0x9a, 0xa3, 0x70, 0x11, 0xcd, 0xf2, 0x6a, 0x32, 0xc6,
-	0x34, 0x9a, 0x29, 0xc6, 0xec, 0x88, 0x3e, 0x83, 0x8a, 0x43, 0xa6, 0x09, 0xf7, 0xae, 0xf7, 0x4f,
-	0x2c, 0xd1, 0x21, 0x4b, 0x75, 0xc8, 0x7a, 0xcb, 0x3b, 0x64, 0x73, 0x23, 0xd4, 0x85, 0x23, 0x35,
-	0x07, 0x9d, 0x32, 0x8f, 0x91, 0xcb, 0xe6, 0x02, 0x5a, 0x0a, 0x2b, 0x89, 0xa3, 0x30, 0xc1, 0xa8,
-	0x07, 0x55, 0x82, 0x69, 0x4a, 0x42, 0x8e, 0xb7, 0x23, 0xb8, 0x34, 0x43, 0x4f, 0xe1, 0x68, 0xe2,
-	0xf8, 0x01, 0xab, 0x52, 0x96, 0x4f, 0x99, 0xbb, 0x14, 0x4a, 0x78, 0x85, 0xdd, 0xd9, 0x99, 0xd0,
-	0xdb, 0xb9, 0xa1, 0xf9, 0x3b, 0x34, 0xb8, 0xa6, 0x40, 0x51, 0x41, 0x32, 0x8a, 0x59, 0x58, 0x46,
-	0x31, 0x0a, 0xbc, 0xdb, 0x29, 0x66, 0x46, 0x99, 0x71, 0x88, 0x7f, 0x4b, 0x38, 0xbd, 0x5d, 0xc6,
-	0x99, 0x91, 0x99, 0x42, 0x53, 0x62, 0xaf, 0x28, 0xfb, 0x61, 0x9c, 0xd2, 0xe4, 0x56, 0xca, 0xc2,
-	0xec, 0x6e, 0x94, 0x07, 0x92, 0xb2, 0xd4, 0xc8, 0xb6, 0xc4, 0x98, 0x50, 0x35, 0xcc, 0xb9, 0x8c,
-	0x8e, 0xb3, 0x26, 0x38, 0x49, 0x3e, 0x1f, 0x52, 0x32, 0xaf, 0x0d, 0xa8, 0x9f, 0xfa, 0x93, 0x89,
-	0x2a, 0x5b, 0x0b, 0x4a, 0xbe, 0x27, 0xbd, 0xd9, 0x49, 0x95, 0xb1, 0xb4, 0x59, 0xc6, 0xf2, 0x7f,
-	0x29, 0x63, 0x65, 0x9f, 0x32, 0xfe, 0x63, 0x40, 0x43, 0xe4, 0x22, 0xcb, 0xc8, 0x08, 0x11, 0x1c,
-	0x07, 0x8e, 0x2b, 0xef, 0x3c, 0x23, 0xa4, 0x64, 0xd4, 0x81, 0x07, 0x09, 0x15, 0xeb, 0xa0, 0xc4,
-	0x55, 0x4a, 0x44, 0x9f, 0xc3, 0x07, 0x1e, 0x0e, 0x30, 0xc5, 0x03, 0x3c, 0x89, 0xb2, 0x8d, 0xc0,
-	0x3d, 0x78, 0xbe, 0x47, 0xf6, 0x4d, 0x2a, 0xf4, 0x02, 0x1e, 0xb8, 0x57, 0x4e, 0x38, 0xc5, 0x22,
-	0xd1, 0x56, 0xff, 0x93, 0x42, 0xf1, 0x8b, 0x19, 0x71, 0xe1, 0xa5, 0x30, 0xb5, 0x95, 0x8f, 0xf9,
-	0x42, 0x94, 0x50, 0x7e, 0x67, 0x25, 0x6b, 0x9c, 0x8e, 0xce, 0xce, 0x7e, 0x7e, 0x37, 0x7e, 0x35,
-	0x3e, 0xbf, 0x18, 0xb7, 0x0f, 0x50, 0x13, 0x6a, 0xfc, 0xcb, 0xf8, 0x7c, 0x3c, 0x6c, 0x1b, 0xb9,
-	0xf8, 0xf6, 0xfc, 0xf5, 0xb0, 0x5d, 0x32, 0x7f, 0x62, 0xd3, 0xc3, 0xba, 0x41, 0xf1, 0xf6, 0xd1,
-	0xfd, 0x02, 0x40, 0x76, 0xd2, 0xc7, 0xb7, 0x0e, 0x70, 0xc1, 0xd4, 0xfc, 0x11, 0x5a, 0x2a, 0xb6,
-	0xac, 0xe9, 0x7a, 0x83, 0xef, 0x1c, 0xfa, 0x0a, 0xea, 0x36, 0x76, 0xbc, 0xfd, 0x07, 0x47, 0x47,
-	0x2a, 0xef, 0x8f, 0x74, 0x01, 0x0d, 0x81, 0x74, 0xdf, 0x14, 0xfe, 0x34, 0xa0, 0xf9, 0x2e, 0xf6,
-	0x0a, 0xa5, 0x7f, 0x9f, 0xe3, 0x3f, 0x82, 0x96, 0x4a, 0x46, 0x12, 0xd5, 0x89, 0x19, 0xfb, 0x13,
-	0x63, 0x0b, 0xff, 0x94, 0xcf, 0xf9, 0xff, 0xd0, 0x9d, 0x3f, 0xe0, 0x84, 0x3f, 0x66, 0x2c, 0xeb,
-	0x28, 0x25, 0x2e, 0x1e, 0x85, 0x3e, 0xcd, 0x36, 0x12, 0xf6, 0xee, 0xad, 0x51, 0xd9, 0x65, 0x17,
-	0xfb, 0x2a, 0xcb, 0x8c, 0x5f, 0x76, 0x29, 0xf6, 0xaf, 0x0f, 0xa1, 0xad, 0x90, 0xdf, 0xc8, 0x37,
-	0x08, 0x0d, 0xa0, 0x96, 0x3f, 0xb4, 0xe8, 0xd1, 0x8e, 0xdf, 0x84, 0xee, 0xf1, 0x06, 0xfa, 0x30,
-	0xfb, 0x4f, 0x31, 0x0f, 0xd0, 0xd7, 0x50, 0x15, 0xef, 0x18, 0xea, 0x14, 0x02, 0x68, 0xcf, 0x68,
-	0xf7, 0xe1, 0x0d, 0x1a, 0xd1, 0x3a, 0x16, 0xe0, 0x39, 0x1c, 0xf2, 0xed, 0x8c, 0x36, 0x36, 0xb9,
-	0x72, 0xef, 0x6c, 0x2a, 0x72, 0xef, 0xaf, 0xa0, 0x92, 0xed, 0x14, 0x74, 0xbc, 0xb1, 0x89, 0x84,
-	0xef, 0xc9, 0x96, 0x0d, 0x25, 0x32, 0x17, 0x77, 0x5e, 0xcb, 0x5c, 0x5b, 0x31, 0x5a, 0xe6, 0xfa,
-	0x82, 0x10, 0xd8, 0xd9, 0x7d, 0xd3, 0xb0, 0x0b, 0x57, 0x5d, 0xc3, 0x2e, 0x5e, 0x4c, 0x81, 0x2d,
-	0x66, 0x58, 0xc3, 0xd6, 0xee, 0x98, 0x86, 0xad, 0x0f, 0x3c, 0xaf, 0x5a, 0x55, 0x4c, 0xae, 0x16,
-	0x40, 0x1b, 0xe6, 0x1d, 0x4d, 0x7b, 0xc6, 0xa8, 0x3b, 0xa1, 0x8b, 0x03, 0xb4, 0xc5, 0x66, 0x87,
-	0xef, 0x37, 0xd0, 0xfc, 0x16, 0xd3, 0x37, 0xfc, 0x27, 0x76, 0x14, 0x4e, 0xa2, 0xad, 0x21, 0x3e,
-	0x2a, 0x24, 0xb6, 0x32, 0x37, 0x0f, 0x2e, 0xab, 0xdc, 0xf0, 0xe9, 0xbf, 0x01, 0x00, 0x00, 0xff,
-	0xff, 0x2f, 0x82, 0x27, 0xcd, 0x25, 0x0b, 0x00, 0x00,
+func init() { proto.RegisterFile("provider.proto", fileDescriptor_provider_6f9555814963b6b8) }
+
+var fileDescriptor_provider_6f9555814963b6b8 = []byte{
+	// 1001 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xc4, 0x57, 0xdd, 0x72, 0xdb, 0x44,
+	0x14, 0x8e, 0xac, 0xc4, 0xb1, 0x8f, 0x7f, 0xf0, 0x6c, 0x21, 0x71, 0xd4, 0x5c, 0x64, 0xc4, 0x4d,
+	0x07, 0x06, 0x87, 0x49, 0x2f, 0x80, 0x4e, 0x3b, 0x74, 0x9c, 0x38, 0xc5, 0xd3, 0xd6, 0x29, 0x2a,
+	0xa5, 0x03, 0x37, 0x8c, 0x62, 0x1d, 0x2b, 0xaa, 0x65, 0x49, 0xec, 0xae, 0xcc, 0x84, 0xe1, 0x05,
+	0x18, 0xde, 0x80, 0x5b, 0xde, 0x80, 0xf7, 0xe0, 0x6d, 0x78, 0x00, 0x46, 0xfb, 0x23, 0x4b, 0xb1,
+	0xf3, 0x43, 0x27, 0xd0, 0x3b, 0x9d, 0xfd, 0xce, 0xd9, 0xef, 0xfc, 0xed, 0xd9, 0x15, 0xb4, 0x13,
+	0x1a, 0xcf, 0x03, 0x0f, 0x69, 0x2f, 0xa1, 0x31, 0x8f, 0x49, 0x3d, 0x49, 0xc3, 0x74, 0x16, 0xd0,
+	0x64, 0x6c, 0x35, 0x93, 0x30, 0xf5, 0x83, 0x48, 0x02, 0xd6, 0x5d, 0x3f, 0x8e, 0xfd, 0x10, 0xf7,
+	0x85, 0x74, 0x9a, 0x4e, 0xf6, 0x71, 0x96, 0xf0, 0x73, 0x05, 0xee, 0x5e, 0x04, 0x19, 0xa7, 0xe9,
+	0x98, 0x4b, 0xd4, 0xfe, 0xdd, 0x80, 0xce, 0x61, 0x1c, 0x4d, 0x02, 0x3f, 0xa5, 0xe8, 0xe0, 0x8f,
+	0x29, 0x32, 0x4e, 0xbe, 0x82, 0xfa, 0xdc, 0xa5, 0x81, 0x7b, 0x1a, 0x22, 0xeb, 0x1a, 0x7b, 0xe6,
+	0xbd, 0xc6, 0xc1, 0x47, 0xbd, 0x9c, 0xbc, 0x77, 0x51, 0xbf, 0xf7, 0xad, 0x56, 0x1e, 0x44, 0x9c,
+	0x9e, 0x3b, 0x0b, 0x63, 0xeb, 0x21, 0xb4, 0xcb, 0x20, 0xe9, 0x80, 0x39, 0xc5, 0xf3, 0xae, 0xb1,
+	0x67, 0xdc, 0xab, 0x3b, 0xd9, 0x27, 0x79, 0x1f, 0x36, 0xe6, 0x6e, 0x98, 0x62, 0xb7, 0x22, 0xd6,
+	0xa4, 0xf0, 0xa0, 0xf2, 0xb9, 0x61, 0xff, 0x69, 0xc0, 0x4e, 0x4e, 0x36, 0xa0, 0x34, 0xa6, 0xcf,
+	0x03, 0xc6, 0x82, 0xc8, 0x7f, 0x8a, 0xe7, 0x8c, 0x7c, 0x0d, 0x8d, 0xd9, 0x42, 0x54, 0x7e, 0xee,
+	0xaf, 0xf2, 0xf3, 0xa2, 0x69, 0x6f, 0xf1, 0xed, 0x14, 0xf7, 0xb0, 0xfa, 0x00, 0x0b, 0x88, 0x10,
+	0x58, 0x8f, 0xdc, 0x19, 0x2a, 0x5f, 0xc5, 0x37, 0xd9, 0x83, 0x86, 0x87, 0x6c, 0x4c, 0x83, 0x84,
+	0x07, 0x71, 0xa4, 0x5c, 0x2e, 0x2e, 0xd9, 0x6f, 0xa0, 0x35, 0x8c, 0xe6, 0xf1, 0x34, 0xcf, 0x66,
+	0x07, 0x4c, 0x1e, 0x4f, 0x75, 0xc4, 0x3c, 0x9e, 0x92, 0x8f, 0x61, 0xdd, 0xa5, 0x3e, 0x13, 0xd6,
+	0x8d, 0x83, 0xed, 0x9e, 0xac, 0x50, 0x4f, 0x57, 0xa8, 0xf7, 0x52, 0x54, 0xc8, 0x11, 0x4a, 0xc4,
+	0x82, 0x9a, 0xee, 0x83, 0xae, 0x29, 0xf6, 0xc8, 0x65, 0x7b, 0x0e, 0x6d, 0xcd, 0xc5, 0x92, 0x38,
+	0x62, 0x48, 0xf6, 0xa1, 0x4a, 0x91, 0xa7, 0x34, 0xea, 0x1a, 0x57, 0x6f, 0xae, 0xd4, 0xc8, 0x7d,
+	0xa8, 0x4d, 0xdc, 0x20, 0x4c, 0x29, 0x66, 0xfe, 0x98, 0xc2, 0xa4, 0x90, 0xc2, 0x33, 0x1c, 0x4f,
+	0x8f, 0x25, 0xee, 0xe4, 0x8a, 0xf6, 0xcf, 0xd0, 0x14, 0x48, 0x21, 0x44, 0x4d, 0x59, 0x77, 0xb2,
+	0xcf, 0x2c, 0xc4, 0x38, 0xf4, 0xae, 0x0f, 0x31, 0x53, 0xca, 0x94, 0x23, 0xfc, 0x89, 0x75, 0xcd,
+	0x6b, 0x94, 0x33, 0x25, 0x3b, 0x85, 0x96, 0xe2, 0x5e, 0x84, 0x1c, 0x44, 0x49, 0xca, 0xd9, 0xb5,
+	0x21, 0x4b, 0xb5, 0xb7, 0x0b, 0xb9, 0x0f, 0xcd, 0x22, 0xa2, 0xca, 0x92, 0x20, 0xe5, 0xba, 0x99,
+	0x73, 0x99, 0x6c, 0x65, 0x45, 0x70, 0x59, 0xde, 0x1f, 0x4a, 0xb2, 0x7f, 0x35, 0xa0, 0x71, 0x14,
+	0x4c, 0x26, 0x3a, 0x6d, 0x6d, 0xa8, 0x04, 0x9e, 0xb2, 0xae, 0x04, 0x9e, 0x4e, 0x63, 0x65, 0x39,
+	0x8d, 0xe6, 0xbf, 0x49, 0xe3, 0xfa, 0x4d, 0xd2, 0xf8, 0xb7, 0x01, 0x4d, 0xe9, 0x8b, 0x4a, 0xa3,
+	0x05, 0x35, 0x8a, 0x49, 0xe8, 0x8e, 0xd5, 0x99, 0xaf, 0x3b, 0xb9, 0x4c, 0xba, 0xb0, 0xc9, 0xb8,
+	0x1c, 0x07, 0x15, 0x01, 0x69, 0x91, 0x7c, 0x0a, 0x77, 0x3c, 0x0c, 0x91, 0x63, 0x1f, 0x27, 0x71,
+	0x36, 0x11, 0x84, 0x85, 0xf0, 0xb7, 0xe6, 0xac, 0x82, 0xc8, 0x23, 0xd8, 0x1c, 0x9f, 0xb9, 0x91,
+	0x8f, 0xd2, 0xd1, 0xf6, 0xc1, 0x87, 0x85, 0xe4, 0x17, 0x3d, 0x12, 0xc2, 0xa1, 0x54, 0x75, 0xb4,
+	0x8d, 0xfd, 0x08, 0x1a, 0x85, 0x75, 0xd2, 0x81, 0xe6, 0xd1, 0xf0, 0xf8, 0xf8, 0x87, 0x57, 0xa3,
+	0xa7, 0xa3, 0x93, 0xd7, 0xa3, 0xce, 0x1a, 0x69, 0x41, 0x5d, 0xac, 0x8c, 0x4e, 0x46, 0x83, 0x8e,
+	0x91, 0x8b, 0x2f, 0x4f, 0x9e, 0x0f, 0x3a, 0x15, 0xfb, 0x7b, 0x68, 0x1d, 0x52, 0x74, 0x39, 0x5e,
+	0xde, 0xba, 0x9f, 0x01, 0xa8, 0x4a, 0x06, 0x78, 0x6d, 0x03, 0x17, 0x54, 0xed, 0xef, 0xa0, 0xad,
+	0xf7, 0x56, 0x39, 0xbd, 0x58, 0xe0, 0xb7, 0xde, 0xfa, 0x0c, 0x1a, 0x0e, 0xba, 0xde, 0xcd, 0x1b,
+	0xa7, 0xcc, 0x64, 0xde, 0x9c, 0xe9, 0x35, 0x34, 0x25, 0xd3, 0x6d, 0x87, 0xf0, 0x9b, 0x01, 0xad,
+	0x57, 0x89, 0x57, 0x48, 0xfd, 0xbb, 0x6c, 0xff, 0x21, 0xb4, 0xb5, 0x33, 0x2a, 0xd0, 0x72, 0x60,
+	0xc6, 0xcd, 0x03, 0x7b, 0x03, 0xad, 0x23, 0xd1, 0xe7, 0xff, 0x43, 0x75, 0xfe, 0x30, 0xa0, 0xfd,
+	0x04, 0xf9, 0xb3, 0xd8, 0x67, 0xff, 0x3d, 0x1b, 0xd9, 0x85, 0x3a, 0xe3, 0x2e, 0xe5, 0xdf, 0x04,
+	0x33, 0x14, 0x69, 0x35, 0x9d, 0xc5, 0x42, 0x36, 0x14, 0x30, 0xf2, 0x04, 0xb6, 0x21, 0x30, 0x2d,
+	0xda, 0x8f, 0xe1, 0xbd, 0xdc, 0x49, 0x95, 0xdd, 0x4f, 0x32, 0x65, 0x4e, 0x83, 0xfc, 0x41, 0x71,
+	0xa7, 0x70, 0xea, 0x9f, 0xc5, 0xbe, 0x7c, 0x39, 0x68, 0x1d, 0xdb, 0x81, 0x9a, 0x5e, 0x5c, 0x0a,
+	0x70, 0x17, 0xea, 0x3c, 0x98, 0x21, 0xe3, 0xee, 0x2c, 0x11, 0x61, 0x9a, 0xce, 0x62, 0x21, 0xf3,
+	0x6a, 0x86, 0x8c, 0xb9, 0x3e, 0xaa, 0xdb, 0x52, 0x8b, 0xf6, 0x2f, 0xb0, 0x2d, 0x1e, 0x02, 0x0e,
+	0xb2, 0x38, 0xa5, 0x63, 0x1c, 0x46, 0x01, 0xcf, 0xa6, 0x39, 0x7a, 0xb7, 0xd6, 0xe4, 0x19, 0xbb,
+	0x9c, 0xf5, 0x59, 0x9e, 0xc5, 0xa0, 0x54, 0xe2, 0xc1, 0x5f, 0x1b, 0xd0, 0xd1, 0xcc, 0x2f, 0xd4,
+	0xfd, 0x4d, 0xfa, 0x50, 0xcf, 0x1f, 0x29, 0xe4, 0xee, 0x15, 0x4f, 0x2c, 0x6b, 0x6b, 0x89, 0x7d,
+	0x90, 0xbd, 0xf1, 0xec, 0x35, 0xf2, 0x25, 0x54, 0xe5, 0x1b, 0x80, 0x74, 0x0b, 0x1b, 0x94, 0x9e,
+	0x20, 0xd6, 0xce, 0x0a, 0x44, 0x16, 0xc6, 0x5e, 0x23, 0x0f, 0x61, 0x43, 0xdc, 0x6c, 0x64, 0xe9,
+	0x16, 0xd4, 0xe6, 0xdd, 0x65, 0x20, 0xb7, 0xfe, 0x02, 0xd6, 0xb3, 0x79, 0x4c, 0xb6, 0x96, 0xa6,
+	0xb8, 0xb4, 0xdd, 0xbe, 0x64, 0xba, 0x4b, 0xcf, 0xe5, 0xbc, 0x2c, 0x79, 0x5e, 0x1a, 0xcf, 0xd6,
+	0xce, 0x0a, 0xa4, 0xc8, 0x9d, 0xcd, 0xaa, 0x12, 0x77, 0x61, 0x4c, 0x5a, 0xdb, 0x4b, 0xeb, 0x45,
+	0x6e, 0x79, 0xfe, 0x4b, 0xdc, 0xa5, 0xf9, 0x64, 0xed, 0xac, 0x40, 0x0a, 0x59, 0xab, 0xca, 0x53,
+	0x5f, 0xda, 0xa0, 0x34, 0x08, 0xae, 0x28, 0xda, 0x03, 0xa8, 0x1e, 0xba, 0xd1, 0x18, 0x43, 0x72,
+	0x89, 0xce, 0x15, 0xb6, 0x8f, 0xa1, 0xf5, 0x04, 0xf9, 0x0b, 0xf1, 0x03, 0x30, 0x8c, 0x26, 0xf1,
+	0xa5, 0x5b, 0x7c, 0x50, 0x70, 0x6c, 0xa1, 0x6e, 0xaf, 0x91, 0x3e, 0x6c, 0xaa, 0xf3, 0x49, 0x8a,
+	0x31, 0x96, 0x07, 0x8b, 0x65, 0xad, 0x82, 0x74, 0xfc, 0xa7, 0x55, 0x41, 0x76, 0xff, 0x9f, 0x01,
+	0x00, 0x95, 0xc2, 0xa5, 0x0e, 0xa5, 0x0c, 0x00, 0x00,
 }
@@ -137,8 +137,29 @@ func newLanguageHost(exec, engineAddress, tracing string) pulumirpc.LanguageRunt
 // GetRequiredPlugins computes the complete set of anticipated plugins required by a program.
 func (host *pythonLanguageHost) GetRequiredPlugins(ctx context.Context,
 	req *pulumirpc.GetRequiredPluginsRequest) (*pulumirpc.GetRequiredPluginsResponse, error) {
-	// TODO: implement this.
-	return &pulumirpc.GetRequiredPluginsResponse{}, nil
+	// To get the plugins required by a program, find all of the Pulumi provider packages, such as `pulumi-aws`,
+	// installed where the program's interpreter will look for them.  As with Node.js, a program could load packages
+	// from elsewhere, and we'd miss them; the solution for that is simple: install the packages as usual.
+	sysPath, err := getSysPath(getPythonCmd(), req.GetPwd())
+	if err != nil {
+		logging.V(3).Infof("could not discover plugins: %s", err)
+		return &pulumirpc.GetRequiredPluginsResponse{}, nil
+	}
+	plugins, err := getPluginsFromDirs(sysPath)
+	if err != nil {
+		logging.V(3).Infof("one or more errors while discovering plugins: %s", err)
+	}
+	return &pulumirpc.GetRequiredPluginsResponse{
+		Plugins: plugins,
+	}, nil
+}
+
+// getPythonCmd returns the Python interpreter used to run programs.
+func getPythonCmd() string {
+	if pythonCmd := os.Getenv("PULUMI_PYTHON_CMD"); pythonCmd != "" {
+		return pythonCmd
+	}
+	return "python"
 }
 
 // RPC endpoint for LanguageRuntimeServer::Run
@@ -159,12 +180,7 @@ func (host *pythonLanguageHost) Run(ctx context.Context, req *pulumirpc.RunReque
 
 	// Now simply spawn a process to execute the requested program, wiring up stdout/stderr directly.
 	var errResult string
-	pythonCmd := os.Getenv("PULUMI_PYTHON_CMD")
-	if pythonCmd == "" {
-		pythonCmd = "python"
-	}
-
-	cmd := exec.Command(pythonCmd, args...) // nolint: gas, intentionally running dynamic program name.
+	cmd := exec.Command(getPythonCmd(), args...) // nolint: gas, intentionally running dynamic program name.
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if config != "" {
@@ -0,0 +1,179 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+// printSysPath is a Python program, compatible with Python 2 and 3, that prints the interpreter's module search path.
+const printSysPath = "import json, sys; print(json.dumps([p for p in sys.path if p]))"
+
+// getSysPath returns the directories the given Python interpreter searches for packages, when run in the given
+// directory.  Running the interpreter, rather than guessing, means that virtual environments are respected.
+func getSysPath(pythonCmd string, dir string) ([]string, error) {
+	cmd := exec.Command(pythonCmd, "-c", printSysPath) // nolint: gas, intentionally running dynamic program name.
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "running %s to find installed packages", pythonCmd)
+	}
+
+	var paths []string
+	if err = json.Unmarshal(out, &paths); err != nil {
+		return nil, errors.Wrapf(err, "parsing search path reported by %s", pythonCmd)
+	}
+	return paths, nil
+}
+
+// getPluginsFromDirs returns the resource provider plugins required by the Pulumi provider packages, e.g.
+// `pulumi-aws`, installed in the given directories, as described by the packages' pip metadata (their `*.dist-info`
+// or `*.egg-info` entries).  Directories are searched in order; when a package is installed in more than one, the
+// first wins, just as it would for an import.
+func getPluginsFromDirs(dirs []string) ([]*pulumirpc.PluginDependency, error) {
+	var plugins []*pulumirpc.PluginDependency
+	var allErrors *multierror.Error
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			// Entries of the search path, such as zip files or missing directories, need not be directories.
+			continue
+		}
+		for _, file := range files {
+			name := file.Name()
+			ext := filepath.Ext(name)
+			if ext != ".dist-info" && ext != ".egg-info" {
+				continue
+			}
+
+			pkg, version, err := readPackageMetadata(filepath.Join(dir, name), file.IsDir())
+			if err != nil {
+				allErrors = multierror.Append(allErrors, err)
+				continue
+			}
+			plugin, ok := getPluginName(pkg)
+			if !ok || seen[plugin] {
+				continue
+			}
+			seen[plugin] = true
+			plugins = append(plugins, &pulumirpc.PluginDependency{
+				Name:    plugin,
+				Kind:    "resource",
+				Version: getPluginVersion(version),
+			})
+		}
+	}
+	return plugins, allErrors.ErrorOrNil()
+}
+
+// readPackageMetadata reads the name and version of a package from its `*.dist-info` directory or its `*.egg-info`
+// directory or file.
+func readPackageMetadata(path string, isDir bool) (string, string, error) {
+	metadata := path
+	if isDir {
+		metadata = filepath.Join(path, "METADATA")
+		if filepath.Ext(path) == ".egg-info" {
+			metadata = filepath.Join(path, "PKG-INFO")
+		}
+	}
+
+	f, err := os.Open(metadata)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "reading package metadata %s", metadata)
+	}
+	defer contract.IgnoreClose(f)
+
+	name, version, err := parsePackageMetadata(f)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "parsing package metadata %s", metadata)
+	}
+	return name, version, nil
+}
+
+// parsePackageMetadata extracts the name and version from the headers of a package's metadata, which are formatted
+// as email headers.
+func parsePackageMetadata(r io.Reader) (string, string, error) {
+	var name, version string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			// The headers end at the first blank line; the description follows.
+			break
+		}
+		if i := strings.Index(line, ":"); i > 0 {
+			switch strings.TrimSpace(line[:i]) {
+			case "Name":
+				name = strings.TrimSpace(line[i+1:])
+			case "Version":
+				version = strings.TrimSpace(line[i+1:])
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", "", err
+	}
+	if name == "" {
+		return "", "", errors.New("missing expected \"Name\" header")
+	}
+	return name, version, nil
+}
+
+// nonProviderPackages are the names, less their `pulumi-` prefix, of Pulumi packages that are not provider packages and
+// so have no resource plugin.
+var nonProviderPackages = map[string]bool{
+	"policy":           true,
+	"terraform":        true,
+	"terraform-bridge": true,
+}
+
+// getPluginName returns the name of the plugin corresponding to the given Python package, if it is a Pulumi provider
+// package.  Package names are normalized as pip does, so `pulumi_aws` and `Pulumi-AWS` are both the `aws` plugin.
+func getPluginName(pkg string) (string, bool) {
+	normalized := strings.ToLower(strings.NewReplacer("_", "-", ".", "-").Replace(pkg))
+	if !strings.HasPrefix(normalized, "pulumi-") {
+		return "", false
+	}
+	name := strings.TrimPrefix(normalized, "pulumi-")
+	if name == "" || nonProviderPackages[name] {
+		return "", false
+	}
+	return name, true
+}
+
+// getPluginVersion converts a package version into the semantic version of the corresponding plugin.  Versions that
+// are not also semantic versions, such as many PEP 440 pre-releases, yield the empty string, leaving the engine to
+// choose a version.
+func getPluginVersion(version string) string {
+	if _, err := semver.Parse(version); err != nil {
+		return ""
+	}
+	return "v" + version
+}
@@ -0,0 +1,78 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+func TestGetPluginsFromDirs(t *testing.T) {
+	newDir := func() string {
+		dir, err := ioutil.TempDir("", "pulumi-python-plugins")
+		assert.NoError(t, err)
+		return dir
+	}
+	writeFile := func(path, contents string) {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+	}
+
+	venv, site := newDir(), newDir()
+	defer os.RemoveAll(venv)
+	defer os.RemoveAll(site)
+
+	writeFile(filepath.Join(venv, "pulumi_aws-0.16.2.dist-info", "METADATA"),
+		"Metadata-Version: 2.1\nName: pulumi_aws\nVersion: 0.16.2\n\nName: not-a-header\n")
+	writeFile(filepath.Join(venv, "pulumi-0.16.0.dist-info", "METADATA"), "Name: pulumi\nVersion: 0.16.0\n")
+	writeFile(filepath.Join(venv, "requests-2.19.1.dist-info", "METADATA"), "Name: requests\nVersion: 2.19.1\n")
+	writeFile(filepath.Join(venv, "Pulumi_Random-0.2.0.egg-info", "PKG-INFO"),
+		"Name: Pulumi-Random\nVersion: 0.2.0a1538513523\n")
+	writeFile(filepath.Join(venv, "pulumi_aws", "__init__.py"), "")
+
+	// Packages installed earlier on the search path hide those installed later.
+	writeFile(filepath.Join(site, "pulumi_aws-0.15.0.dist-info", "METADATA"), "Name: pulumi-aws\nVersion: 0.15.0\n")
+	writeFile(filepath.Join(site, "pulumi_gcp-0.16.0-py2.7.egg-info"), "Name: pulumi-gcp\nVersion: 0.16.0\n")
+
+	plugins, err := getPluginsFromDirs([]string{venv, filepath.Join(venv, "missing"), site})
+	assert.NoError(t, err)
+	assert.Equal(t, []*pulumirpc.PluginDependency{
+		{Name: "random", Kind: "resource"},
+		{Name: "aws", Kind: "resource", Version: "v0.16.2"},
+		{Name: "gcp", Kind: "resource", Version: "v0.16.0"},
+	}, plugins)
+}
+
+func TestGetPluginName(t *testing.T) {
+	for pkg, expected := range map[string]string{
+		"pulumi-aws":        "aws",
+		"pulumi_kubernetes": "kubernetes",
+		"Pulumi.Azure":      "azure",
+	} {
+		name, ok := getPluginName(pkg)
+		assert.True(t, ok, pkg)
+		assert.Equal(t, expected, name)
+	}
+	for _, pkg := range []string{"pulumi", "pulumi-", "requests", "pulumiaws", "pulumi-policy", "pulumi_terraform"} {
+		_, ok := getPluginName(pkg)
+		assert.False(t, ok, pkg)
+	}
+}
@@ -22,11 +22,21 @@ class AnalyzerStub(object):
         request_serializer=analyzer__pb2.AnalyzeRequest.SerializeToString,
         response_deserializer=analyzer__pb2.AnalyzeResponse.FromString,
         )
+    self.AnalyzeStack = channel.unary_unary(
+        '/pulumirpc.Analyzer/AnalyzeStack',
+        request_serializer=analyzer__pb2.AnalyzeStackRequest.SerializeToString,
+        response_deserializer=analyzer__pb2.AnalyzeResponse.FromString,
+        )
     self.GetPluginInfo = channel.unary_unary(
         '/pulumirpc.Analyzer/GetPluginInfo',
         request_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
         response_deserializer=plugin__pb2.PluginInfo.FromString,
         )
+    self.Configure = channel.unary_unary(
+        '/pulumirpc.Analyzer/Configure',
+        request_serializer=analyzer__pb2.ConfigureAnalyzerRequest.SerializeToString,
+        response_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
+        )
 
 
 class AnalyzerServicer(object):
@@ -41,6 +51,16 @@ class AnalyzerServicer(object):
     context.set_details('Method not implemented!')
     raise NotImplementedError('Method not implemented!')
 
+  def AnalyzeStack(self, request, context):
+    """AnalyzeStack analyzes all of the resources in a stack at once, after the program has registered all of them,
+    and returns any errors that it finds.  This allows analyzers to check invariants that span multiple resources.
+    Before an update changes anything, the engine runs the program as a preview and analyzes the resources that
+    it proposes, so that mandatory failures block the update before any step executes.
+    """
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
   def GetPluginInfo(self, request, context):
     """GetPluginInfo returns generic information about this plugin, like its version.
     """
@@ -48,6 +68,14 @@ class AnalyzerServicer(object):
     context.set_details('Method not implemented!')
     raise NotImplementedError('Method not implemented!')
 
+  def Configure(self, request, context):
+    """Configure passes the analyzer its settings from the `policy` section of the project and stack files.  It is
+    called once, before any resources are analyzed.
+    """
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
 
 def add_AnalyzerServicer_to_server(servicer, server):
   rpc_method_handlers = {
@@ -56,11 +84,21 @@ def add_AnalyzerServicer_to_server(servicer, server):
           request_deserializer=analyzer__pb2.AnalyzeRequest.FromString,
           response_serializer=analyzer__pb2.AnalyzeResponse.SerializeToString,
       ),
+      'AnalyzeStack': grpc.unary_unary_rpc_method_handler(
+          servicer.AnalyzeStack,
+          request_deserializer=analyzer__pb2.AnalyzeStackRequest.FromString,
+          response_serializer=analyzer__pb2.AnalyzeResponse.SerializeToString,
+      ),
       'GetPluginInfo': grpc.unary_unary_rpc_method_handler(
           servicer.GetPluginInfo,
           request_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
           response_serializer=plugin__pb2.PluginInfo.SerializeToString,
       ),
+      'Configure': grpc.unary_unary_rpc_method_handler(
+          servicer.Configure,
+          request_deserializer=analyzer__pb2.ConfigureAnalyzerRequest.FromString,
+          response_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
+      ),
   }
   generic_handler = grpc.method_handlers_generic_handler(
       'pulumirpc.Analyzer', rpc_method_handlers)
@@ -67,6 +67,11 @@ class ResourceProviderStub(object):
         request_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
         response_deserializer=plugin__pb2.PluginInfo.FromString,
         )
+    self.GetLogs = channel.unary_unary(
+        '/pulumirpc.ResourceProvider/GetLogs',
+        request_serializer=provider__pb2.GetLogsRequest.SerializeToString,
+        response_deserializer=provider__pb2.GetLogsResponse.FromString,
+        )
 
 
 class ResourceProviderServicer(object):
@@ -150,6 +155,14 @@ class ResourceProviderServicer(object):
     context.set_details('Method not implemented!')
     raise NotImplementedError('Method not implemented!')
 
+  def GetLogs(self, request, context):
+    """GetLogs returns the log entries emitted by a resource managed by this provider.  This is optional: providers
+    that do not serve logs for a resource's type return UNIMPLEMENTED.
+    """
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
 
 def add_ResourceProviderServicer_to_server(servicer, server):
   rpc_method_handlers = {
@@ -203,6 +216,11 @@ def add_ResourceProviderServicer_to_server(servicer, server):
           request_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
           response_serializer=plugin__pb2.PluginInfo.SerializeToString,
       ),
+      'GetLogs': grpc.unary_unary_rpc_method_handler(
+          servicer.GetLogs,
+          request_deserializer=provider__pb2.GetLogsRequest.FromString,
+          response_serializer=provider__pb2.GetLogsResponse.SerializeToString,
+      ),
   }
   generic_handler = grpc.method_handlers_generic_handler(
       'pulumirpc.ResourceProvider', rpc_method_handlers)